@@ -0,0 +1,170 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathfhir
+
+// coreResourceTypes contains the elements of the commonly used FHIR R4
+// resources whose values cannot be converted without knowing their type
+// (mostly temporal values). A type registry that has been loaded from the
+// StructureDefinitions of the resources replaces these types.
+var coreResourceTypes = []builtInType{
+	{"AllergyIntolerance", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("onset[x]", false, "dateTime", "Age", "Period", "Range", "string"),
+		NewElementDefinition("recordedDate", false, "dateTime"),
+		NewElementDefinition("lastOccurrence", false, "dateTime"),
+	}},
+	{"Appointment", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("start", false, "instant"),
+		NewElementDefinition("end", false, "instant"),
+		NewElementDefinition("created", false, "dateTime"),
+		NewElementDefinition("requestedPeriod", true, "Period"),
+	}},
+	{"AuditEvent", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("period", false, "Period"),
+		NewElementDefinition("recorded", false, "instant"),
+	}},
+	{"CarePlan", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("period", false, "Period"),
+		NewElementDefinition("created", false, "dateTime"),
+	}},
+	{"Claim", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("billablePeriod", false, "Period"),
+		NewElementDefinition("created", false, "dateTime"),
+	}},
+	{"Communication", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("sent", false, "dateTime"),
+		NewElementDefinition("received", false, "dateTime"),
+	}},
+	{"Composition", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("date", false, "dateTime"),
+	}},
+	{"Condition", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("onset[x]", false, "dateTime", "Age", "Period", "Range", "string"),
+		NewElementDefinition("abatement[x]", false, "dateTime", "Age", "Period", "Range", "string"),
+		NewElementDefinition("recordedDate", false, "dateTime"),
+	}},
+	{"Consent", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("dateTime", false, "dateTime"),
+	}},
+	{"Coverage", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("period", false, "Period"),
+	}},
+	{"DiagnosticReport", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("effective[x]", false, "dateTime", "Period"),
+		NewElementDefinition("issued", false, "instant"),
+	}},
+	{"DocumentReference", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("date", false, "instant"),
+	}},
+	{"Encounter", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("period", false, "Period"),
+	}},
+	{"FamilyMemberHistory", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("date", false, "dateTime"),
+		NewElementDefinition("born[x]", false, "Period", "date", "string"),
+		NewElementDefinition("deceased[x]", false, "boolean", "Age", "Range", "date", "string"),
+	}},
+	{"Flag", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("period", false, "Period"),
+	}},
+	{"Goal", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("start[x]", false, "date", "CodeableConcept"),
+		NewElementDefinition("statusDate", false, "date"),
+	}},
+	{"Immunization", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("occurrence[x]", false, "dateTime", "string"),
+		NewElementDefinition("recorded", false, "dateTime"),
+		NewElementDefinition("expirationDate", false, "date"),
+	}},
+	{"MedicationAdministration", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("effective[x]", false, "dateTime", "Period"),
+	}},
+	{"MedicationDispense", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("whenPrepared", false, "dateTime"),
+		NewElementDefinition("whenHandedOver", false, "dateTime"),
+	}},
+	{"MedicationRequest", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("authoredOn", false, "dateTime"),
+	}},
+	{"MedicationStatement", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("effective[x]", false, "dateTime", "Period"),
+		NewElementDefinition("dateAsserted", false, "dateTime"),
+	}},
+	{"Observation", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("identifier", true, "Identifier"),
+		NewElementDefinition("code", false, "CodeableConcept"),
+		NewElementDefinition("effective[x]", false, "dateTime", "Period", "Timing", "instant"),
+		NewElementDefinition("issued", false, "instant"),
+		NewElementDefinition("value[x]", false, "Quantity", "CodeableConcept", "string", "boolean", "integer", "Range", "Ratio", "SampledData", "time", "dateTime", "Period"),
+	}},
+	{"Patient", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("identifier", true, "Identifier"),
+		NewElementDefinition("active", false, "boolean"),
+		NewElementDefinition("name", true, "HumanName"),
+		NewElementDefinition("telecom", true, "ContactPoint"),
+		NewElementDefinition("gender", false, "code"),
+		NewElementDefinition("birthDate", false, "date"),
+		NewElementDefinition("deceased[x]", false, "boolean", "dateTime"),
+		NewElementDefinition("address", true, "Address"),
+		NewElementDefinition("maritalStatus", false, "CodeableConcept"),
+		NewElementDefinition("multipleBirth[x]", false, "boolean", "integer"),
+		NewElementDefinition("photo", true, "Attachment"),
+	}},
+	{"Person", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("birthDate", false, "date"),
+	}},
+	{"Practitioner", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("birthDate", false, "date"),
+	}},
+	{"Procedure", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("performed[x]", false, "dateTime", "Period", "string", "Age", "Range"),
+	}},
+	{"Provenance", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("occurred[x]", false, "Period", "dateTime"),
+		NewElementDefinition("recorded", false, "instant"),
+	}},
+	{"QuestionnaireResponse", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("authored", false, "dateTime"),
+	}},
+	{"RelatedPerson", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("birthDate", false, "date"),
+		NewElementDefinition("period", false, "Period"),
+	}},
+	{"ServiceRequest", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("occurrence[x]", false, "dateTime", "Period", "Timing"),
+		NewElementDefinition("authoredOn", false, "dateTime"),
+	}},
+	{"Specimen", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("receivedTime", false, "dateTime"),
+	}},
+	{"Task", "DomainResource", []ElementDefinitionAccessor{
+		NewElementDefinition("executionPeriod", false, "Period"),
+		NewElementDefinition("authoredOn", false, "dateTime"),
+		NewElementDefinition("lastModified", false, "dateTime"),
+	}},
+}
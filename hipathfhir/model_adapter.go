@@ -0,0 +1,515 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathfhir
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/healthiop/hipath/hipathsys"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const extensionElementPrefix = "_"
+const containedElementName = "contained"
+
+type modelAdapter struct {
+	registry          *TypeRegistry
+	maxCollectionSize int
+}
+
+// NewModelAdapter returns a model adapter for FHIR resources that have been
+// decoded from JSON. If no type registry is specified, the registry that is
+// returned by NewTypeRegistry is used, which knows only the elements of a
+// limited set of resources.
+func NewModelAdapter(registry *TypeRegistry) hipathsys.ModelAdapter {
+	if registry == nil {
+		registry = NewTypeRegistry()
+	}
+	return &modelAdapter{registry: registry}
+}
+
+func (a *modelAdapter) BindMaxCollectionSize(maxSize int) hipathsys.ModelAdapter {
+	ba := *a
+	ba.maxCollectionSize = maxSize
+	return &ba
+}
+
+func (a *modelAdapter) newCollection() hipathsys.CollectionModifier {
+	return hipathsys.NewCollectionWithMaxSize(a, a.maxCollectionSize)
+}
+
+func (a *modelAdapter) ConvertToSystem(node interface{}) (interface{}, error) {
+	switch n := node.(type) {
+	case *elementNode:
+		return elementSystemNode(n)
+	case *primitiveNode:
+		return primitiveSystemNode(n)
+	case map[string]interface{}:
+		return elementSystemNode(a.newElementNode(n, "", nil, nil))
+	case []interface{}:
+		return nil, fmt.Errorf("JSON array cannot be converted to a single node")
+	}
+	return primitiveSystemNode(a.newPrimitiveNode(node, nil, nil))
+}
+
+func (a *modelAdapter) TypeSpec(node interface{}) hipathsys.TypeSpecAccessor {
+	switch n := node.(type) {
+	case hipathsys.AnyAccessor:
+		if s, ok := n.Source().(NodeAccessor); ok {
+			return s.TypeSpec()
+		}
+		return hipathsys.UndefinedTypeSpec
+	case NodeAccessor:
+		return n.TypeSpec()
+	case map[string]interface{}:
+		return a.newElementNode(n, "", nil, nil).typeSpec
+	}
+	return a.newPrimitiveNode(node, nil, nil).typeSpec
+}
+
+func (a *modelAdapter) Cast(node interface{}, name hipathsys.FQTypeNameAccessor) (interface{}, error) {
+	if a.TypeSpec(node).ExtendsName(name) {
+		return node, nil
+	}
+	return nil, nil
+}
+
+func (a *modelAdapter) Equal(node1 interface{}, node2 interface{}) bool {
+	return jsonValueEqual(JSONValue(node1), JSONValue(node2), false)
+}
+
+func (a *modelAdapter) Equivalent(node1 interface{}, node2 interface{}) bool {
+	return jsonValueEqual(JSONValue(node1), JSONValue(node2), true)
+}
+
+func (a *modelAdapter) Navigate(node interface{}, name string) (interface{}, error) {
+	if col, ok := node.(hipathsys.CollectionAccessor); ok {
+		return a.navigateCollection(col, name)
+	}
+
+	e := a.elementOf(node)
+	if e == nil || e.value == nil {
+		return hipathsys.EmptyCollection, nil
+	}
+
+	value, found := e.value[name]
+	ext, extFound := e.value[extensionElementPrefix+name]
+	if !found && !extFound {
+		if e.resource() && e.typeSpec.ExtendsName(hipathsys.NewTypeName(name)) {
+			return e, nil
+		}
+		return a.navigateChoice(e, name)
+	}
+
+	return a.newChildNode(e, name, value, ext)
+}
+
+func (a *modelAdapter) navigateChoice(e *elementNode, name string) (interface{}, error) {
+	def := a.registry.ElementDefinition(e.defPath, name)
+	if def != nil {
+		if !def.Choice() {
+			return hipathsys.EmptyCollection, nil
+		}
+		for _, typeName := range def.TypeNames() {
+			n := name + ChoiceTypeSuffix(typeName)
+			value, found := e.value[n]
+			ext, extFound := e.value[extensionElementPrefix+n]
+			if found || extFound {
+				return a.newTypedNode(e, value, ext, typeName)
+			}
+		}
+		return hipathsys.EmptyCollection, nil
+	}
+
+	// element is unknown, type is determined by the suffix of a matching element
+	names := make([]string, 0)
+	for n := range e.value {
+		if len(n) > len(name) && strings.HasPrefix(n, name) {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		if typeName := a.registry.choiceSuffixTypeName(n[len(name):]); typeName != "" {
+			return a.newTypedNode(e, e.value[n], e.value[extensionElementPrefix+n], typeName)
+		}
+	}
+	return hipathsys.EmptyCollection, nil
+}
+
+func (a *modelAdapter) newTypedNode(parent *elementNode, value interface{}, ext interface{}, typeName string) (interface{}, error) {
+	return a.newNodes(value, ext, typeName, a.registry.elementTypeSpec(typeName), parent.container)
+}
+
+func (a *modelAdapter) navigateCollection(col hipathsys.CollectionAccessor, name string) (interface{}, error) {
+	res := a.newCollection()
+	count := col.Count()
+	for i := 0; i < count; i++ {
+		n, err := a.Navigate(col.Get(i), name)
+		if err != nil {
+			return nil, err
+		}
+		if c, ok := n.(hipathsys.CollectionAccessor); ok {
+			_, err = res.AddAll(c)
+		} else {
+			err = res.Add(n)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (a *modelAdapter) Children(node interface{}) (hipathsys.CollectionAccessor, error) {
+	e := a.elementOf(node)
+	if e == nil || e.value == nil {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(e.value))
+	for k := range e.value {
+		if k == "resourceType" {
+			continue
+		}
+		if strings.HasPrefix(k, extensionElementPrefix) {
+			k = k[len(extensionElementPrefix):]
+			if _, found := e.value[k]; found {
+				continue
+			}
+		}
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	res := a.newCollection()
+	for _, name := range names {
+		n, err := a.newChildNode(e, name,
+			e.value[name], e.value[extensionElementPrefix+name])
+		if err != nil {
+			return nil, err
+		}
+		if c, ok := n.(hipathsys.CollectionAccessor); ok {
+			_, err = res.AddAll(c)
+		} else {
+			err = res.Add(n)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (a *modelAdapter) elementOf(node interface{}) *elementNode {
+	switch n := node.(type) {
+	case *elementNode:
+		return n
+	case map[string]interface{}:
+		return a.newElementNode(n, "", nil, nil)
+	case *primitiveNode:
+		return &elementNode{value: n.element, defPath: n.defPath(), typeSpec: n.typeSpec}
+	case hipathsys.AnyAccessor:
+		switch s := n.Source().(type) {
+		case *elementNode:
+			return s
+		case *primitiveNode:
+			return &elementNode{value: s.element, defPath: s.defPath(), typeSpec: s.typeSpec}
+		}
+	}
+	return nil
+}
+
+func (a *modelAdapter) newChildNode(parent *elementNode, name string, value interface{}, ext interface{}) (interface{}, error) {
+	childPath, typeSpec := a.childType(parent.defPath, name)
+	n, err := a.newNodes(value, ext, childPath, typeSpec, parent.container)
+	if err != nil || name != containedElementName {
+		return n, err
+	}
+
+	// contained resources are resolved within the scope of their container
+	if col, ok := n.(hipathsys.CollectionAccessor); ok {
+		count := col.Count()
+		for i := 0; i < count; i++ {
+			if e, ok := col.Get(i).(*elementNode); ok {
+				e.container = parent.container
+			}
+		}
+	} else if e, ok := n.(*elementNode); ok {
+		e.container = parent.container
+	}
+	return n, nil
+}
+
+func (a *modelAdapter) newNodes(value interface{}, ext interface{}, childPath string, typeSpec hipathsys.TypeSpecAccessor, container *elementNode) (interface{}, error) {
+	values, valuesArray := value.([]interface{})
+	exts, extsArray := ext.([]interface{})
+	if valuesArray || extsArray {
+		count := len(values)
+		if len(exts) > count {
+			count = len(exts)
+		}
+
+		col := a.newCollection()
+		for i := 0; i < count; i++ {
+			var v interface{}
+			var e map[string]interface{}
+			if i < len(values) {
+				v = values[i]
+			}
+			if i < len(exts) {
+				e, _ = exts[i].(map[string]interface{})
+			}
+			if v != nil || e != nil {
+				if err := col.Add(a.newNode(v, e, childPath, typeSpec, container)); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return col, nil
+	}
+
+	e, _ := ext.(map[string]interface{})
+	if value == nil && e == nil {
+		return hipathsys.EmptyCollection, nil
+	}
+	return a.ConvertToSystem(a.newNode(value, e, childPath, typeSpec, container))
+}
+
+func (a *modelAdapter) childType(defPath string, name string) (string, hipathsys.TypeSpecAccessor) {
+	def := a.registry.ElementDefinition(defPath, name)
+	if def == nil {
+		if _, typeName := a.registry.ChoiceElementDefinition(defPath, name); typeName != "" {
+			return typeName, a.registry.elementTypeSpec(typeName)
+		}
+		return "", nil
+	}
+	if len(def.TypeNames()) != 1 {
+		return "", nil
+	}
+
+	path := defPath + "." + name
+	if t, found := a.registry.types[path]; found {
+		return path, t.typeSpec
+	}
+
+	typeName := def.TypeNames()[0]
+	return typeName, a.registry.elementTypeSpec(typeName)
+}
+
+func (a *modelAdapter) newNode(value interface{}, ext map[string]interface{}, defPath string, typeSpec hipathsys.TypeSpecAccessor, container *elementNode) interface{} {
+	if m, ok := value.(map[string]interface{}); ok {
+		return a.newElementNode(m, defPath, typeSpec, container)
+	}
+	return a.newPrimitiveNode(value, ext, typeSpec)
+}
+
+func (a *modelAdapter) newElementNode(value map[string]interface{}, defPath string, typeSpec hipathsys.TypeSpecAccessor, container *elementNode) *elementNode {
+	if resourceType, ok := value["resourceType"].(string); ok {
+		defPath, typeSpec := a.registry.resourceTypeSpec(resourceType)
+		n := &elementNode{value: value, defPath: defPath, typeSpec: typeSpec}
+		n.container = n
+		return n
+	}
+	if typeSpec == nil {
+		return &elementNode{value, "Element", a.registry.TypeSpec("Element"), container}
+	}
+	return &elementNode{value, defPath, typeSpec, container}
+}
+
+func (a *modelAdapter) newPrimitiveNode(value interface{}, ext map[string]interface{}, typeSpec hipathsys.TypeSpecAccessor) *primitiveNode {
+	if typeSpec == nil {
+		var typeName string
+		switch value.(type) {
+		case bool:
+			typeName = "boolean"
+		case string:
+			typeName = "string"
+		case float64, json.Number:
+			typeName = "decimal"
+		default:
+			typeName = "Element"
+		}
+		typeSpec = a.registry.TypeSpec(typeName)
+	}
+	return &primitiveNode{value, ext, typeSpec}
+}
+
+// elementSystemNode converts a FHIR Quantity into a System.Quantity. The UCUM
+// code is used as unit if the quantity uses UCUM, otherwise its unit. Other
+// elements and quantities without a value are not converted.
+func elementSystemNode(n *elementNode) (interface{}, error) {
+	if n.typeSpec == nil || !n.typeSpec.ExtendsName(quantityTypeName) {
+		return n, nil
+	}
+
+	var value hipathsys.DecimalAccessor
+	switch v := n.value["value"].(type) {
+	case nil:
+		return n, nil
+	case float64:
+		value = hipathsys.NewDecimalFloat64(v)
+	case json.Number:
+		var err error
+		if value, err = hipathsys.ParseDecimal(string(v)); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("not a valid quantity value: %v", v)
+	}
+
+	var unit hipathsys.StringAccessor
+	code, _ := n.value["code"].(string)
+	system, _ := n.value["system"].(string)
+	if u, ok := n.value["unit"].(string); ok && (code == "" || system != hipathsys.UCUMSystemURI.String()) {
+		unit = hipathsys.NewString(u)
+	} else if code != "" {
+		unit = hipathsys.NewString(code)
+	}
+	return hipathsys.NewQuantityWithSource(value, unit, n), nil
+}
+
+func primitiveSystemNode(n *primitiveNode) (interface{}, error) {
+	t := n.typeSpec
+	switch v := n.value.(type) {
+	case nil:
+		return n, nil
+	case bool:
+		return hipathsys.NewBooleanWithSource(v, n), nil
+	case string:
+		switch {
+		case t.ExtendsName(dateTypeName):
+			return hipathsys.ParseDateWithSource(v, n)
+		case t.ExtendsName(dateTimeTypeName), t.ExtendsName(instantTypeName):
+			return hipathsys.ParseDateTimeWithSource(v, n)
+		case t.ExtendsName(timeTypeName):
+			return hipathsys.ParseTimeWithSource(v, n)
+		}
+		return hipathsys.NewStringWithSource(v, n), nil
+	case float64:
+		if t.ExtendsName(integerTypeName) {
+			if v != math.Trunc(v) || v < math.MinInt32 || v > math.MaxInt32 {
+				return nil, fmt.Errorf("not a valid integer: %v", v)
+			}
+			return hipathsys.NewIntegerWithSource(int32(v), n), nil
+		}
+		return hipathsys.NewDecimalFloat64WithSource(v, n), nil
+	case json.Number:
+		if t.ExtendsName(integerTypeName) {
+			i, err := strconv.ParseInt(string(v), 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("not a valid integer: %s", v)
+			}
+			return hipathsys.NewIntegerWithSource(int32(i), n), nil
+		}
+		return hipathsys.ParseDecimalWithSource(string(v), n)
+	}
+	return nil, fmt.Errorf("unsupported JSON value: %T", n.value)
+}
+
+func jsonValueEqual(v1 interface{}, v2 interface{}, equivalent bool) bool {
+	switch t1 := v1.(type) {
+	case nil:
+		return v2 == nil
+	case map[string]interface{}:
+		t2, ok := v2.(map[string]interface{})
+		if !ok || len(t1) != len(t2) {
+			return false
+		}
+		for k, c1 := range t1 {
+			if c2, found := t2[k]; !found || !jsonValueEqual(c1, c2, equivalent) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		t2, ok := v2.([]interface{})
+		if !ok || len(t1) != len(t2) {
+			return false
+		}
+		if equivalent {
+			return jsonArrayEquivalent(t1, t2)
+		}
+		for i := range t1 {
+			if !jsonValueEqual(t1[i], t2[i], false) {
+				return false
+			}
+		}
+		return true
+	case string:
+		t2, ok := v2.(string)
+		if !ok {
+			return false
+		}
+		if equivalent {
+			return hipathsys.NormalizedStringEqual(t1, t2)
+		}
+		return t1 == t2
+	case bool:
+		t2, ok := v2.(bool)
+		return ok && t1 == t2
+	case float64, json.Number:
+		f1, ok1 := jsonNumber(t1)
+		f2, ok2 := jsonNumber(v2)
+		return ok1 && ok2 && f1 == f2
+	}
+	return false
+}
+
+func jsonArrayEquivalent(a1 []interface{}, a2 []interface{}) bool {
+	matched := make([]bool, len(a2))
+	for _, c1 := range a1 {
+		found := false
+		for i, c2 := range a2 {
+			if !matched[i] && jsonValueEqual(c1, c2, true) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func jsonNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,729 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathfhir
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/healthiop/hipath"
+	"github.com/healthiop/hipath/hipathsys"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testPatient = `{
+  "resourceType": "Patient",
+  "id": "example",
+  "active": true,
+  "name": [
+    {
+      "family": "Doe",
+      "given": ["John", "Richard"],
+      "_given": [null, {"extension": [{"url": "http://example.com/ext", "valueString": "test"}]}]
+    },
+    {
+      "family": "Roe",
+      "given": ["Jonny"]
+    }
+  ],
+  "birthDate": "1974-12-25",
+  "_birthDate": {
+    "extension": [{"url": "http://example.com/birthTime", "valueDateTime": "1974-12-25T14:35:45-05:00"}]
+  },
+  "multipleBirthInteger": 2,
+  "contact": [
+    {"name": {"family": "Doe", "given": ["Jane"]}}
+  ],
+  "contained": [
+    {"resourceType": "Practitioner", "id": "p1"}
+  ]
+}`
+
+func newTestTypeRegistry(t *testing.T) *TypeRegistry {
+	r := NewTypeRegistry()
+	_, err := r.RegisterType("HumanName", "Element",
+		NewElementDefinition("family", false, "string"),
+		NewElementDefinition("given", true, "string"))
+	assert.NoError(t, err, "no error expected")
+	err = r.RegisterBackboneElement("Patient.contact", "BackboneElement",
+		NewElementDefinition("name", false, "HumanName"))
+	assert.NoError(t, err, "no error expected")
+	_, err = r.RegisterType("Patient", "DomainResource",
+		NewElementDefinition("active", false, "boolean"),
+		NewElementDefinition("name", true, "HumanName"),
+		NewElementDefinition("birthDate", false, "date"),
+		NewElementDefinition("multipleBirthInteger", false, "integer"),
+		NewElementDefinition("contact", true, "BackboneElement"))
+	assert.NoError(t, err, "no error expected")
+	return r
+}
+
+func decodeTestJSON(t *testing.T, value string, useNumber bool) map[string]interface{} {
+	d := json.NewDecoder(strings.NewReader(value))
+	if useNumber {
+		d.UseNumber()
+	}
+	var res map[string]interface{}
+	if err := d.Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func assertSystemEqual(t *testing.T, expected hipathsys.AnyAccessor, actual interface{}) {
+	assert.True(t, expected.Equal(actual), "expected %v, actual %v", expected, actual)
+}
+
+func executeTestPath(t *testing.T, adapter hipathsys.ModelAdapter, path string, node interface{}, opts ...hipathsys.ContextOption) hipathsys.CollectionAccessor {
+	res, err := gohipath.Execute(hipathsys.NewContext(adapter, opts...), path, node)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return res
+}
+
+func TestModelAdapterConvertToSystemString(t *testing.T) {
+	a := NewModelAdapter(nil)
+	res, err := a.ConvertToSystem("test")
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.StringAccessor)(nil), res) {
+		assert.Equal(t, "test", res.(hipathsys.StringAccessor).String())
+		assert.Equal(t, "FHIR.string", a.TypeSpec(res).String())
+	}
+}
+
+func TestModelAdapterConvertToSystemBoolean(t *testing.T) {
+	a := NewModelAdapter(nil)
+	res, err := a.ConvertToSystem(true)
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.BooleanAccessor)(nil), res) {
+		assert.True(t, res.(hipathsys.BooleanAccessor).Bool())
+		assert.Equal(t, "FHIR.boolean", a.TypeSpec(res).String())
+	}
+}
+
+func TestModelAdapterConvertToSystemFloat(t *testing.T) {
+	a := NewModelAdapter(nil)
+	res, err := a.ConvertToSystem(10.5)
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.DecimalAccessor)(nil), res) {
+		assert.Equal(t, 10.5, res.(hipathsys.DecimalAccessor).Float64())
+		assert.Equal(t, "FHIR.decimal", a.TypeSpec(res).String())
+	}
+}
+
+func TestModelAdapterConvertToSystemNumber(t *testing.T) {
+	a := NewModelAdapter(nil)
+	res, err := a.ConvertToSystem(json.Number("10.50"))
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.DecimalAccessor)(nil), res) {
+		assert.Equal(t, "10.50", res.(hipathsys.DecimalAccessor).String())
+	}
+}
+
+func TestModelAdapterConvertToSystemArray(t *testing.T) {
+	a := NewModelAdapter(nil)
+	res, err := a.ConvertToSystem([]interface{}{"test"})
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res)
+}
+
+func TestModelAdapterConvertToSystemUnsupported(t *testing.T) {
+	a := NewModelAdapter(nil)
+	res, err := a.ConvertToSystem(10)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res)
+}
+
+func TestModelAdapterConvertToSystemResource(t *testing.T) {
+	a := NewModelAdapter(nil)
+	res, err := a.ConvertToSystem(decodeTestJSON(t, testPatient, false))
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*NodeAccessor)(nil), res) {
+		assert.Equal(t, "FHIR.Patient", res.(NodeAccessor).TypeSpec().String())
+		assert.True(t, res.(NodeAccessor).TypeSpec().ExtendsName(
+			hipathsys.NewFQTypeName("DomainResource", "FHIR")))
+	}
+}
+
+func TestModelAdapterTypeSpecSystem(t *testing.T) {
+	a := NewModelAdapter(nil)
+	assert.Same(t, hipathsys.UndefinedTypeSpec, a.TypeSpec(hipathsys.NewString("test")))
+}
+
+func TestModelAdapterTypeSpecElement(t *testing.T) {
+	a := NewModelAdapter(nil)
+	assert.Equal(t, "FHIR.Element", a.TypeSpec(map[string]interface{}{}).String())
+}
+
+func TestModelAdapterNavigate(t *testing.T) {
+	a := NewModelAdapter(newTestTypeRegistry(t))
+	res := executeTestPath(t, a, "Patient.name.given", decodeTestJSON(t, testPatient, false))
+	if assert.Equal(t, 3, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("John"), res.Get(0))
+		assertSystemEqual(t, hipathsys.NewString("Richard"), res.Get(1))
+		assertSystemEqual(t, hipathsys.NewString("Jonny"), res.Get(2))
+		assert.Equal(t, "FHIR.string", a.TypeSpec(res.Get(0)).String())
+	}
+}
+
+func TestModelAdapterNavigateTypes(t *testing.T) {
+	a := NewModelAdapter(newTestTypeRegistry(t))
+	patient := decodeTestJSON(t, testPatient, true)
+
+	res := executeTestPath(t, a, "birthDate", patient)
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewDateYMDWithPrecision(1974, 12, 25, hipathsys.DayDatePrecision), res.Get(0))
+		assert.Equal(t, "FHIR.date", a.TypeSpec(res.Get(0)).String())
+	}
+
+	res = executeTestPath(t, a, "multipleBirthInteger", patient)
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewInteger(2), res.Get(0))
+	}
+
+	res = executeTestPath(t, a, "active", patient)
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.True, res.Get(0))
+	}
+}
+
+func TestModelAdapterNavigateBackboneElement(t *testing.T) {
+	a := NewModelAdapter(newTestTypeRegistry(t))
+	patient := decodeTestJSON(t, testPatient, false)
+
+	res := executeTestPath(t, a, "contact.name.given", patient)
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("Jane"), res.Get(0))
+	}
+
+	res = executeTestPath(t, a, "contact is BackboneElement", patient)
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.True, res.Get(0))
+	}
+
+	res = executeTestPath(t, a, "contact.name is HumanName", patient)
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.True, res.Get(0))
+	}
+}
+
+func TestModelAdapterNavigateContained(t *testing.T) {
+	a := NewModelAdapter(newTestTypeRegistry(t))
+	res := executeTestPath(t, a, "contained.where($this is Practitioner).id",
+		decodeTestJSON(t, testPatient, false))
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("p1"), res.Get(0))
+		assert.Equal(t, "FHIR.id", a.TypeSpec(res.Get(0)).String())
+	}
+}
+
+func TestModelAdapterNavigateOtherResourceType(t *testing.T) {
+	a := NewModelAdapter(newTestTypeRegistry(t))
+	res := executeTestPath(t, a, "Observation.id", decodeTestJSON(t, testPatient, false))
+	assert.Equal(t, 0, res.Count())
+}
+
+func TestModelAdapterNavigateMissing(t *testing.T) {
+	a := NewModelAdapter(newTestTypeRegistry(t))
+	res := executeTestPath(t, a, "gender.other", decodeTestJSON(t, testPatient, false))
+	assert.Equal(t, 0, res.Count())
+}
+
+func TestModelAdapterNavigatePrimitiveExtension(t *testing.T) {
+	a := NewModelAdapter(newTestTypeRegistry(t))
+	patient := decodeTestJSON(t, testPatient, false)
+
+	res := executeTestPath(t, a, "birthDate.extension.url", patient)
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("http://example.com/birthTime"), res.Get(0))
+		assert.Equal(t, "FHIR.uri", a.TypeSpec(res.Get(0)).String())
+	}
+
+	res = executeTestPath(t, a, "name.given[1].extension.url", patient)
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("http://example.com/ext"), res.Get(0))
+	}
+
+	res = executeTestPath(t, a, "name.given[0].extension", patient)
+	assert.Equal(t, 0, res.Count())
+}
+
+func TestModelAdapterNavigateExtensionOnly(t *testing.T) {
+	a := NewModelAdapter(newTestTypeRegistry(t))
+	patient := decodeTestJSON(t, `{"resourceType": "Patient",
+      "_birthDate": {"extension": [{"url": "http://example.com/ext"}]}}`, false)
+
+	res := executeTestPath(t, a, "birthDate", patient)
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, "FHIR.date", a.TypeSpec(res.Get(0)).String())
+		assert.Nil(t, JSONValue(res.Get(0)))
+	}
+
+	res = executeTestPath(t, a, "birthDate.extension.url", patient)
+	assert.Equal(t, 1, res.Count())
+}
+
+func TestModelAdapterNavigateInvalidDate(t *testing.T) {
+	a := NewModelAdapter(newTestTypeRegistry(t))
+	_, err := gohipath.Execute(hipathsys.NewContext(a), "birthDate",
+		decodeTestJSON(t, `{"resourceType": "Patient", "birthDate": "x"}`, false))
+	assert.NotNil(t, err, "error expected")
+}
+
+func TestModelAdapterNavigateSystem(t *testing.T) {
+	a := NewModelAdapter(nil)
+	res, err := a.Navigate(hipathsys.NewString("test"), "id")
+	assert.NoError(t, err, "no error expected")
+	assert.Same(t, hipathsys.EmptyCollection, res)
+}
+
+func TestModelAdapterChildren(t *testing.T) {
+	a := NewModelAdapter(newTestTypeRegistry(t))
+	res := executeTestPath(t, a, "name[0].children()", decodeTestJSON(t, testPatient, false))
+	if assert.Equal(t, 3, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("Doe"), res.Get(0))
+		assertSystemEqual(t, hipathsys.NewString("John"), res.Get(1))
+		assertSystemEqual(t, hipathsys.NewString("Richard"), res.Get(2))
+	}
+}
+
+func TestModelAdapterCollectionMaxSize(t *testing.T) {
+	a := NewModelAdapter(newTestTypeRegistry(t))
+	patient := decodeTestJSON(t, testPatient, false)
+	ctx := hipathsys.NewContext(a, hipathsys.WithLimits(hipathsys.Limits{MaxCollectionSize: 1}))
+	for _, path := range []string{"name.given", "name[0].children()"} {
+		res, err := gohipath.Execute(ctx, path, patient)
+		assert.True(t, errors.Is(err, hipathsys.ErrLimitExceeded), "limit error expected: %s", path)
+		assert.Nil(t, res, "no result expected: %s", path)
+	}
+
+	res, err := gohipath.Execute(hipathsys.NewContext(a), "name.given", patient)
+	assert.Nil(t, err, "no error expected")
+	if assert.NotNil(t, res, "result expected") {
+		assert.Equal(t, 3, res.Count())
+	}
+}
+
+func TestModelAdapterChildrenExtensionOnly(t *testing.T) {
+	a := NewModelAdapter(newTestTypeRegistry(t))
+	patient := decodeTestJSON(t, `{"resourceType": "Patient", "id": "x",
+      "_birthDate": {"extension": [{"url": "http://example.com/ext"}]}}`, false)
+
+	res, err := a.Children(patient)
+	assert.NoError(t, err, "no error expected")
+	if assert.NotNil(t, res) && assert.Equal(t, 2, res.Count()) {
+		assert.Equal(t, "FHIR.date", a.TypeSpec(res.Get(0)).String())
+		assertSystemEqual(t, hipathsys.NewString("x"), res.Get(1))
+	}
+}
+
+func TestModelAdapterDescendants(t *testing.T) {
+	a := NewModelAdapter(newTestTypeRegistry(t))
+	res := executeTestPath(t, a, "descendants().where($this is HumanName).family",
+		decodeTestJSON(t, testPatient, false))
+	if assert.Equal(t, 3, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("Doe"), res.Get(0))
+		assertSystemEqual(t, hipathsys.NewString("Doe"), res.Get(1))
+		assertSystemEqual(t, hipathsys.NewString("Roe"), res.Get(2))
+	}
+}
+
+func TestModelAdapterChildrenSystem(t *testing.T) {
+	a := NewModelAdapter(nil)
+	res, err := a.Children(hipathsys.NewString("test"))
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res)
+}
+
+func TestModelAdapterCast(t *testing.T) {
+	a := NewModelAdapter(newTestTypeRegistry(t))
+	patient := decodeTestJSON(t, testPatient, false)
+
+	res, err := a.Cast(patient, hipathsys.NewFQTypeName("Resource", "FHIR"))
+	assert.NoError(t, err, "no error expected")
+	assert.NotNil(t, res)
+
+	res, err = a.Cast(patient, hipathsys.NewFQTypeName("HumanName", "FHIR"))
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res)
+}
+
+func TestModelAdapterEqual(t *testing.T) {
+	a := NewModelAdapter(newTestTypeRegistry(t))
+	patient := decodeTestJSON(t, testPatient, false)
+
+	res := executeTestPath(t, a, "name[0] = name[0]", patient)
+	assert.Equal(t, hipathsys.True, res.Get(0))
+	res = executeTestPath(t, a, "name[0] = name[1]", patient)
+	assert.Equal(t, hipathsys.False, res.Get(0))
+	res = executeTestPath(t, a, "name[0].family = contact.name.family", patient)
+	assert.Equal(t, hipathsys.True, res.Get(0))
+}
+
+func TestModelAdapterEqualJSON(t *testing.T) {
+	a := NewModelAdapter(nil)
+	v1 := decodeTestJSON(t, `{"a": [1, 2.5, "Test", true, null, {"b": "x"}]}`, false)
+	v2 := decodeTestJSON(t, `{"a": [1, 2.50, "Test", true, null, {"b": "x"}]}`, true)
+	v3 := decodeTestJSON(t, `{"a": [{"b": "X"}, null, "test ", true, 2.5, 1]}`, false)
+	v4 := decodeTestJSON(t, `{"a": [{"b": "X"}, null, "test ", false, 2.5, 1]}`, false)
+
+	assert.True(t, a.Equal(v1, v2))
+	assert.False(t, a.Equal(v1, v3))
+	assert.True(t, a.Equivalent(v1, v3))
+	assert.False(t, a.Equivalent(v1, v4))
+	assert.False(t, a.Equivalent(v1, map[string]interface{}{}))
+	assert.False(t, a.Equal(map[string]interface{}{"a": "x"}, map[string]interface{}{"b": "x"}))
+	assert.False(t, a.Equal(map[string]interface{}{"a": "x"}, map[string]interface{}{"a": 1.0}))
+}
+
+const testObservationQuantity = `{
+  "resourceType": "Observation",
+  "status": "final",
+  "valueQuantity": {"value": 185, "unit": "lbs", "system": "http://unitsofmeasure.org", "code": "[lb_av]"},
+  "effectiveDateTime": "2016-03-28"
+}`
+
+const testObservationString = `{
+  "resourceType": "Observation",
+  "status": "final",
+  "valueString": "test",
+  "_valueString": {"extension": [{"url": "http://example.com/ext"}]}
+}`
+
+func newTestChoiceTypeRegistry(t *testing.T) *TypeRegistry {
+	r := NewTypeRegistry()
+	_, err := r.RegisterType("Quantity", "Element",
+		NewElementDefinition("value", false, "decimal"),
+		NewElementDefinition("unit", false, "string"),
+		NewElementDefinition("system", false, "uri"),
+		NewElementDefinition("code", false, "code"))
+	assert.NoError(t, err, "no error expected")
+	_, err = r.RegisterType("Observation", "DomainResource",
+		NewElementDefinition("status", false, "code"),
+		NewElementDefinition("value[x]", false, "Quantity", "string", "boolean"))
+	assert.NoError(t, err, "no error expected")
+	return r
+}
+
+func TestModelAdapterNavigateChoice(t *testing.T) {
+	a := NewModelAdapter(newTestChoiceTypeRegistry(t))
+	obs := decodeTestJSON(t, testObservationQuantity, true)
+
+	res := executeTestPath(t, a, "Observation.value", obs)
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, "FHIR.Quantity", a.TypeSpec(res.Get(0)).String())
+	}
+
+	res = executeTestPath(t, a, "Observation.value.value", obs)
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewDecimalInt(185), res.Get(0))
+	}
+
+	res = executeTestPath(t, a, "Observation.value.code", obs)
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, "FHIR.code", a.TypeSpec(res.Get(0)).String())
+	}
+}
+
+func TestModelAdapterNavigateChoiceOfType(t *testing.T) {
+	a := NewModelAdapter(newTestChoiceTypeRegistry(t))
+	obs1 := decodeTestJSON(t, testObservationQuantity, false)
+	obs2 := decodeTestJSON(t, testObservationString, false)
+
+	res := executeTestPath(t, a, "Observation.value.ofType(Quantity).unit", obs1)
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("lbs"), res.Get(0))
+	}
+	res = executeTestPath(t, a, "Observation.value.ofType(FHIR.string)", obs1)
+	assert.Equal(t, 0, res.Count())
+
+	res = executeTestPath(t, a, "Observation.value.ofType(Quantity)", obs2)
+	assert.Equal(t, 0, res.Count())
+	res = executeTestPath(t, a, "Observation.value.ofType(string)", obs2)
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("test"), res.Get(0))
+	}
+}
+
+func TestModelAdapterNavigateChoiceAs(t *testing.T) {
+	a := NewModelAdapter(newTestChoiceTypeRegistry(t))
+	obs1 := decodeTestJSON(t, testObservationQuantity, false)
+	obs2 := decodeTestJSON(t, testObservationString, false)
+
+	res := executeTestPath(t, a, "(Observation.value as Quantity).unit", obs1)
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("lbs"), res.Get(0))
+	}
+	res = executeTestPath(t, a, "Observation.value as string", obs1)
+	assert.Equal(t, 0, res.Count())
+
+	res = executeTestPath(t, a, "Observation.value as Quantity", obs2)
+	assert.Equal(t, 0, res.Count())
+	res = executeTestPath(t, a, "Observation.value as string", obs2)
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("test"), res.Get(0))
+	}
+	res = executeTestPath(t, a, "Observation.value.as(FHIR.string)", obs2)
+	assert.Equal(t, 1, res.Count())
+	res = executeTestPath(t, a, "Observation.value is string", obs2)
+	assertSystemEqual(t, hipathsys.True, res.Get(0))
+}
+
+func TestModelAdapterNavigateChoiceExtension(t *testing.T) {
+	a := NewModelAdapter(newTestChoiceTypeRegistry(t))
+	res := executeTestPath(t, a, "Observation.value.extension.url",
+		decodeTestJSON(t, testObservationString, false))
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("http://example.com/ext"), res.Get(0))
+	}
+}
+
+func TestModelAdapterNavigateChoiceMissing(t *testing.T) {
+	a := NewModelAdapter(newTestChoiceTypeRegistry(t))
+	res := executeTestPath(t, a, "Observation.value",
+		decodeTestJSON(t, `{"resourceType": "Observation", "valueInteger": 1}`, false))
+	assert.Equal(t, 0, res.Count())
+}
+
+func TestModelAdapterNavigateNoChoice(t *testing.T) {
+	a := NewModelAdapter(newTestChoiceTypeRegistry(t))
+	res := executeTestPath(t, a, "Observation.status",
+		decodeTestJSON(t, `{"resourceType": "Observation", "statusCode": "final"}`, false))
+	assert.Equal(t, 0, res.Count())
+}
+
+func TestModelAdapterNavigateChoiceUnknown(t *testing.T) {
+	a := NewModelAdapter(newTestChoiceTypeRegistry(t))
+	obs := decodeTestJSON(t, testObservationQuantity, false)
+
+	res := executeTestPath(t, a, "Observation.effective", obs)
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, "FHIR.dateTime", a.TypeSpec(res.Get(0)).String())
+		assertSystemEqual(t, hipathsys.NewDateTimeYMDHMSNWithPrecision(
+			2016, 3, 28, 0, 0, 0, 0, time.Local, hipathsys.DayDatePrecision), res.Get(0))
+	}
+
+	res = executeTestPath(t, a, "Observation.effective.ofType(dateTime)", obs)
+	assert.Equal(t, 1, res.Count())
+	res = executeTestPath(t, a, "Observation.effect", obs)
+	assert.Equal(t, 0, res.Count())
+}
+
+func TestModelAdapterChoiceElementType(t *testing.T) {
+	a := NewModelAdapter(newTestChoiceTypeRegistry(t))
+	obs := decodeTestJSON(t, testObservationQuantity, false)
+
+	res := executeTestPath(t, a, "Observation.valueQuantity", obs)
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, "FHIR.Quantity", a.TypeSpec(res.Get(0)).String())
+	}
+
+	res = executeTestPath(t, a, "Observation.children().ofType(Quantity).unit", obs)
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("lbs"), res.Get(0))
+	}
+}
+
+func TestModelAdapterCoreTypes(t *testing.T) {
+	a := NewModelAdapter(nil)
+	patient := decodeTestJSON(t, `{"resourceType": "Patient", "birthDate": "1974-12-25",
+      "deceasedDateTime": "2019-01-02T10:20:30Z", "meta": {"lastUpdated": "2020-05-06T08:09:10.123Z"},
+      "name": [{"family": "Doe", "period": {"start": "2001-02-03"}}]}`, false)
+
+	res := executeTestPath(t, a, "Patient.birthDate > @2019-01-01", patient)
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.False, res.Get(0))
+	}
+	res = executeTestPath(t, a, "Patient.birthDate is date", patient)
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.True, res.Get(0))
+	}
+	res = executeTestPath(t, a, "Patient.deceased", patient)
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Implements(t, (*hipathsys.DateTimeAccessor)(nil), res.Get(0))
+		assert.Equal(t, "FHIR.dateTime", a.TypeSpec(res.Get(0)).String())
+	}
+	res = executeTestPath(t, a, "Patient.meta.lastUpdated is FHIR.instant", patient)
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.True, res.Get(0))
+	}
+	res = executeTestPath(t, a, "Patient.name.period.start is dateTime", patient)
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.True, res.Get(0))
+	}
+}
+
+func TestModelAdapterQuantity(t *testing.T) {
+	a := NewModelAdapter(nil)
+	observation := decodeTestJSON(t, `{"resourceType": "Observation", "status": "final",
+      "valueQuantity": {"value": 37.0, "unit": "°C", "system": "http://unitsofmeasure.org", "code": "Cel"}}`, true)
+
+	tests := []struct {
+		path     string
+		expected hipathsys.AnyAccessor
+	}{
+		{"Observation.value > 98 '[degF]'", hipathsys.True},
+		{"Observation.value < 99 '[degF]'", hipathsys.True},
+		{"Observation.value = 37 'Cel'", hipathsys.True},
+		{"Observation.value.toQuantity('[degF]') = 98.6 '[degF]'", hipathsys.True},
+		{"Observation.value is Quantity", hipathsys.True},
+		{"Observation.value.unit", hipathsys.NewString("°C")},
+		{"Observation.value.value", hipathsys.NewDecimalInt(37)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			res := executeTestPath(t, a, tt.path, observation)
+			if assert.Equal(t, 1, res.Count()) {
+				assertSystemEqual(t, tt.expected, res.Get(0))
+			}
+		})
+	}
+}
+
+func TestModelAdapterQuantityUnit(t *testing.T) {
+	a := NewModelAdapter(nil)
+	observation := decodeTestJSON(t, `{"resourceType": "Observation", "status": "final",
+      "component": [{"valueQuantity": {"value": 5, "unit": "mg"}},
+        {"valueQuantity": {"value": 6, "unit": "tablets", "system": "http://example.com/units", "code": "tab"}},
+        {"valueQuantity": {"unit": "mg"}}]}`, false)
+
+	res := executeTestPath(t, a, "Observation.component[0].value = 5 'mg'", observation)
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.True, res.Get(0))
+	}
+	res = executeTestPath(t, a, "Observation.component[1].value = 6 'tablets'", observation)
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.True, res.Get(0))
+	}
+	res = executeTestPath(t, a, "Observation.component[2].value.unit", observation)
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("mg"), res.Get(0))
+	}
+}
+
+func TestModelAdapterUnknownResourceElement(t *testing.T) {
+	a := NewModelAdapter(nil)
+	request := decodeTestJSON(t, `{"resourceType": "DeviceRequest", "authoredOn": "2020-03-01"}`, false)
+
+	res := executeTestPath(t, a, "DeviceRequest.authoredOn", request)
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("2020-03-01"), res.Get(0))
+	}
+	res = executeTestPath(t, a, "DeviceRequest.authoredOn is System.String", request)
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.True, res.Get(0))
+	}
+	res = executeTestPath(t, a, "DeviceRequest.authoredOn is FHIR.dateTime", request)
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.False, res.Get(0))
+	}
+}
+
+func TestModelAdapterUnknownResourceElementRegistered(t *testing.T) {
+	r := NewTypeRegistry()
+	_, err := r.RegisterType("DeviceRequest", "DomainResource",
+		NewElementDefinition("authoredOn", false, "dateTime"))
+	assert.NoError(t, err, "no error expected")
+	a := NewModelAdapter(r)
+	request := decodeTestJSON(t, `{"resourceType": "DeviceRequest", "authoredOn": "2020-03-01"}`, false)
+
+	res := executeTestPath(t, a, "DeviceRequest.authoredOn is FHIR.dateTime", request)
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.True, res.Get(0))
+	}
+}
+
+func TestModelAdapterChoiceUnknownElementNoType(t *testing.T) {
+	a := NewModelAdapter(nil)
+	questionnaire := decodeTestJSON(t, `{"resourceType": "Questionnaire", "item": [
+      {"linkId": "1", "answerOption": [{"valueString": "yes"}]}]}`, false)
+
+	res := executeTestPath(t, a, "Questionnaire.item.answer", questionnaire)
+	assert.Equal(t, 0, res.Count())
+	res = executeTestPath(t, a, "Questionnaire.item.answerOption.value", questionnaire)
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, "FHIR.string", a.TypeSpec(res.Get(0)).String())
+	}
+}
+
+func TestModelAdapterExtension(t *testing.T) {
+	a := NewModelAdapter(newTestTypeRegistry(t))
+	node := decodeTestJSON(t, testPatient, false)
+
+	res := executeTestPath(t, a, "Patient.birthDate.extension('http://example.com/birthTime').value", node)
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, "FHIR.dateTime", a.TypeSpec(res.Get(0)).String())
+	}
+
+	res = executeTestPath(t, a, "Patient.name.given.extension('http://example.com/ext').value", node)
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("test"), res.Get(0))
+	}
+
+	res = executeTestPath(t, a, "Patient.extension('http://example.com/ext')", node)
+	assert.Equal(t, 0, res.Count())
+}
+
+func TestModelAdapterHasValue(t *testing.T) {
+	a := NewModelAdapter(newTestTypeRegistry(t))
+	node := decodeTestJSON(t, `{"resourceType": "Patient", "birthDate": "1974-12-25",
+		"name": [{"given": [null], "_given": [{"extension": [{"url": "http://example.com/ext", "valueString": "test"}]}]}]}`, false)
+
+	res := executeTestPath(t, a, "Patient.birthDate.hasValue()", node)
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.True, res.Get(0))
+	}
+
+	res = executeTestPath(t, a, "Patient.name.given.hasValue()", node)
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.False, res.Get(0))
+	}
+
+	res = executeTestPath(t, a, "Patient.name.given.exists() and Patient.name.hasValue() = false", node)
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.True, res.Get(0))
+	}
+}
+
+func TestModelAdapterGetValue(t *testing.T) {
+	a := NewModelAdapter(newTestTypeRegistry(t))
+	node := decodeTestJSON(t, testPatient, false)
+
+	res := executeTestPath(t, a, "Patient.birthDate.getValue()", node)
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewDateYMD(1974, 12, 25), res.Get(0))
+	}
+
+	res = executeTestPath(t, a, "Patient.name.first().getValue()", node)
+	assert.Equal(t, 0, res.Count())
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathfhir
+
+import "github.com/healthiop/hipath/hipathsys"
+
+type elementNode struct {
+	value     map[string]interface{}
+	defPath   string
+	typeSpec  hipathsys.TypeSpecAccessor
+	container *elementNode
+}
+
+type primitiveNode struct {
+	value    interface{}
+	element  map[string]interface{}
+	typeSpec hipathsys.TypeSpecAccessor
+}
+
+type NodeAccessor interface {
+	TypeSpec() hipathsys.TypeSpecAccessor
+	JSONValue() interface{}
+}
+
+func (n *elementNode) TypeSpec() hipathsys.TypeSpecAccessor {
+	return n.typeSpec
+}
+
+func (n *elementNode) JSONValue() interface{} {
+	return n.value
+}
+
+func (n *elementNode) resource() bool {
+	_, ok := n.value["resourceType"]
+	return ok
+}
+
+func (n *primitiveNode) TypeSpec() hipathsys.TypeSpecAccessor {
+	return n.typeSpec
+}
+
+func (n *primitiveNode) JSONValue() interface{} {
+	return n.value
+}
+
+func (n *primitiveNode) defPath() string {
+	return n.typeSpec.FQName().Name()
+}
+
+func JSONValue(node interface{}) interface{} {
+	if n, ok := node.(hipathsys.AnyAccessor); ok {
+		if s, ok := n.Source().(NodeAccessor); ok {
+			return s.JSONValue()
+		}
+		return node
+	}
+	if n, ok := node.(NodeAccessor); ok {
+		return n.JSONValue()
+	}
+	return node
+}
@@ -0,0 +1,642 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathfhir
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/healthiop/hipath"
+	"github.com/healthiop/hipath/hipathsys"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+type profileType struct {
+	code     string
+	profiles []string
+}
+
+type profileConstraint struct {
+	key        string
+	expression string
+}
+
+type profileElement struct {
+	path           string
+	name           string
+	choice         bool
+	childPath      string
+	min            int
+	max            int
+	types          []profileType
+	fixed          interface{}
+	fixedDefined   bool
+	pattern        interface{}
+	patternDefined bool
+	constraints    []profileConstraint
+}
+
+type profile struct {
+	url      string
+	typeName string
+	kind     string
+	root     *profileElement
+	children map[string][]*profileElement
+}
+
+type LocalProfileValidator struct {
+	lock      sync.RWMutex
+	profiles  map[string]*profile
+	paths     map[string]*gohipath.Path
+	functions *hipathsys.FunctionRegistry
+}
+
+type ProfileValidatorOption func(v *LocalProfileValidator)
+
+type validation struct {
+	validator *LocalProfileValidator
+	ctx       hipathsys.ContextAccessor
+	profile   *profile
+}
+
+// constraintContext is the context in which the constraints of a profile are
+// evaluated. It replaces only the context node (and the resource if the node
+// is a resource) of the wrapped context. The limiter of the wrapped context
+// is shared, so that the constraints count against the limits of the
+// evaluation that invoked the validation.
+type constraintContext struct {
+	hipathsys.ContextAccessor
+	node      interface{}
+	resource  interface{}
+	validator hipathsys.ProfileValidator
+}
+
+// WithConstraintFunctions sets the function registry that is used to compile
+// the constraint expressions of the profiles.
+func WithConstraintFunctions(functions *hipathsys.FunctionRegistry) ProfileValidatorOption {
+	return func(v *LocalProfileValidator) {
+		v.functions = functions
+	}
+}
+
+func NewLocalProfileValidator(opts ...ProfileValidatorOption) *LocalProfileValidator {
+	v := &LocalProfileValidator{
+		profiles: make(map[string]*profile),
+		paths:    make(map[string]*gohipath.Path),
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+func (v *LocalProfileValidator) LoadDir(dirname string) error {
+	files, err := ioutil.ReadDir(dirname)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		if err := v.LoadFile(filepath.Join(dirname, f.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *LocalProfileValidator) LoadFile(filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := v.Load(f); err != nil {
+		return fmt.Errorf("%s: %v", filename, err)
+	}
+	return nil
+}
+
+func (v *LocalProfileValidator) Load(reader io.Reader) error {
+	var resource map[string]interface{}
+	if err := json.NewDecoder(reader).Decode(&resource); err != nil {
+		return fmt.Errorf("structure definition cannot be decoded: %v", err)
+	}
+	return v.Add(resource)
+}
+
+func (v *LocalProfileValidator) Add(resource map[string]interface{}) error {
+	switch resource["resourceType"] {
+	case "StructureDefinition":
+		p, err := newProfile(resource)
+		if err != nil {
+			return err
+		}
+
+		v.lock.Lock()
+		defer v.lock.Unlock()
+		v.profiles[p.url] = p
+		return nil
+	case "Bundle":
+		entries, _ := resource["entry"].([]interface{})
+		for _, e := range entries {
+			entry, _ := e.(map[string]interface{})
+			r, _ := entry["resource"].(map[string]interface{})
+			if r == nil || r["resourceType"] != "StructureDefinition" {
+				continue
+			}
+			if err := v.Add(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("not a structure definition: %v", resource["resourceType"])
+}
+
+func newProfile(resource map[string]interface{}) (*profile, error) {
+	url, _ := resource["url"].(string)
+	if len(url) == 0 {
+		return nil, fmt.Errorf("structure definition does not define a URL")
+	}
+	typeName, _ := resource["type"].(string)
+	if len(typeName) == 0 {
+		return nil, fmt.Errorf("structure definition %s does not define a type", url)
+	}
+
+	elements := structureDefinitionElements(resource)
+	if len(elements) == 0 {
+		return nil, fmt.Errorf("structure definition %s does not define elements", url)
+	}
+
+	p := &profile{url: url, typeName: typeName, children: make(map[string][]*profileElement)}
+	p.kind, _ = resource["kind"].(string)
+	for _, e := range elements {
+		element, _ := e.(map[string]interface{})
+		id, _ := element["id"].(string)
+		if _, sliced := element["sliceName"]; sliced || strings.ContainsRune(id, ':') {
+			continue
+		}
+
+		pe, err := newProfileElement(element)
+		if err != nil {
+			return nil, fmt.Errorf("structure definition %s: %v", url, err)
+		}
+
+		pi := strings.LastIndexByte(pe.path, '.')
+		if pi < 0 {
+			if p.root != nil {
+				return nil, fmt.Errorf("structure definition %s defines multiple root elements", url)
+			}
+			p.root = pe
+		} else {
+			parentPath := pe.path[:pi]
+			p.children[parentPath] = append(p.children[parentPath], pe)
+		}
+	}
+	if p.root == nil {
+		return nil, fmt.Errorf("structure definition %s does not define a root element", url)
+	}
+
+	return p, nil
+}
+
+func structureDefinitionElements(resource map[string]interface{}) []interface{} {
+	for _, n := range []string{"snapshot", "differential"} {
+		if c, ok := resource[n].(map[string]interface{}); ok {
+			if elements, ok := c["element"].([]interface{}); ok {
+				return elements
+			}
+		}
+	}
+	return nil
+}
+
+func newProfileElement(element map[string]interface{}) (*profileElement, error) {
+	path, _ := element["path"].(string)
+	if len(path) == 0 {
+		return nil, fmt.Errorf("element does not define a path")
+	}
+
+	min, max, err := elementCardinality(element)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cardinality of element %s: %v", path, err)
+	}
+
+	e := &profileElement{path: path, childPath: path, min: min, max: max}
+	e.name = path[strings.LastIndexByte(path, '.')+1:]
+	if strings.HasSuffix(e.name, choiceElementSuffix) {
+		e.name = e.name[:len(e.name)-len(choiceElementSuffix)]
+		e.choice = true
+	}
+	if ref, ok := element["contentReference"].(string); ok {
+		e.childPath = ref[strings.IndexByte(ref, '#')+1:]
+	}
+
+	types, _ := element["type"].([]interface{})
+	for _, t := range types {
+		code := elementTypeCode(t)
+		if len(code) == 0 {
+			continue
+		}
+		pt := profileType{code: code}
+		elementType, _ := t.(map[string]interface{})
+		profiles, _ := elementType["profile"].([]interface{})
+		for _, p := range profiles {
+			if s, ok := p.(string); ok {
+				pt.profiles = append(pt.profiles, s)
+			}
+		}
+		e.types = append(e.types, pt)
+	}
+
+	for k, value := range element {
+		if strings.HasPrefix(k, "fixed") {
+			e.fixed, e.fixedDefined = value, true
+		} else if strings.HasPrefix(k, "pattern") {
+			e.pattern, e.patternDefined = value, true
+		}
+	}
+
+	constraints, _ := element["constraint"].([]interface{})
+	for _, c := range constraints {
+		constraint, _ := c.(map[string]interface{})
+		if constraint["severity"] == "warning" {
+			continue
+		}
+		expression, _ := constraint["expression"].(string)
+		if len(expression) == 0 {
+			continue
+		}
+		key, _ := constraint["key"].(string)
+		e.constraints = append(e.constraints, profileConstraint{key, expression})
+	}
+
+	return e, nil
+}
+
+func (v *LocalProfileValidator) profile(url string) *profile {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	return v.profiles[canonicalURL(url)]
+}
+
+func (v *LocalProfileValidator) compile(expression string) (*gohipath.Path, error) {
+	v.lock.RLock()
+	path := v.paths[expression]
+	v.lock.RUnlock()
+	if path != nil {
+		return path, nil
+	}
+
+	path, compileErr := gohipath.CompileWithFunctions(expression, v.functions)
+	if compileErr != nil {
+		return nil, compileErr
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.paths[expression] = path
+	return path, nil
+}
+
+func (v *LocalProfileValidator) ConformsTo(ctx hipathsys.ContextAccessor, node interface{}, url string) (hipathsys.BooleanAccessor, error) {
+	p := v.profile(url)
+	if p == nil {
+		return nil, nil
+	}
+
+	if p.kind == "resource" {
+		value, _ := JSONValue(node).(map[string]interface{})
+		if value == nil || value["resourceType"] != p.typeName {
+			return hipathsys.False, nil
+		}
+	}
+
+	vc := &validation{validator: v, ctx: v.newConstraintContext(ctx, node), profile: p}
+	conforms, err := vc.validate(p.root, node, "")
+	if err != nil {
+		return nil, err
+	}
+	return hipathsys.BooleanOf(conforms), nil
+}
+
+func (v *LocalProfileValidator) newConstraintContext(ctx hipathsys.ContextAccessor, node interface{}) hipathsys.ContextAccessor {
+	var validator hipathsys.ProfileValidator = v
+	if cv := hipathsys.ContextProfileValidator(ctx); cv != nil {
+		validator = cv
+	}
+
+	resource := node
+	if value, _ := JSONValue(node).(map[string]interface{}); value["resourceType"] == nil {
+		resource, _ = ctx.EnvVar("resource")
+	}
+	if c, ok := ctx.(*constraintContext); ok {
+		ctx = c.ContextAccessor
+	}
+	return &constraintContext{ctx, node, resource, validator}
+}
+
+func (c *constraintContext) EnvVar(name string) (interface{}, bool) {
+	switch name {
+	case "context":
+		return c.node, true
+	case "resource":
+		return c.resource, true
+	case "rootResource":
+		if rootResource, found := c.ContextAccessor.EnvVar(name); found && rootResource != nil {
+			return rootResource, true
+		}
+		return c.resource, true
+	}
+	return c.ContextAccessor.EnvVar(name)
+}
+
+func (c *constraintContext) ContextNode() interface{} {
+	return c.node
+}
+
+func (c *constraintContext) BindContextNode(interface{}) hipathsys.ContextAccessor {
+	return c
+}
+
+func (c *constraintContext) Limiter() *hipathsys.Limiter {
+	return hipathsys.ContextLimiter(c.ContextAccessor)
+}
+
+func (c *constraintContext) BindLimiter() hipathsys.ContextAccessor {
+	return c
+}
+
+func (c *constraintContext) LazyErrors() bool {
+	return hipathsys.LazyErrors(c.ContextAccessor)
+}
+
+func (c *constraintContext) GoContext() context.Context {
+	return hipathsys.GoContext(c.ContextAccessor)
+}
+
+func (c *constraintContext) ReferenceResolver() hipathsys.ReferenceResolver {
+	return hipathsys.ContextReferenceResolver(c.ContextAccessor)
+}
+
+func (c *constraintContext) TerminologyProvider() hipathsys.TerminologyProvider {
+	return hipathsys.ContextTerminologyProvider(c.ContextAccessor)
+}
+
+func (c *constraintContext) ProfileValidator() hipathsys.ProfileValidator {
+	return c.validator
+}
+
+func (vc *validation) validate(e *profileElement, node interface{}, typeCode string) (bool, error) {
+	value := JSONValue(node)
+	if len(typeCode) > 0 && !jsonKindMatches(typeCode, value) {
+		return false, nil
+	}
+	if e.fixedDefined && !jsonValueEqual(e.fixed, value, false) {
+		return false, nil
+	}
+	if e.patternDefined && !jsonPatternMatches(e.pattern, value) {
+		return false, nil
+	}
+
+	if conforms, err := vc.validateTypeProfiles(e, node, typeCode); !conforms || err != nil {
+		return false, err
+	}
+	for _, c := range e.constraints {
+		if satisfied, err := vc.constraintSatisfied(c, node); !satisfied || err != nil {
+			return false, err
+		}
+	}
+
+	for _, child := range vc.profile.children[e.childPath] {
+		items, typeCodes, err := vc.childItems(child, node)
+		if items == nil || err != nil {
+			return false, err
+		}
+		if len(items) < child.min || (child.max != UnboundedCardinality && len(items) > child.max) {
+			return false, nil
+		}
+		for i, item := range items {
+			if conforms, err := vc.validate(child, item, typeCodes[i]); !conforms || err != nil {
+				return false, err
+			}
+		}
+	}
+
+	return true, nil
+}
+
+func (vc *validation) childItems(e *profileElement, node interface{}) ([]interface{}, []string, error) {
+	items := make([]interface{}, 0)
+	typeCodes := make([]string, 0)
+	adapter := vc.ctx.ModelAdapter()
+
+	if !e.choice {
+		typeCode := ""
+		if len(e.types) == 1 {
+			typeCode = e.types[0].code
+		}
+		n, err := adapter.Navigate(node, e.name)
+		if err != nil {
+			return nil, nil, err
+		}
+		items = appendItems(items, n)
+		for len(typeCodes) < len(items) {
+			typeCodes = append(typeCodes, typeCode)
+		}
+		return items, typeCodes, nil
+	}
+
+	value, _ := JSONValue(node).(map[string]interface{})
+	for _, name := range choiceElementNames(value, e.name) {
+		typeCode := ""
+		suffix := name[len(e.name):]
+		for _, t := range e.types {
+			if ChoiceTypeSuffix(t.code) == suffix {
+				typeCode = t.code
+				break
+			}
+		}
+		if len(typeCode) == 0 {
+			return nil, nil, nil
+		}
+
+		n, err := adapter.Navigate(node, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		items = appendItems(items, n)
+		for len(typeCodes) < len(items) {
+			typeCodes = append(typeCodes, typeCode)
+		}
+	}
+	return items, typeCodes, nil
+}
+
+func (vc *validation) validateTypeProfiles(e *profileElement, node interface{}, typeCode string) (bool, error) {
+	for _, t := range e.types {
+		if t.code != typeCode || len(t.profiles) == 0 {
+			continue
+		}
+		for _, url := range t.profiles {
+			p := vc.validator.profile(url)
+			if p == nil {
+				return false, fmt.Errorf("profile %s of element %s is not available", url, e.path)
+			}
+			res, err := vc.validator.ConformsTo(vc.ctx, node, url)
+			if err != nil {
+				return false, err
+			}
+			if res.Bool() {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+func (vc *validation) constraintSatisfied(c profileConstraint, node interface{}) (bool, error) {
+	path, err := vc.validator.compile(c.expression)
+	if err != nil {
+		return false, fmt.Errorf("constraint %s of profile %s cannot be compiled: %w", c.key, vc.profile.url, err)
+	}
+
+	res, execErr := path.Execute(vc.ctx, node)
+	if execErr != nil {
+		return false, fmt.Errorf("constraint %s of profile %s cannot be evaluated: %w", c.key, vc.profile.url, execErr)
+	}
+	if res.Empty() {
+		return true, nil
+	}
+	if res.Count() != 1 {
+		return false, nil
+	}
+	b, ok := res.Get(0).(hipathsys.BooleanAccessor)
+	return ok && b.Bool(), nil
+}
+
+func appendItems(items []interface{}, node interface{}) []interface{} {
+	if col, ok := node.(hipathsys.CollectionAccessor); ok {
+		count := col.Count()
+		for i := 0; i < count; i++ {
+			items = append(items, col.Get(i))
+		}
+		return items
+	}
+	if node != nil {
+		items = append(items, node)
+	}
+	return items
+}
+
+func choiceElementNames(value map[string]interface{}, name string) []string {
+	names := make([]string, 0)
+	for k := range value {
+		k = strings.TrimPrefix(k, extensionElementPrefix)
+		if len(k) <= len(name) || !strings.HasPrefix(k, name) {
+			continue
+		}
+		if c, _ := utf8.DecodeRuneInString(k[len(name):]); !unicode.IsUpper(c) {
+			continue
+		}
+		if !containsString(names, k) {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func jsonKindMatches(typeCode string, value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	if c, _ := utf8.DecodeRuneInString(typeCode); !unicode.IsLower(c) {
+		_, ok := value.(map[string]interface{})
+		return ok
+	}
+
+	switch typeCode {
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "integer", "decimal", "positiveInt", "unsignedInt":
+		_, ok := jsonNumber(value)
+		return ok
+	}
+	_, ok := value.(string)
+	return ok
+}
+
+func jsonPatternMatches(pattern interface{}, value interface{}) bool {
+	switch p := pattern.(type) {
+	case map[string]interface{}:
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, pv := range p {
+			if !jsonPatternMatches(pv, m[k]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		a, ok := value.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, pv := range p {
+			found := false
+			for _, av := range a {
+				if jsonPatternMatches(pv, av) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+	return jsonValueEqual(pattern, value, false)
+}
@@ -0,0 +1,472 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathfhir
+
+import (
+	"errors"
+	"fmt"
+	"github.com/healthiop/hipath"
+	"github.com/healthiop/hipath/hipathsys"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testProfileBundle = `{
+  "resourceType": "Bundle",
+  "entry": [
+    {"resource": {"resourceType": "StructureDefinition", "url": "http://example.com/Patient", "type": "Patient",
+      "kind": "resource", "derivation": "constraint",
+      "snapshot": {"element": [
+        {"id": "Patient", "path": "Patient", "min": 0, "max": "*",
+          "constraint": [
+            {"key": "tst-1", "severity": "error", "expression": "name.exists() or identifier.exists()"},
+            {"key": "tst-2", "severity": "warning", "expression": "active = true"}
+          ]},
+        {"id": "Patient.identifier", "path": "Patient.identifier", "min": 0, "max": "*", "type": [{"code": "Identifier"}]},
+        {"id": "Patient.identifier.system", "path": "Patient.identifier.system", "min": 1, "max": "1", "type": [{"code": "uri"}]},
+        {"id": "Patient.active", "path": "Patient.active", "min": 0, "max": "1", "type": [{"code": "boolean"}]},
+        {"id": "Patient.name", "path": "Patient.name", "min": 1, "max": "*", "type": [{"code": "HumanName"}],
+          "constraint": [{"key": "tst-3", "severity": "error", "expression": "family.exists()"}]},
+        {"id": "Patient.name.family", "path": "Patient.name.family", "min": 0, "max": "1", "type": [{"code": "string"}]},
+        {"id": "Patient.gender", "path": "Patient.gender", "min": 0, "max": "1", "type": [{"code": "code"}],
+          "fixedCode": "female"},
+        {"id": "Patient.birthDate", "path": "Patient.birthDate", "min": 0, "max": "1", "type": [{"code": "date"}]},
+        {"id": "Patient.deceased[x]", "path": "Patient.deceased[x]", "min": 0, "max": "1",
+          "type": [{"code": "boolean"}, {"code": "dateTime"}]},
+        {"id": "Patient.address", "path": "Patient.address", "min": 0, "max": "*",
+          "type": [{"code": "Address", "profile": ["http://example.com/Address"]}]},
+        {"id": "Patient.maritalStatus", "path": "Patient.maritalStatus", "min": 0, "max": "1",
+          "type": [{"code": "CodeableConcept"}],
+          "patternCodeableConcept": {"coding": [{"system": "http://example.com/cs", "code": "M"}]}},
+        {"id": "Patient.photo", "path": "Patient.photo", "min": 0, "max": "0", "type": [{"code": "Attachment"}]},
+        {"id": "Patient.extension", "path": "Patient.extension", "min": 0, "max": "*", "type": [{"code": "Extension"}]},
+        {"id": "Patient.extension:test", "path": "Patient.extension", "sliceName": "test", "min": 1, "max": "1",
+          "type": [{"code": "Extension"}]}
+      ]}}},
+    {"resource": {"resourceType": "StructureDefinition", "url": "http://example.com/Address", "type": "Address",
+      "kind": "complex-type", "derivation": "constraint",
+      "snapshot": {"element": [
+        {"id": "Address", "path": "Address", "min": 0, "max": "*"},
+        {"id": "Address.country", "path": "Address.country", "min": 1, "max": "1", "type": [{"code": "string"}],
+          "fixedString": "DE"}
+      ]}}},
+    {"resource": {"resourceType": "StructureDefinition", "url": "http://example.com/Questionnaire", "type": "Questionnaire",
+      "kind": "resource", "derivation": "constraint",
+      "differential": {"element": [
+        {"id": "Questionnaire", "path": "Questionnaire", "min": 0, "max": "*"},
+        {"id": "Questionnaire.item", "path": "Questionnaire.item", "min": 0, "max": "*", "type": [{"code": "BackboneElement"}]},
+        {"id": "Questionnaire.item.linkId", "path": "Questionnaire.item.linkId", "min": 1, "max": "1", "type": [{"code": "string"}]},
+        {"id": "Questionnaire.item.item", "path": "Questionnaire.item.item", "min": 0, "max": "*",
+          "contentReference": "#Questionnaire.item"}
+      ]}}}
+  ]
+}`
+
+const testProfilePatient = `{
+  "resourceType": "Patient",
+  "identifier": [{"system": "http://example.com/id", "value": "1"}],
+  "active": false,
+  "name": [{"family": "Doe", "given": ["Jane"]}],
+  "gender": "female",
+  "birthDate": "1974-12-25",
+  "deceasedBoolean": false,
+  "address": [{"city": "Berlin", "country": "DE"}],
+  "maritalStatus": {"coding": [{"system": "http://example.com/other", "code": "X"}, {"system": "http://example.com/cs", "code": "M", "display": "Married"}]}
+}`
+
+func newTestProfileValidator(t *testing.T) *LocalProfileValidator {
+	v := NewLocalProfileValidator()
+	err := v.Load(strings.NewReader(testProfileBundle))
+	assert.NoError(t, err, "no error expected")
+	return v
+}
+
+func modifiedTestProfilePatient(t *testing.T, modify func(patient map[string]interface{})) map[string]interface{} {
+	patient := decodeTestJSON(t, testProfilePatient, false)
+	modify(patient)
+	return patient
+}
+
+func assertConformsTo(t *testing.T, v *LocalProfileValidator, node interface{}, url string, expected bool) {
+	ctx := hipathsys.NewContext(NewModelAdapter(nil))
+	res, err := v.ConformsTo(ctx, node, url)
+	if assert.NoError(t, err, "no error expected") && assert.NotNil(t, res, "result expected") {
+		assert.Equal(t, expected, res.Bool())
+	}
+}
+
+func TestProfileValidatorConforms(t *testing.T) {
+	v := newTestProfileValidator(t)
+	assertConformsTo(t, v, decodeTestJSON(t, testProfilePatient, false), "http://example.com/Patient", true)
+}
+
+func TestProfileValidatorConformsNumber(t *testing.T) {
+	v := newTestProfileValidator(t)
+	assertConformsTo(t, v, decodeTestJSON(t, testProfilePatient, true), "http://example.com/Patient|1.0", true)
+}
+
+func TestProfileValidatorUnknownProfile(t *testing.T) {
+	v := newTestProfileValidator(t)
+	res, err := v.ConformsTo(hipathsys.NewContext(NewModelAdapter(nil)),
+		decodeTestJSON(t, testProfilePatient, false), "http://example.com/Other")
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestProfileValidatorResourceType(t *testing.T) {
+	v := newTestProfileValidator(t)
+	assertConformsTo(t, v, modifiedTestProfilePatient(t, func(p map[string]interface{}) {
+		p["resourceType"] = "Person"
+	}), "http://example.com/Patient", false)
+	assertConformsTo(t, v, hipathsys.NewString("test"), "http://example.com/Patient", false)
+}
+
+func TestProfileValidatorMinCardinality(t *testing.T) {
+	v := newTestProfileValidator(t)
+	assertConformsTo(t, v, modifiedTestProfilePatient(t, func(p map[string]interface{}) {
+		delete(p, "name")
+	}), "http://example.com/Patient", false)
+}
+
+func TestProfileValidatorNestedMinCardinality(t *testing.T) {
+	v := newTestProfileValidator(t)
+	assertConformsTo(t, v, modifiedTestProfilePatient(t, func(p map[string]interface{}) {
+		p["identifier"] = []interface{}{map[string]interface{}{"value": "1"}}
+	}), "http://example.com/Patient", false)
+}
+
+func TestProfileValidatorMaxCardinality(t *testing.T) {
+	v := newTestProfileValidator(t)
+	assertConformsTo(t, v, modifiedTestProfilePatient(t, func(p map[string]interface{}) {
+		p["name"] = []interface{}{map[string]interface{}{"family": []interface{}{"Doe", "Roe"}}}
+	}), "http://example.com/Patient", false)
+}
+
+func TestProfileValidatorProhibited(t *testing.T) {
+	v := newTestProfileValidator(t)
+	assertConformsTo(t, v, modifiedTestProfilePatient(t, func(p map[string]interface{}) {
+		p["photo"] = []interface{}{map[string]interface{}{"url": "http://example.com/photo"}}
+	}), "http://example.com/Patient", false)
+}
+
+func TestProfileValidatorFixed(t *testing.T) {
+	v := newTestProfileValidator(t)
+	assertConformsTo(t, v, modifiedTestProfilePatient(t, func(p map[string]interface{}) {
+		p["gender"] = "male"
+	}), "http://example.com/Patient", false)
+}
+
+func TestProfileValidatorPattern(t *testing.T) {
+	v := newTestProfileValidator(t)
+	assertConformsTo(t, v, modifiedTestProfilePatient(t, func(p map[string]interface{}) {
+		p["maritalStatus"] = map[string]interface{}{"coding": []interface{}{
+			map[string]interface{}{"system": "http://example.com/cs", "code": "S"}}}
+	}), "http://example.com/Patient", false)
+	assertConformsTo(t, v, modifiedTestProfilePatient(t, func(p map[string]interface{}) {
+		p["maritalStatus"] = map[string]interface{}{"text": "married"}
+	}), "http://example.com/Patient", false)
+}
+
+func TestProfileValidatorPrimitiveType(t *testing.T) {
+	v := newTestProfileValidator(t)
+	assertConformsTo(t, v, modifiedTestProfilePatient(t, func(p map[string]interface{}) {
+		p["active"] = "false"
+	}), "http://example.com/Patient", false)
+	assertConformsTo(t, v, modifiedTestProfilePatient(t, func(p map[string]interface{}) {
+		p["birthDate"] = 1974.0
+	}), "http://example.com/Patient", false)
+}
+
+func TestProfileValidatorPrimitiveExtensionOnly(t *testing.T) {
+	v := newTestProfileValidator(t)
+	assertConformsTo(t, v, modifiedTestProfilePatient(t, func(p map[string]interface{}) {
+		delete(p, "birthDate")
+		p["_birthDate"] = map[string]interface{}{"extension": []interface{}{
+			map[string]interface{}{"url": "http://example.com/ext", "valueString": "unknown"}}}
+	}), "http://example.com/Patient", true)
+}
+
+func TestProfileValidatorComplexType(t *testing.T) {
+	v := newTestProfileValidator(t)
+	assertConformsTo(t, v, modifiedTestProfilePatient(t, func(p map[string]interface{}) {
+		p["maritalStatus"] = "M"
+	}), "http://example.com/Patient", false)
+}
+
+func TestProfileValidatorChoiceType(t *testing.T) {
+	v := newTestProfileValidator(t)
+	assertConformsTo(t, v, modifiedTestProfilePatient(t, func(p map[string]interface{}) {
+		delete(p, "deceasedBoolean")
+		p["deceasedDateTime"] = "2020-01-01"
+	}), "http://example.com/Patient", true)
+	assertConformsTo(t, v, modifiedTestProfilePatient(t, func(p map[string]interface{}) {
+		delete(p, "deceasedBoolean")
+		p["deceasedString"] = "yes"
+	}), "http://example.com/Patient", false)
+	assertConformsTo(t, v, modifiedTestProfilePatient(t, func(p map[string]interface{}) {
+		p["deceasedDateTime"] = "2020-01-01"
+	}), "http://example.com/Patient", false)
+}
+
+func TestProfileValidatorConstraint(t *testing.T) {
+	v := newTestProfileValidator(t)
+	assertConformsTo(t, v, modifiedTestProfilePatient(t, func(p map[string]interface{}) {
+		p["name"] = []interface{}{map[string]interface{}{"given": []interface{}{"Jane"}}}
+	}), "http://example.com/Patient", false)
+}
+
+func TestProfileValidatorTypeProfile(t *testing.T) {
+	v := newTestProfileValidator(t)
+	assertConformsTo(t, v, modifiedTestProfilePatient(t, func(p map[string]interface{}) {
+		p["address"] = []interface{}{map[string]interface{}{"city": "Vienna", "country": "AT"}}
+	}), "http://example.com/Patient", false)
+	assertConformsTo(t, v, map[string]interface{}{"country": "DE"}, "http://example.com/Address", true)
+}
+
+func TestProfileValidatorTypeProfileUnavailable(t *testing.T) {
+	v := NewLocalProfileValidator()
+	bundle := decodeTestJSON(t, testProfileBundle, false)
+	entry := bundle["entry"].([]interface{})[0].(map[string]interface{})
+	assert.NoError(t, v.Add(entry["resource"].(map[string]interface{})), "no error expected")
+
+	res, err := v.ConformsTo(hipathsys.NewContext(NewModelAdapter(nil)),
+		decodeTestJSON(t, testProfilePatient, false), "http://example.com/Patient")
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestProfileValidatorContentReference(t *testing.T) {
+	v := newTestProfileValidator(t)
+	questionnaire := decodeTestJSON(t, `{"resourceType": "Questionnaire", "item": [
+      {"linkId": "1", "item": [{"linkId": "1.1", "item": [{"linkId": "1.1.1"}]}]}]}`, false)
+	assertConformsTo(t, v, questionnaire, "http://example.com/Questionnaire", true)
+
+	questionnaire = decodeTestJSON(t, `{"resourceType": "Questionnaire", "item": [
+      {"linkId": "1", "item": [{"linkId": "1.1", "item": [{"text": "missing"}]}]}]}`, false)
+	assertConformsTo(t, v, questionnaire, "http://example.com/Questionnaire", false)
+}
+
+func TestProfileValidatorConstraintInvalid(t *testing.T) {
+	v := NewLocalProfileValidator()
+	err := v.Add(decodeTestJSON(t, `{"resourceType": "StructureDefinition", "url": "http://example.com/Invalid",
+      "type": "Patient", "kind": "resource", "snapshot": {"element": [
+        {"id": "Patient", "path": "Patient", "constraint": [{"key": "inv-1", "severity": "error", "expression": "name.("}]}]}}`, false))
+	assert.NoError(t, err, "no error expected")
+
+	res, err := v.ConformsTo(hipathsys.NewContext(NewModelAdapter(nil)),
+		decodeTestJSON(t, testProfilePatient, false), "http://example.com/Invalid")
+	if assert.Error(t, err, "error expected") {
+		assert.Contains(t, err.Error(), "inv-1")
+	}
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestProfileValidatorConformsToFunction(t *testing.T) {
+	v := newTestProfileValidator(t)
+	ctx := hipathsys.NewContext(NewModelAdapter(nil), hipathsys.WithProfileValidator(v))
+	patient := decodeTestJSON(t, testProfilePatient, false)
+
+	res, err := gohipath.Execute(ctx, "Patient.conformsTo('http://example.com/Patient')", patient)
+	assert.Nil(t, err, "no error expected")
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.True, res.Get(0))
+	}
+
+	res, err = gohipath.Execute(ctx, "Patient.address.first().conformsTo('http://example.com/Address')", patient)
+	assert.Nil(t, err, "no error expected")
+	if assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.True, res.Get(0))
+	}
+}
+
+func TestProfileValidatorNestedConformsTo(t *testing.T) {
+	v := newTestProfileValidator(t)
+	err := v.Add(decodeTestJSON(t, `{"resourceType": "StructureDefinition", "url": "http://example.com/Nested",
+      "type": "Patient", "kind": "resource", "snapshot": {"element": [
+        {"id": "Patient", "path": "Patient", "constraint": [{"key": "nst-1", "severity": "error",
+          "expression": "address.all(conformsTo('http://example.com/Address'))"}]}]}}`, false))
+	assert.NoError(t, err, "no error expected")
+
+	assertConformsTo(t, v, decodeTestJSON(t, testProfilePatient, false), "http://example.com/Nested", true)
+	assertConformsTo(t, v, modifiedTestProfilePatient(t, func(p map[string]interface{}) {
+		p["address"] = []interface{}{map[string]interface{}{"country": "AT"}}
+	}), "http://example.com/Nested", false)
+}
+
+const testProfileConstraint = `{"resourceType": "StructureDefinition", "url": "http://example.com/Constraint",
+  "type": "Patient", "kind": "resource", "snapshot": {"element": [
+    {"id": "Patient", "path": "Patient", "constraint": [{"key": "cst-1", "severity": "error", "expression": %q}]}]}}`
+
+func newTestConstraintProfileValidator(t *testing.T, expression string, opts ...ProfileValidatorOption) *LocalProfileValidator {
+	v := NewLocalProfileValidator(opts...)
+	err := v.Add(decodeTestJSON(t, fmt.Sprintf(testProfileConstraint, expression), false))
+	assert.NoError(t, err, "no error expected")
+	return v
+}
+
+func TestProfileValidatorConstraintEnvVar(t *testing.T) {
+	v := newTestConstraintProfileValidator(t, "name.family = %family and %context = %resource")
+	patient := decodeTestJSON(t, testProfilePatient, false)
+
+	res, err := v.ConformsTo(hipathsys.NewContext(NewModelAdapter(nil),
+		hipathsys.WithEnvVar("family", hipathsys.NewString("Doe"))), patient, "http://example.com/Constraint")
+	if assert.NoError(t, err, "no error expected") && assert.NotNil(t, res, "result expected") {
+		assert.Equal(t, true, res.Bool())
+	}
+
+	res, err = v.ConformsTo(hipathsys.NewContext(NewModelAdapter(nil),
+		hipathsys.WithEnvVar("family", hipathsys.NewString("Roe"))), patient, "http://example.com/Constraint")
+	if assert.NoError(t, err, "no error expected") && assert.NotNil(t, res, "result expected") {
+		assert.Equal(t, false, res.Bool())
+	}
+}
+
+func TestProfileValidatorConstraintLimits(t *testing.T) {
+	expression := "(1 | 2 | 3 | 4 | 5 | 6 | 7 | 8 | 9 | 10).select($this + 1).where($this > 2).exists()"
+	v := newTestConstraintProfileValidator(t, expression)
+	ctx := hipathsys.NewContext(NewModelAdapter(nil), hipathsys.WithProfileValidator(v),
+		hipathsys.WithLimits(hipathsys.Limits{MaxSteps: 23}))
+	patient := decodeTestJSON(t, testProfilePatient, false)
+
+	// the constraint alone does not exceed the limit, but the invoking path is
+	// evaluated with the same limiter
+	res, err := gohipath.Execute(ctx, expression, patient)
+	assert.Nil(t, err, "no error expected")
+	assert.Equal(t, 1, res.Count())
+
+	res, err = gohipath.Execute(ctx, "Patient.conformsTo('http://example.com/Constraint')", patient)
+	if assert.NotNil(t, err, "error expected") {
+		assert.True(t, errors.Is(err, hipathsys.ErrLimitExceeded))
+	}
+	assert.Nil(t, res, "no result expected")
+}
+
+type testFamilyFunction struct {
+	hipathsys.BaseFunction
+}
+
+func (f *testFamilyFunction) Execute(hipathsys.ContextAccessor, interface{}, []interface{}, hipathsys.Looper) (interface{}, error) {
+	return hipathsys.NewString("Doe"), nil
+}
+
+func TestProfileValidatorConstraintFunctions(t *testing.T) {
+	functions := gohipath.NewFunctionRegistry()
+	err := functions.Register(&testFamilyFunction{
+		BaseFunction: hipathsys.NewBaseFunction("expectedFamily", -1, 0, 0),
+	})
+	assert.NoError(t, err, "no error expected")
+
+	v := newTestConstraintProfileValidator(t, "name.family = expectedFamily()", WithConstraintFunctions(functions))
+	assertConformsTo(t, v, decodeTestJSON(t, testProfilePatient, false), "http://example.com/Constraint", true)
+	assertConformsTo(t, v, modifiedTestProfilePatient(t, func(p map[string]interface{}) {
+		p["name"] = []interface{}{map[string]interface{}{"family": "Roe"}}
+	}), "http://example.com/Constraint", false)
+}
+
+func TestProfileValidatorConstraintFunctionsNotRegistered(t *testing.T) {
+	v := newTestConstraintProfileValidator(t, "name.family = expectedFamily()")
+	res, err := v.ConformsTo(hipathsys.NewContext(NewModelAdapter(nil)),
+		decodeTestJSON(t, testProfilePatient, false), "http://example.com/Constraint")
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestProfileValidatorAddNoStructureDefinition(t *testing.T) {
+	v := NewLocalProfileValidator()
+	err := v.Add(map[string]interface{}{"resourceType": "ValueSet"})
+	assert.Error(t, err, "error expected")
+}
+
+func TestProfileValidatorAddNoURL(t *testing.T) {
+	v := NewLocalProfileValidator()
+	err := v.Add(map[string]interface{}{"resourceType": "StructureDefinition", "type": "Patient"})
+	assert.Error(t, err, "error expected")
+}
+
+func TestProfileValidatorAddNoType(t *testing.T) {
+	v := NewLocalProfileValidator()
+	err := v.Add(map[string]interface{}{"resourceType": "StructureDefinition", "url": "http://example.com/Test"})
+	assert.Error(t, err, "error expected")
+}
+
+func TestProfileValidatorAddNoElements(t *testing.T) {
+	v := NewLocalProfileValidator()
+	err := v.Add(map[string]interface{}{"resourceType": "StructureDefinition",
+		"url": "http://example.com/Test", "type": "Patient"})
+	assert.Error(t, err, "error expected")
+}
+
+func TestProfileValidatorAddNoRoot(t *testing.T) {
+	v := NewLocalProfileValidator()
+	err := v.Add(decodeTestJSON(t, `{"resourceType": "StructureDefinition", "url": "http://example.com/Test",
+      "type": "Patient", "snapshot": {"element": [{"path": "Patient.name"}]}}`, false))
+	assert.Error(t, err, "error expected")
+}
+
+func TestProfileValidatorAddInvalidCardinality(t *testing.T) {
+	v := NewLocalProfileValidator()
+	err := v.Add(decodeTestJSON(t, `{"resourceType": "StructureDefinition", "url": "http://example.com/Test",
+      "type": "Patient", "snapshot": {"element": [{"path": "Patient"}, {"path": "Patient.name", "max": "x"}]}}`, false))
+	assert.Error(t, err, "error expected")
+}
+
+func TestProfileValidatorLoadInvalid(t *testing.T) {
+	v := NewLocalProfileValidator()
+	err := v.Load(strings.NewReader("{"))
+	assert.Error(t, err, "error expected")
+}
+
+func TestProfileValidatorLoadDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "profiles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "profiles.json"), []byte(testProfileBundle), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "readme.txt"), []byte("test"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	v := NewLocalProfileValidator()
+	err = v.LoadDir(dir)
+	assert.NoError(t, err, "no error expected")
+	assertConformsTo(t, v, decodeTestJSON(t, testProfilePatient, false), "http://example.com/Patient", true)
+}
+
+func TestProfileValidatorLoadFileNotFound(t *testing.T) {
+	v := NewLocalProfileValidator()
+	err := v.LoadFile(filepath.Join(os.TempDir(), "not-existing-profile.json"))
+	assert.Error(t, err, "error expected")
+}
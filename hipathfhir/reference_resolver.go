@@ -0,0 +1,196 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathfhir
+
+import (
+	"fmt"
+	"github.com/healthiop/hipath/hipathsys"
+	"strings"
+	"sync"
+)
+
+const historyPathSegment = "_history"
+
+type ResourceStore struct {
+	lock      sync.RWMutex
+	resources map[string]map[string]interface{}
+}
+
+func NewResourceStore() *ResourceStore {
+	return &ResourceStore{resources: make(map[string]map[string]interface{})}
+}
+
+func (s *ResourceStore) Add(resource map[string]interface{}) error {
+	resourceType, _ := resource["resourceType"].(string)
+	id, _ := resource["id"].(string)
+	if len(resourceType) == 0 || len(id) == 0 {
+		return fmt.Errorf("resource type and ID are required to store a resource")
+	}
+	return s.AddWithURL(resourceType+"/"+id, resource)
+}
+
+func (s *ResourceStore) AddWithURL(url string, resource map[string]interface{}) error {
+	if len(url) == 0 {
+		return fmt.Errorf("URL is required to store a resource")
+	}
+	if resource == nil {
+		return fmt.Errorf("resource must not be nil")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.resources[url] = resource
+	return nil
+}
+
+func (s *ResourceStore) Lookup(reference string) map[string]interface{} {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if resource, found := s.resources[reference]; found {
+		return resource
+	}
+	if resourceType, id, ok := referenceTypeID(reference); ok {
+		return s.resources[resourceType+"/"+id]
+	}
+	return nil
+}
+
+type referenceResolver struct {
+	store *ResourceStore
+}
+
+func NewReferenceResolver(store *ResourceStore) hipathsys.ReferenceResolver {
+	return &referenceResolver{store}
+}
+
+func (r *referenceResolver) Resolve(ctx hipathsys.ContextAccessor, node interface{}, reference string) (interface{}, error) {
+	if strings.HasPrefix(reference, "#") {
+		container := containerOf(ctx, node)
+		if container == nil {
+			return nil, nil
+		}
+		if len(reference) == 1 {
+			return container, nil
+		}
+		return containedResource(ctx, container, reference[1:])
+	}
+
+	for _, name := range []string{"rootResource", "resource"} {
+		if n, found := ctx.EnvVar(name); found {
+			if resource := bundleEntryResource(JSONValue(n), reference); resource != nil {
+				return ctx.ModelAdapter().ConvertToSystem(resource)
+			}
+		}
+	}
+
+	if r.store != nil {
+		if resource := r.store.Lookup(reference); resource != nil {
+			return ctx.ModelAdapter().ConvertToSystem(resource)
+		}
+	}
+	return nil, nil
+}
+
+func containerOf(ctx hipathsys.ContextAccessor, node interface{}) *elementNode {
+	if e, ok := node.(*elementNode); ok && e.container != nil {
+		return e.container
+	}
+
+	// container is unknown when reference is not navigated from a resource
+	if n, found := ctx.EnvVar("resource"); found && n != nil {
+		if _, ok := n.(map[string]interface{}); ok {
+			n, _ = ctx.ModelAdapter().ConvertToSystem(n)
+		}
+		if e, ok := n.(*elementNode); ok && e.resource() {
+			return e
+		}
+	}
+	return nil
+}
+
+func containedResource(ctx hipathsys.ContextAccessor, container *elementNode, id string) (interface{}, error) {
+	contained, _ := container.value[containedElementName].([]interface{})
+	for _, c := range contained {
+		resource, _ := c.(map[string]interface{})
+		if resource != nil && resource["id"] == id {
+			n, err := ctx.ModelAdapter().ConvertToSystem(resource)
+			if e, ok := n.(*elementNode); ok {
+				e.container = container
+			}
+			return n, err
+		}
+	}
+	return nil, nil
+}
+
+func bundleEntryResource(node interface{}, reference string) map[string]interface{} {
+	bundle, _ := node.(map[string]interface{})
+	if bundle == nil || bundle["resourceType"] != "Bundle" {
+		return nil
+	}
+
+	resourceType, id, relative := referenceTypeID(reference)
+	relative = relative && !strings.Contains(reference, ":")
+
+	entries, _ := bundle["entry"].([]interface{})
+	for _, e := range entries {
+		entry, _ := e.(map[string]interface{})
+		resource, _ := entry["resource"].(map[string]interface{})
+		if resource == nil {
+			continue
+		}
+
+		fullURL, _ := entry["fullUrl"].(string)
+		if len(fullURL) > 0 && (fullURL == reference ||
+			relative && strings.HasSuffix(fullURL, "/"+resourceType+"/"+id)) {
+			return resource
+		}
+		if relative && resource["resourceType"] == resourceType && resource["id"] == id {
+			return resource
+		}
+	}
+	return nil
+}
+
+func referenceTypeID(reference string) (string, string, bool) {
+	parts := strings.Split(reference, "/")
+	if len(parts) > 2 && parts[len(parts)-2] == historyPathSegment {
+		parts = parts[:len(parts)-2]
+	}
+	if len(parts) < 2 {
+		return "", "", false
+	}
+
+	resourceType, id := parts[len(parts)-2], parts[len(parts)-1]
+	if len(resourceType) == 0 || len(id) == 0 || resourceType[0] < 'A' || resourceType[0] > 'Z' {
+		return "", "", false
+	}
+	return resourceType, id, true
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathfhir
+
+import (
+	"github.com/healthiop/hipath/hipathsys"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const testReferenceBundle = `{
+  "resourceType": "Bundle",
+  "type": "collection",
+  "entry": [
+    {
+      "fullUrl": "http://example.com/fhir/Observation/o1",
+      "resource": {
+        "resourceType": "Observation",
+        "id": "o1",
+        "contained": [
+          {"resourceType": "Practitioner", "id": "p1", "name": [{"family": "Contained"}]},
+          {"resourceType": "PractitionerRole", "id": "r1", "practitioner": {"reference": "#p1"}}
+        ],
+        "subject": {"reference": "Patient/pat1"},
+        "performer": [{"reference": "#p1"}, {"reference": "#r1"}, {"reference": "#"}],
+        "specimen": {"reference": "urn:uuid:a6d80eda-d8d5-4d1a-9dcd-3f6a5c70e4e5"},
+        "device": {"reference": "Device/d1"},
+        "encounter": {"reference": "Encounter/e1/_history/2"}
+      }
+    },
+    {
+      "fullUrl": "http://example.com/fhir/Patient/pat1",
+      "resource": {"resourceType": "Patient", "id": "pat1",
+        "contained": [{"resourceType": "Practitioner", "id": "p1", "name": [{"family": "Other"}]}]}
+    },
+    {
+      "fullUrl": "urn:uuid:a6d80eda-d8d5-4d1a-9dcd-3f6a5c70e4e5",
+      "resource": {"resourceType": "Specimen", "id": "s1"}
+    },
+    {
+      "resource": {"resourceType": "Encounter", "id": "e1"}
+    }
+  ]
+}`
+
+func TestReferenceResolverContained(t *testing.T) {
+	node := decodeTestJSON(t, testReferenceBundle, false)
+
+	res := executeTestPath(t, NewModelAdapter(nil),
+		"Bundle.entry.resource.ofType(Observation).performer.resolve().ofType(Practitioner).name.family", node,
+		hipathsys.WithReferenceResolver(NewReferenceResolver(nil)))
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("Contained"), res.Get(0))
+	}
+}
+
+func TestReferenceResolverContainedSibling(t *testing.T) {
+	node := decodeTestJSON(t, testReferenceBundle, false)
+
+	res := executeTestPath(t, NewModelAdapter(nil),
+		"Bundle.entry.resource.ofType(Observation).performer.resolve().ofType(PractitionerRole)"+
+			".practitioner.resolve().name.family", node,
+		hipathsys.WithReferenceResolver(NewReferenceResolver(nil)))
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("Contained"), res.Get(0))
+	}
+}
+
+func TestReferenceResolverContainer(t *testing.T) {
+	node := decodeTestJSON(t, testReferenceBundle, false)
+
+	res := executeTestPath(t, NewModelAdapter(nil),
+		"Bundle.entry.resource.ofType(Observation).performer.resolve().ofType(Observation).id", node,
+		hipathsys.WithReferenceResolver(NewReferenceResolver(nil)))
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("o1"), res.Get(0))
+	}
+}
+
+func TestReferenceResolverContainedResource(t *testing.T) {
+	node := decodeTestJSON(t, `{"resourceType": "Observation",
+		"contained": [{"resourceType": "Patient", "id": "p1"}],
+		"subject": {"reference": "#p1"}}`, false)
+
+	res := executeTestPath(t, NewModelAdapter(nil), "Observation.subject.reference.resolve().id", node,
+		hipathsys.WithReferenceResolver(NewReferenceResolver(nil)))
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("p1"), res.Get(0))
+	}
+
+	res = executeTestPath(t, NewModelAdapter(nil), "Observation.subject.resolve() is Patient", node,
+		hipathsys.WithReferenceResolver(NewReferenceResolver(nil)))
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.True, res.Get(0))
+	}
+}
+
+func TestReferenceResolverBundleRelative(t *testing.T) {
+	node := decodeTestJSON(t, testReferenceBundle, false)
+
+	res := executeTestPath(t, NewModelAdapter(nil),
+		"Bundle.entry.resource.ofType(Observation).subject.resolve().id", node,
+		hipathsys.WithReferenceResolver(NewReferenceResolver(nil)))
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("pat1"), res.Get(0))
+	}
+}
+
+func TestReferenceResolverBundleFullURL(t *testing.T) {
+	node := decodeTestJSON(t, testReferenceBundle, false)
+
+	res := executeTestPath(t, NewModelAdapter(nil),
+		"Bundle.entry.resource.ofType(Observation).specimen.resolve().id", node,
+		hipathsys.WithReferenceResolver(NewReferenceResolver(nil)))
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("s1"), res.Get(0))
+	}
+
+	res = executeTestPath(t, NewModelAdapter(nil), "'http://example.com/fhir/Patient/pat1'.resolve().id", node,
+		hipathsys.WithReferenceResolver(NewReferenceResolver(nil)))
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("pat1"), res.Get(0))
+	}
+}
+
+func TestReferenceResolverBundleHistory(t *testing.T) {
+	node := decodeTestJSON(t, testReferenceBundle, false)
+
+	res := executeTestPath(t, NewModelAdapter(nil),
+		"Bundle.entry.resource.ofType(Observation).encounter.resolve().id", node,
+		hipathsys.WithReferenceResolver(NewReferenceResolver(nil)))
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("e1"), res.Get(0))
+	}
+}
+
+func TestReferenceResolverNotFound(t *testing.T) {
+	node := decodeTestJSON(t, testReferenceBundle, false)
+
+	res := executeTestPath(t, NewModelAdapter(nil),
+		"Bundle.entry.resource.ofType(Observation).device.resolve()", node,
+		hipathsys.WithReferenceResolver(NewReferenceResolver(nil)))
+	assert.Equal(t, 0, res.Count())
+}
+
+func TestReferenceResolverStore(t *testing.T) {
+	store := NewResourceStore()
+	assert.NoError(t, store.Add(decodeTestJSON(t,
+		`{"resourceType": "Device", "id": "d1", "lotNumber": "123"}`, false)), "no error expected")
+	node := decodeTestJSON(t, testReferenceBundle, false)
+
+	res := executeTestPath(t, NewModelAdapter(nil),
+		"Bundle.entry.resource.ofType(Observation).device.resolve().lotNumber", node,
+		hipathsys.WithReferenceResolver(NewReferenceResolver(store)))
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("123"), res.Get(0))
+	}
+
+	res = executeTestPath(t, NewModelAdapter(nil), "'http://example.com/fhir/Device/d1/_history/1'.resolve().id", node,
+		hipathsys.WithReferenceResolver(NewReferenceResolver(store)))
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.NewString("d1"), res.Get(0))
+	}
+}
+
+func TestResourceStoreAddWithURL(t *testing.T) {
+	store := NewResourceStore()
+	resource := map[string]interface{}{"resourceType": "Device"}
+	assert.NoError(t, store.AddWithURL("urn:uuid:1", resource), "no error expected")
+	assert.NotNil(t, store.Lookup("urn:uuid:1"))
+	assert.Nil(t, store.Lookup("urn:uuid:2"))
+	assert.Nil(t, store.Lookup("Device/1"))
+}
+
+func TestResourceStoreAddInvalid(t *testing.T) {
+	store := NewResourceStore()
+	assert.Error(t, store.Add(map[string]interface{}{"resourceType": "Device"}), "error expected")
+	assert.Error(t, store.AddWithURL("", map[string]interface{}{}), "error expected")
+	assert.Error(t, store.AddWithURL("Device/1", nil), "error expected")
+}
+
+func TestReferenceTypeID(t *testing.T) {
+	resourceType, id, ok := referenceTypeID("http://example.com/fhir/Patient/1/_history/2")
+	assert.True(t, ok)
+	assert.Equal(t, "Patient", resourceType)
+	assert.Equal(t, "1", id)
+
+	_, _, ok = referenceTypeID("urn:uuid:1")
+	assert.False(t, ok)
+	_, _, ok = referenceTypeID("fhir/patient/1")
+	assert.False(t, ok)
+}
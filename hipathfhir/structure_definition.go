@@ -0,0 +1,303 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathfhir
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const systemTypeURLPrefix = "http://hl7.org/fhirpath/System."
+const fhirTypeExtensionURL = "http://hl7.org/fhir/StructureDefinition/structuredefinition-fhir-type"
+
+type structureDefinition struct {
+	typeName string
+	baseName string
+	kind     string
+	elements []interface{}
+}
+
+type backboneElement struct {
+	typeName string
+	elements []ElementDefinitionAccessor
+}
+
+func (r *TypeRegistry) LoadFile(filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return r.Load(f)
+}
+
+func (r *TypeRegistry) Load(reader io.Reader) error {
+	var resource map[string]interface{}
+	if err := json.NewDecoder(reader).Decode(&resource); err != nil {
+		return fmt.Errorf("structure definitions cannot be decoded: %v", err)
+	}
+	return r.RegisterStructureDefinitions(resource)
+}
+
+func (r *TypeRegistry) RegisterStructureDefinitions(resource map[string]interface{}) error {
+	sds, err := structureDefinitions(resource)
+	if err != nil {
+		return err
+	}
+
+	pending := make(map[string]*structureDefinition)
+	for _, sd := range sds {
+		pending[sd.typeName] = sd
+	}
+
+	// base types must be registered before the types that are derived from them
+	backboneElements := make([]map[string]*backboneElement, 0)
+	for len(pending) > 0 {
+		registered := false
+		for _, sd := range sds {
+			if pending[sd.typeName] != sd || pending[sd.baseName] != nil {
+				continue
+			}
+			if len(sd.baseName) > 0 && r.TypeSpec(sd.baseName) == nil {
+				continue
+			}
+
+			elements, backbone, err := sd.elementDefinitions()
+			if err != nil {
+				return err
+			}
+			if _, err := r.RegisterType(sd.typeName, sd.baseName, elements...); err != nil {
+				return err
+			}
+			backboneElements = append(backboneElements, backbone)
+			delete(pending, sd.typeName)
+			registered = true
+		}
+
+		if !registered {
+			names := make([]string, 0, len(pending))
+			for name := range pending {
+				names = append(names, name)
+			}
+			return fmt.Errorf("base types of structure definitions are not available: %s",
+				strings.Join(names, ", "))
+		}
+	}
+
+	for _, backbone := range backboneElements {
+		for path, b := range backbone {
+			if err := r.RegisterBackboneElement(path, b.typeName, b.elements...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func structureDefinitions(resource map[string]interface{}) ([]*structureDefinition, error) {
+	switch resource["resourceType"] {
+	case "StructureDefinition":
+		sd, err := newStructureDefinition(resource)
+		if sd == nil || err != nil {
+			return nil, err
+		}
+		return []*structureDefinition{sd}, nil
+	case "Bundle":
+		sds := make([]*structureDefinition, 0)
+		entries, _ := resource["entry"].([]interface{})
+		for _, e := range entries {
+			entry, _ := e.(map[string]interface{})
+			res, _ := entry["resource"].(map[string]interface{})
+			if res == nil || res["resourceType"] != "StructureDefinition" {
+				continue
+			}
+			sd, err := newStructureDefinition(res)
+			if err != nil {
+				return nil, err
+			}
+			if sd != nil {
+				sds = append(sds, sd)
+			}
+		}
+		return sds, nil
+	}
+	return nil, fmt.Errorf("not a bundle or structure definition: %v", resource["resourceType"])
+}
+
+func newStructureDefinition(resource map[string]interface{}) (*structureDefinition, error) {
+	kind, _ := resource["kind"].(string)
+	if resource["derivation"] == "constraint" || kind == "logical" {
+		return nil, nil
+	}
+
+	typeName, _ := resource["type"].(string)
+	if len(typeName) == 0 {
+		return nil, fmt.Errorf("structure definition does not define a type: %v", resource["url"])
+	}
+
+	var baseName string
+	if baseDefinition, ok := resource["baseDefinition"].(string); ok {
+		baseName = baseDefinition[strings.LastIndexByte(baseDefinition, '/')+1:]
+	}
+
+	var elements []interface{}
+	for _, n := range []string{"snapshot", "differential"} {
+		if c, ok := resource[n].(map[string]interface{}); ok {
+			if elements, ok = c["element"].([]interface{}); ok {
+				break
+			}
+		}
+	}
+
+	return &structureDefinition{
+		typeName: typeName,
+		baseName: baseName,
+		kind:     kind,
+		elements: elements,
+	}, nil
+}
+
+func (sd *structureDefinition) elementDefinitions() ([]ElementDefinitionAccessor, map[string]*backboneElement, error) {
+	elements := make([]ElementDefinitionAccessor, 0)
+	backbone := make(map[string]*backboneElement)
+	typeNames := make(map[string][]string)
+
+	for _, e := range sd.elements {
+		element, _ := e.(map[string]interface{})
+		path, _ := element["path"].(string)
+		pi := strings.LastIndexByte(path, '.')
+		if pi < 0 {
+			continue
+		}
+		if sd.kind == "primitive-type" && path == sd.typeName+".value" {
+			continue
+		}
+
+		min, max, err := elementCardinality(element)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid cardinality of element %s: %v", path, err)
+		}
+		if max == 0 {
+			continue
+		}
+
+		types := elementTypeNames(element)
+		typeNames[path] = types
+		def := NewElementDefinitionWithCardinality(path[pi+1:], min, max, types...)
+
+		parentPath := path[:pi]
+		if parentPath == sd.typeName {
+			elements = append(elements, def)
+		} else if b, ok := backbone[parentPath]; ok {
+			b.elements = append(b.elements, def)
+		} else {
+			parentTypes := typeNames[parentPath]
+			if len(parentTypes) != 1 {
+				return nil, nil, fmt.Errorf("element %s has no unique parent type", path)
+			}
+			backbone[parentPath] = &backboneElement{
+				typeName: parentTypes[0],
+				elements: []ElementDefinitionAccessor{def},
+			}
+		}
+	}
+
+	return elements, backbone, nil
+}
+
+func elementCardinality(element map[string]interface{}) (int, int, error) {
+	min := 0
+	if v, ok := jsonNumber(element["min"]); ok {
+		min = int(v)
+	}
+
+	max := 1
+	switch v := element["max"].(type) {
+	case string:
+		if v == "*" {
+			max = UnboundedCardinality
+		} else {
+			var err error
+			if max, err = strconv.Atoi(v); err != nil {
+				return 0, 0, err
+			}
+		}
+	case nil:
+	default:
+		return 0, 0, fmt.Errorf("unsupported maximum cardinality: %v", v)
+	}
+
+	return min, max, nil
+}
+
+func elementTypeNames(element map[string]interface{}) []string {
+	if ref, ok := element["contentReference"].(string); ok {
+		return []string{ref[strings.IndexByte(ref, '#')+1:]}
+	}
+
+	types, _ := element["type"].([]interface{})
+	typeNames := make([]string, 0, len(types))
+	for _, t := range types {
+		if code := elementTypeCode(t); len(code) > 0 {
+			typeNames = append(typeNames, code)
+		}
+	}
+	return typeNames
+}
+
+func elementTypeCode(t interface{}) string {
+	elementType, _ := t.(map[string]interface{})
+	code, _ := elementType["code"].(string)
+	if !strings.HasPrefix(code, systemTypeURLPrefix) {
+		return code
+	}
+
+	extensions, _ := elementType["extension"].([]interface{})
+	for _, e := range extensions {
+		extension, _ := e.(map[string]interface{})
+		if extension["url"] == fhirTypeExtensionURL {
+			for _, n := range []string{"valueUrl", "valueUri", "valueString"} {
+				if v, ok := extension[n].(string); ok {
+					return v
+				}
+			}
+		}
+	}
+
+	name := code[len(systemTypeURLPrefix):]
+	c, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(c)) + name[size:]
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathfhir
+
+import (
+	"github.com/healthiop/hipath/hipathsys"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+var testStructureDefinitions = `{
+  "resourceType": "Bundle",
+  "entry": [
+    {"resource": {"resourceType": "StructureDefinition", "type": "Patient", "kind": "resource",
+      "baseDefinition": "http://hl7.org/fhir/StructureDefinition/DomainResource",
+      "snapshot": {"element": [
+        {"path": "Patient", "min": 0, "max": "*"},
+        {"path": "Patient.id", "min": 0, "max": "1", "type": [{"code": "http://hl7.org/fhirpath/System.String",
+          "extension": [{"url": "http://hl7.org/fhir/StructureDefinition/structuredefinition-fhir-type", "valueUrl": "id"}]}]},
+        {"path": "Patient.name", "min": 0, "max": "*", "type": [{"code": "HumanName"}]},
+        {"path": "Patient.deceased[x]", "min": 0, "max": "1", "type": [{"code": "boolean"}, {"code": "dateTime"}]},
+        {"path": "Patient.photo", "min": 0, "max": "0", "type": [{"code": "Attachment"}]},
+        {"path": "Patient.contact", "min": 0, "max": "*", "type": [{"code": "BackboneElement"}]},
+        {"path": "Patient.contact.name", "min": 1, "max": "1", "type": [{"code": "HumanName"}]},
+        {"path": "Patient.contact.gender", "min": 0, "max": "1", "type": [{"code": "code"}]}
+      ]}}},
+    {"resource": {"resourceType": "StructureDefinition", "type": "HumanName", "kind": "complex-type",
+      "baseDefinition": "http://hl7.org/fhir/StructureDefinition/Element",
+      "differential": {"element": [
+        {"path": "HumanName", "min": 0, "max": "*"},
+        {"path": "HumanName.family", "min": 0, "max": "1", "type": [{"code": "string"}]},
+        {"path": "HumanName.given", "min": 0, "max": "*", "type": [{"code": "string"}]}
+      ]}}},
+    {"resource": {"resourceType": "StructureDefinition", "type": "markdown", "kind": "primitive-type",
+      "baseDefinition": "http://hl7.org/fhir/StructureDefinition/string",
+      "snapshot": {"element": [
+        {"path": "markdown", "min": 0, "max": "*"},
+        {"path": "markdown.value", "min": 0, "max": "1", "type": [{"code": "http://hl7.org/fhirpath/System.String"}]}
+      ]}}},
+    {"resource": {"resourceType": "StructureDefinition", "type": "Questionnaire", "kind": "resource",
+      "baseDefinition": "http://hl7.org/fhir/StructureDefinition/DomainResource",
+      "snapshot": {"element": [
+        {"path": "Questionnaire", "min": 0, "max": "*"},
+        {"path": "Questionnaire.item", "min": 0, "max": "*", "type": [{"code": "BackboneElement"}]},
+        {"path": "Questionnaire.item.linkId", "min": 1, "max": "1", "type": [{"code": "string"}]},
+        {"path": "Questionnaire.item.item", "min": 0, "max": "*", "contentReference": "#Questionnaire.item"}
+      ]}}},
+    {"resource": {"resourceType": "StructureDefinition", "type": "Patient", "kind": "resource",
+      "derivation": "constraint", "baseDefinition": "http://hl7.org/fhir/StructureDefinition/Patient"}},
+    {"resource": {"resourceType": "ValueSet"}}
+  ]
+}`
+
+func TestTypeRegistryLoad(t *testing.T) {
+	r := NewTypeRegistry()
+	err := r.Load(strings.NewReader(testStructureDefinitions))
+	assert.NoError(t, err, "no error expected")
+
+	ts := r.TypeSpec("Patient")
+	if assert.NotNil(t, ts) {
+		assert.True(t, ts.ExtendsName(hipathsys.NewFQTypeName("DomainResource", "FHIR")))
+	}
+	ts = r.TypeSpec("markdown")
+	if assert.NotNil(t, ts) {
+		assert.True(t, ts.ExtendsName(hipathsys.NewFQTypeName("string", "FHIR")))
+	}
+	assert.Nil(t, r.ElementDefinition("markdown", "value"))
+
+	d := r.ElementDefinition("Patient", "id")
+	if assert.NotNil(t, d) {
+		assert.Equal(t, []string{"id"}, d.TypeNames())
+	}
+	d = r.ElementDefinition("Patient", "name")
+	if assert.NotNil(t, d) {
+		assert.Equal(t, 0, d.Min())
+		assert.Equal(t, UnboundedCardinality, d.Max())
+		assert.Equal(t, []string{"HumanName"}, d.TypeNames())
+	}
+	assert.Nil(t, r.ElementDefinition("Patient", "photo"))
+
+	d, typeName := r.ChoiceElementDefinition("Patient", "deceasedDateTime")
+	if assert.NotNil(t, d) {
+		assert.True(t, d.Choice())
+		assert.Equal(t, "dateTime", typeName)
+	}
+}
+
+func TestTypeRegistryLoadBackboneElement(t *testing.T) {
+	r := NewTypeRegistry()
+	err := r.Load(strings.NewReader(testStructureDefinitions))
+	assert.NoError(t, err, "no error expected")
+
+	ts := r.TypeSpec("Patient.contact")
+	if assert.NotNil(t, ts) {
+		assert.Equal(t, "FHIR.BackboneElement", ts.String())
+	}
+	d := r.ElementDefinition("Patient.contact", "name")
+	if assert.NotNil(t, d) {
+		assert.Equal(t, 1, d.Min())
+		assert.Equal(t, 1, d.Max())
+		assert.Equal(t, []string{"HumanName"}, d.TypeNames())
+	}
+	assert.NotNil(t, r.ElementDefinition("Patient.contact", "modifierExtension"))
+
+	d = r.ElementDefinition("Questionnaire.item", "item")
+	if assert.NotNil(t, d) {
+		assert.Equal(t, []string{"Questionnaire.item"}, d.TypeNames())
+	}
+}
+
+func TestTypeRegistryLoadNavigation(t *testing.T) {
+	r := NewTypeRegistry()
+	err := r.Load(strings.NewReader(testStructureDefinitions))
+	assert.NoError(t, err, "no error expected")
+
+	node := decodeTestJSON(t, `{"resourceType": "Questionnaire",
+		"item": [{"linkId": "1", "item": [{"linkId": "1.1"}]}]}`, false)
+	res := executeTestPath(t, NewModelAdapter(r), "Questionnaire.item.item.is(FHIR.BackboneElement)", node)
+	if assert.Equal(t, 1, res.Count()) {
+		assertSystemEqual(t, hipathsys.True, res.Get(0))
+	}
+}
+
+func TestTypeRegistryLoadStructureDefinition(t *testing.T) {
+	r := NewTypeRegistry()
+	err := r.Load(strings.NewReader(`{"resourceType": "StructureDefinition", "type": "Money",
+		"kind": "complex-type", "baseDefinition": "http://hl7.org/fhir/StructureDefinition/Element",
+		"snapshot": {"element": [{"path": "Money"},
+		{"path": "Money.value", "min": 0, "max": "1", "type": [{"code": "decimal"}]}]}}`))
+	assert.NoError(t, err, "no error expected")
+
+	d := r.ElementDefinition("Money", "value")
+	if assert.NotNil(t, d) {
+		assert.Equal(t, []string{"decimal"}, d.TypeNames())
+	}
+}
+
+func TestTypeRegistryLoadUnknownBase(t *testing.T) {
+	r := NewTypeRegistry()
+	err := r.Load(strings.NewReader(`{"resourceType": "StructureDefinition", "type": "Price",
+		"kind": "complex-type", "baseDefinition": "http://hl7.org/fhir/StructureDefinition/Test"}`))
+	if assert.Error(t, err, "error expected") {
+		assert.Contains(t, err.Error(), "Price")
+	}
+	assert.Nil(t, r.TypeSpec("Price"))
+}
+
+func TestTypeRegistryLoadInvalidCardinality(t *testing.T) {
+	r := NewTypeRegistry()
+	err := r.Load(strings.NewReader(`{"resourceType": "StructureDefinition", "type": "Money",
+		"kind": "complex-type", "baseDefinition": "http://hl7.org/fhir/StructureDefinition/Element",
+		"snapshot": {"element": [{"path": "Money.value", "max": "x"}]}}`))
+	assert.Error(t, err, "error expected")
+}
+
+func TestTypeRegistryLoadNoType(t *testing.T) {
+	r := NewTypeRegistry()
+	err := r.Load(strings.NewReader(`{"resourceType": "StructureDefinition", "kind": "complex-type"}`))
+	assert.Error(t, err, "error expected")
+}
+
+func TestTypeRegistryLoadInvalidResource(t *testing.T) {
+	r := NewTypeRegistry()
+	err := r.Load(strings.NewReader(`{"resourceType": "Patient"}`))
+	assert.Error(t, err, "error expected")
+}
+
+func TestTypeRegistryLoadInvalidJSON(t *testing.T) {
+	r := NewTypeRegistry()
+	err := r.Load(strings.NewReader(`{"resourceType": `))
+	assert.Error(t, err, "error expected")
+}
+
+func TestTypeRegistryLoadFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "profiles-*.json")
+	if !assert.NoError(t, err, "no error expected") {
+		return
+	}
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(testStructureDefinitions)
+	assert.NoError(t, err, "no error expected")
+	assert.NoError(t, f.Close(), "no error expected")
+
+	r := NewTypeRegistry()
+	err = r.LoadFile(f.Name())
+	assert.NoError(t, err, "no error expected")
+	assert.NotNil(t, r.TypeSpec("HumanName"))
+}
+
+func TestTypeRegistryLoadFileNotFound(t *testing.T) {
+	r := NewTypeRegistry()
+	err := r.LoadFile("/non-existing/profiles-types.json")
+	assert.Error(t, err, "error expected")
+}
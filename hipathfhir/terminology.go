@@ -0,0 +1,479 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathfhir
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/healthiop/hipath/hipathsys"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const conceptPropertyName = "concept"
+
+type concept struct {
+	code       string
+	display    string
+	parents    []string
+	properties map[string][]string
+}
+
+type codeSystem struct {
+	url      string
+	name     string
+	codes    []string
+	concepts map[string]*concept
+}
+
+type LocalTerminology struct {
+	lock        sync.RWMutex
+	valueSets   map[string]map[string]interface{}
+	codeSystems map[string]*codeSystem
+	conceptMaps map[string]map[string]interface{}
+}
+
+func NewLocalTerminology() *LocalTerminology {
+	return &LocalTerminology{
+		valueSets:   make(map[string]map[string]interface{}),
+		codeSystems: make(map[string]*codeSystem),
+		conceptMaps: make(map[string]map[string]interface{}),
+	}
+}
+
+func (t *LocalTerminology) LoadDir(dirname string) error {
+	files, err := ioutil.ReadDir(dirname)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		if err := t.LoadFile(filepath.Join(dirname, f.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *LocalTerminology) LoadFile(filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := t.Load(f); err != nil {
+		return fmt.Errorf("%s: %v", filename, err)
+	}
+	return nil
+}
+
+func (t *LocalTerminology) Load(reader io.Reader) error {
+	var resource map[string]interface{}
+	if err := json.NewDecoder(reader).Decode(&resource); err != nil {
+		return fmt.Errorf("terminology resource cannot be decoded: %v", err)
+	}
+	return t.Add(resource)
+}
+
+func (t *LocalTerminology) Add(resource map[string]interface{}) error {
+	switch resource["resourceType"] {
+	case "ValueSet":
+		return t.addValueSet(resource)
+	case "CodeSystem":
+		return t.addCodeSystem(resource)
+	case "ConceptMap":
+		return t.addConceptMap(resource)
+	case "Bundle":
+		entries, _ := resource["entry"].([]interface{})
+		for _, e := range entries {
+			entry, _ := e.(map[string]interface{})
+			r, _ := entry["resource"].(map[string]interface{})
+			if r == nil {
+				continue
+			}
+			if rt := r["resourceType"]; rt != "ValueSet" && rt != "CodeSystem" && rt != "ConceptMap" {
+				continue
+			}
+			if err := t.Add(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("not a terminology resource: %v", resource["resourceType"])
+}
+
+func (t *LocalTerminology) addValueSet(resource map[string]interface{}) error {
+	url, _ := resource["url"].(string)
+	if len(url) == 0 {
+		return fmt.Errorf("value set does not define a URL")
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.valueSets[url] = resource
+	return nil
+}
+
+func (t *LocalTerminology) addCodeSystem(resource map[string]interface{}) error {
+	url, _ := resource["url"].(string)
+	if len(url) == 0 {
+		return fmt.Errorf("code system does not define a URL")
+	}
+
+	cs := &codeSystem{url: url, concepts: make(map[string]*concept)}
+	cs.name, _ = resource["name"].(string)
+	concepts, _ := resource["concept"].([]interface{})
+	cs.addConcepts(concepts, "")
+	for _, c := range cs.concepts {
+		for _, child := range c.properties["child"] {
+			if cc, found := cs.concepts[child]; found {
+				cc.parents = append(cc.parents, c.code)
+			}
+		}
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.codeSystems[url] = cs
+	return nil
+}
+
+func (t *LocalTerminology) addConceptMap(resource map[string]interface{}) error {
+	url, _ := resource["url"].(string)
+	if len(url) == 0 {
+		return fmt.Errorf("concept map does not define a URL")
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.conceptMaps[url] = resource
+	return nil
+}
+
+func (cs *codeSystem) addConcepts(concepts []interface{}, parent string) {
+	for _, v := range concepts {
+		value, _ := v.(map[string]interface{})
+		code, _ := value["code"].(string)
+		if len(code) == 0 {
+			continue
+		}
+
+		c := cs.concepts[code]
+		if c == nil {
+			c = &concept{code: code, properties: make(map[string][]string)}
+			cs.concepts[code] = c
+			cs.codes = append(cs.codes, code)
+		}
+		c.display, _ = value["display"].(string)
+		if len(parent) > 0 {
+			c.parents = append(c.parents, parent)
+		}
+
+		properties, _ := value["property"].([]interface{})
+		for _, p := range properties {
+			property, _ := p.(map[string]interface{})
+			name, _ := property["code"].(string)
+			if value, ok := conceptPropertyValue(property); ok && len(name) > 0 {
+				c.properties[name] = append(c.properties[name], value)
+				if name == "parent" {
+					c.parents = append(c.parents, value)
+				}
+			}
+		}
+
+		nested, _ := value["concept"].([]interface{})
+		cs.addConcepts(nested, code)
+	}
+}
+
+func conceptPropertyValue(property map[string]interface{}) (string, bool) {
+	for name, value := range property {
+		if !strings.HasPrefix(name, "value") {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			return v, true
+		case bool, float64, json.Number:
+			return fmt.Sprint(v), true
+		case map[string]interface{}:
+			if code, ok := v["code"].(string); ok {
+				return code, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (cs *codeSystem) subsumes(code1 string, code2 string) bool {
+	if code1 == code2 {
+		return true
+	}
+
+	visited := make(map[string]bool)
+	pending := []string{code2}
+	for len(pending) > 0 {
+		c := cs.concepts[pending[0]]
+		pending = pending[1:]
+		if c == nil {
+			continue
+		}
+		for _, p := range c.parents {
+			if p == code1 {
+				return true
+			}
+			if !visited[p] {
+				visited[p] = true
+				pending = append(pending, p)
+			}
+		}
+	}
+	return false
+}
+
+func (t *LocalTerminology) MemberOf(valueSetURL string, system string, code string) (hipathsys.BooleanAccessor, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	vs := t.valueSet(valueSetURL)
+	if vs == nil {
+		return nil, nil
+	}
+	member, err := t.valueSetContains(vs, system, code, make(map[string]bool))
+	if err != nil {
+		return nil, err
+	}
+	return hipathsys.BooleanOf(member), nil
+}
+
+func (t *LocalTerminology) Subsumes(system string, code1 string, code2 string) (hipathsys.BooleanAccessor, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	cs := t.codeSystem(system)
+	if cs == nil || cs.concepts[code1] == nil || cs.concepts[code2] == nil {
+		return nil, nil
+	}
+	return hipathsys.BooleanOf(cs.subsumes(code1, code2)), nil
+}
+
+func (t *LocalTerminology) valueSet(url string) map[string]interface{} {
+	return t.valueSets[canonicalURL(url)]
+}
+
+func (t *LocalTerminology) codeSystem(url string) *codeSystem {
+	return t.codeSystems[canonicalURL(url)]
+}
+
+func canonicalURL(url string) string {
+	if pos := strings.IndexByte(url, '|'); pos >= 0 {
+		return url[:pos]
+	}
+	return url
+}
+
+func (t *LocalTerminology) valueSetContains(vs map[string]interface{}, system string, code string, visited map[string]bool) (bool, error) {
+	url, _ := vs["url"].(string)
+	if visited[url] {
+		return false, fmt.Errorf("value set includes itself: %s", url)
+	}
+	visited[url] = true
+	defer delete(visited, url)
+
+	compose, _ := vs["compose"].(map[string]interface{})
+	if compose == nil {
+		expansion, _ := vs["expansion"].(map[string]interface{})
+		contains, _ := expansion["contains"].([]interface{})
+		return expansionContains(contains, system, code), nil
+	}
+
+	includes, _ := compose["include"].([]interface{})
+	included := false
+	for _, inc := range includes {
+		matches, err := t.composeMatches(inc, system, code, visited)
+		if err != nil {
+			return false, err
+		}
+		if matches {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return false, nil
+	}
+
+	excludes, _ := compose["exclude"].([]interface{})
+	for _, exc := range excludes {
+		matches, err := t.composeMatches(exc, system, code, visited)
+		if err != nil {
+			return false, err
+		}
+		if matches {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func expansionContains(contains []interface{}, system string, code string) bool {
+	for _, c := range contains {
+		value, _ := c.(map[string]interface{})
+		if value["code"] == code && (len(system) == 0 || value["system"] == system) {
+			return true
+		}
+		nested, _ := value["contains"].([]interface{})
+		if expansionContains(nested, system, code) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *LocalTerminology) composeMatches(c interface{}, system string, code string, visited map[string]bool) (bool, error) {
+	rule, _ := c.(map[string]interface{})
+	ruleSystem, _ := rule["system"].(string)
+	if len(ruleSystem) > 0 && len(system) > 0 && ruleSystem != system {
+		return false, nil
+	}
+
+	valueSets, _ := rule["valueSet"].([]interface{})
+	if len(ruleSystem) == 0 && len(valueSets) == 0 {
+		return false, nil
+	}
+	for _, v := range valueSets {
+		url, _ := v.(string)
+		vs := t.valueSet(url)
+		if vs == nil {
+			return false, fmt.Errorf("value set cannot be resolved: %s", url)
+		}
+		member, err := t.valueSetContains(vs, system, code, visited)
+		if !member || err != nil {
+			return false, err
+		}
+	}
+	if len(ruleSystem) == 0 {
+		return true, nil
+	}
+
+	concepts, _ := rule["concept"].([]interface{})
+	if len(concepts) > 0 {
+		for _, c := range concepts {
+			value, _ := c.(map[string]interface{})
+			if value["code"] == code {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	cs := t.codeSystem(ruleSystem)
+	filters, _ := rule["filter"].([]interface{})
+	if len(filters) > 0 && cs == nil {
+		return false, fmt.Errorf("code system is required to evaluate filter: %s", ruleSystem)
+	}
+	if cs != nil && cs.concepts[code] == nil {
+		return false, nil
+	}
+	for _, f := range filters {
+		filter, _ := f.(map[string]interface{})
+		matches, err := cs.filterMatches(filter, code)
+		if !matches || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (cs *codeSystem) filterMatches(filter map[string]interface{}, code string) (bool, error) {
+	property, _ := filter["property"].(string)
+	op, _ := filter["op"].(string)
+	value, _ := filter["value"].(string)
+
+	var values []string
+	if property == conceptPropertyName {
+		values = []string{code}
+	} else {
+		values = cs.concepts[code].properties[property]
+	}
+
+	switch op {
+	case "is-a":
+		return cs.subsumes(value, code), nil
+	case "descendent-of":
+		return value != code && cs.subsumes(value, code), nil
+	case "is-not-a":
+		return !cs.subsumes(value, code), nil
+	case "generalizes":
+		return cs.subsumes(code, value), nil
+	case "=":
+		return containsString(values, value), nil
+	case "in", "not-in":
+		found := false
+		for _, v := range strings.Split(value, ",") {
+			if containsString(values, strings.TrimSpace(v)) {
+				found = true
+				break
+			}
+		}
+		return found == (op == "in"), nil
+	case "regex":
+		re, err := regexp.Compile("^(?:" + value + ")$")
+		if err != nil {
+			return false, fmt.Errorf("invalid filter expression: %s", value)
+		}
+		for _, v := range values {
+			if re.MatchString(v) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "exists":
+		return (len(values) > 0) == (value == "true"), nil
+	}
+	return false, fmt.Errorf("unsupported filter operator: %s", op)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,474 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathfhir
+
+import (
+	"fmt"
+	"github.com/healthiop/hipath/hipathsys"
+	"sort"
+)
+
+type jsonCoding struct {
+	system  string
+	code    string
+	display string
+}
+
+func (c jsonCoding) key() string {
+	return c.system + "|" + c.code
+}
+
+func (c jsonCoding) value() map[string]interface{} {
+	v := map[string]interface{}{"code": c.code}
+	if len(c.system) > 0 {
+		v["system"] = c.system
+	}
+	if len(c.display) > 0 {
+		v["display"] = c.display
+	}
+	return v
+}
+
+func (t *LocalTerminology) Expand(_ hipathsys.ContextAccessor, valueSet interface{}, _ interface{}) (interface{}, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	vs := t.valueSetOf(valueSet)
+	if vs == nil {
+		return nil, nil
+	}
+	codings, err := t.expand(vs, make(map[string]bool))
+	if err != nil {
+		return nil, err
+	}
+
+	contains := make([]interface{}, len(codings))
+	for i, c := range codings {
+		contains[i] = c.value()
+	}
+	res := map[string]interface{}{
+		"resourceType": "ValueSet",
+		"status":       "active",
+		"expansion": map[string]interface{}{
+			"total":    float64(len(contains)),
+			"contains": contains,
+		},
+	}
+	if url, ok := vs["url"]; ok {
+		res["url"] = url
+	}
+	return res, nil
+}
+
+func (t *LocalTerminology) Lookup(_ hipathsys.ContextAccessor, coded interface{}, _ interface{}) (interface{}, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	for _, c := range codingsOf(coded) {
+		cs := t.codeSystem(c.system)
+		if cs == nil || cs.concepts[c.code] == nil {
+			continue
+		}
+
+		concept := cs.concepts[c.code]
+		parameters := []interface{}{
+			parameter("name", "valueString", cs.name),
+			parameter("display", "valueString", concept.display),
+		}
+		names := make([]string, 0, len(concept.properties))
+		for name := range concept.properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			for _, v := range concept.properties[name] {
+				parameters = append(parameters, map[string]interface{}{
+					"name": "property",
+					"part": []interface{}{
+						parameter("code", "valueCode", name),
+						parameter("value", "valueString", v),
+					},
+				})
+			}
+		}
+		return newParameters(parameters...), nil
+	}
+	return nil, nil
+}
+
+func (t *LocalTerminology) ValidateVS(_ hipathsys.ContextAccessor, valueSet interface{}, coded interface{}, _ interface{}) (interface{}, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	vs := t.valueSetOf(valueSet)
+	if vs == nil {
+		return nil, nil
+	}
+	for _, c := range codingsOf(coded) {
+		member, err := t.valueSetContains(vs, c.system, c.code, make(map[string]bool))
+		if err != nil {
+			return nil, err
+		}
+		if member {
+			return t.validationResult(true, c), nil
+		}
+	}
+	return t.validationResult(false, jsonCoding{}), nil
+}
+
+func (t *LocalTerminology) ValidateCS(_ hipathsys.ContextAccessor, codeSystem interface{}, coded interface{}, _ interface{}) (interface{}, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	url, _ := jsonString(codeSystem)
+	cs := t.codeSystem(url)
+	if cs == nil {
+		return nil, nil
+	}
+	for _, c := range codingsOf(coded) {
+		if (len(c.system) == 0 || c.system == cs.url) && cs.concepts[c.code] != nil {
+			c.system = cs.url
+			return t.validationResult(true, c), nil
+		}
+	}
+	return t.validationResult(false, jsonCoding{}), nil
+}
+
+func (t *LocalTerminology) SubsumesOutcome(_ hipathsys.ContextAccessor, system interface{}, coding1 interface{}, coding2 interface{}, _ interface{}) (interface{}, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	url, _ := jsonString(system)
+	cs := t.codeSystem(url)
+	codings1, codings2 := codingsOf(coding1), codingsOf(coding2)
+	if cs == nil || len(codings1) == 0 || len(codings2) == 0 {
+		return nil, nil
+	}
+
+	code1, code2 := codings1[0].code, codings2[0].code
+	if cs.concepts[code1] == nil || cs.concepts[code2] == nil {
+		return nil, nil
+	}
+
+	var outcome string
+	switch {
+	case code1 == code2:
+		outcome = "equivalent"
+	case cs.subsumes(code1, code2):
+		outcome = "subsumes"
+	case cs.subsumes(code2, code1):
+		outcome = "subsumed-by"
+	default:
+		outcome = "not-subsumed"
+	}
+	return newParameters(parameter("outcome", "valueCode", outcome)), nil
+}
+
+func (t *LocalTerminology) Translate(_ hipathsys.ContextAccessor, conceptMap interface{}, coded interface{}, _ interface{}) (interface{}, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	cm := t.conceptMapOf(conceptMap)
+	if cm == nil {
+		return nil, nil
+	}
+
+	matches := make([]interface{}, 0)
+	groups, _ := cm["group"].([]interface{})
+	for _, c := range codingsOf(coded) {
+		for _, g := range groups {
+			group, _ := g.(map[string]interface{})
+			source, _ := group["source"].(string)
+			if len(c.system) > 0 && len(source) > 0 && c.system != source {
+				continue
+			}
+			targetSystem, _ := group["target"].(string)
+
+			elements, _ := group["element"].([]interface{})
+			for _, e := range elements {
+				element, _ := e.(map[string]interface{})
+				if element["code"] != c.code {
+					continue
+				}
+				targets, _ := element["target"].([]interface{})
+				for _, tv := range targets {
+					target, _ := tv.(map[string]interface{})
+					matches = append(matches, translationMatch(targetSystem, target))
+				}
+			}
+		}
+	}
+
+	parameters := []interface{}{parameter("result", "valueBoolean", len(matches) > 0)}
+	return newParameters(append(parameters, matches...)...), nil
+}
+
+func translationMatch(system string, target map[string]interface{}) map[string]interface{} {
+	c := jsonCoding{system: system}
+	c.code, _ = target["code"].(string)
+	c.display, _ = target["display"].(string)
+
+	parts := make([]interface{}, 0, 2)
+	if equivalence, ok := target["equivalence"].(string); ok {
+		parts = append(parts, parameter("equivalence", "valueCode", equivalence))
+	} else if relationship, ok := target["relationship"].(string); ok {
+		parts = append(parts, parameter("relationship", "valueCode", relationship))
+	}
+	parts = append(parts, parameter("concept", "valueCoding", c.value()))
+	return map[string]interface{}{"name": "match", "part": parts}
+}
+
+func (t *LocalTerminology) validationResult(result bool, c jsonCoding) map[string]interface{} {
+	parameters := []interface{}{parameter("result", "valueBoolean", result)}
+	if result {
+		display := c.display
+		if cs := t.codeSystem(c.system); cs != nil && cs.concepts[c.code] != nil {
+			display = cs.concepts[c.code].display
+		}
+		if len(display) > 0 {
+			parameters = append(parameters, parameter("display", "valueString", display))
+		}
+	}
+	return newParameters(parameters...)
+}
+
+func (t *LocalTerminology) expand(vs map[string]interface{}, visited map[string]bool) ([]jsonCoding, error) {
+	url, _ := vs["url"].(string)
+	if visited[url] {
+		return nil, fmt.Errorf("value set includes itself: %s", url)
+	}
+	visited[url] = true
+	defer delete(visited, url)
+
+	compose, _ := vs["compose"].(map[string]interface{})
+	if compose == nil {
+		expansion, _ := vs["expansion"].(map[string]interface{})
+		contains, _ := expansion["contains"].([]interface{})
+		return expansionCodings(contains, make([]jsonCoding, 0)), nil
+	}
+
+	res := make([]jsonCoding, 0)
+	keys := make(map[string]bool)
+	includes, _ := compose["include"].([]interface{})
+	for _, inc := range includes {
+		codings, err := t.expandRule(inc, visited)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range codings {
+			if !keys[c.key()] {
+				keys[c.key()] = true
+				res = append(res, c)
+			}
+		}
+	}
+
+	excludes, _ := compose["exclude"].([]interface{})
+	if len(excludes) == 0 {
+		return res, nil
+	}
+	filtered := make([]jsonCoding, 0, len(res))
+	for _, c := range res {
+		excluded := false
+		for _, exc := range excludes {
+			matches, err := t.composeMatches(exc, c.system, c.code, visited)
+			if err != nil {
+				return nil, err
+			}
+			if matches {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered, nil
+}
+
+func (t *LocalTerminology) expandRule(r interface{}, visited map[string]bool) ([]jsonCoding, error) {
+	rule, _ := r.(map[string]interface{})
+	system, _ := rule["system"].(string)
+	valueSets, _ := rule["valueSet"].([]interface{})
+
+	var codings []jsonCoding
+	if len(system) > 0 {
+		cs := t.codeSystem(system)
+		concepts, _ := rule["concept"].([]interface{})
+		if len(concepts) > 0 {
+			codings = make([]jsonCoding, 0, len(concepts))
+			for _, v := range concepts {
+				value, _ := v.(map[string]interface{})
+				c := jsonCoding{system: system}
+				c.code, _ = value["code"].(string)
+				c.display, _ = value["display"].(string)
+				if cs != nil && cs.concepts[c.code] != nil && len(c.display) == 0 {
+					c.display = cs.concepts[c.code].display
+				}
+				codings = append(codings, c)
+			}
+		} else {
+			if cs == nil {
+				return nil, fmt.Errorf("code system is required to expand value set: %s", system)
+			}
+			filters, _ := rule["filter"].([]interface{})
+			codings = make([]jsonCoding, 0)
+			for _, code := range cs.codes {
+				matches := true
+				for _, f := range filters {
+					filter, _ := f.(map[string]interface{})
+					var err error
+					if matches, err = cs.filterMatches(filter, code); err != nil {
+						return nil, err
+					}
+					if !matches {
+						break
+					}
+				}
+				if matches {
+					codings = append(codings, jsonCoding{system, code, cs.concepts[code].display})
+				}
+			}
+		}
+	}
+
+	for _, v := range valueSets {
+		url, _ := v.(string)
+		vs := t.valueSet(url)
+		if vs == nil {
+			return nil, fmt.Errorf("value set cannot be resolved: %s", url)
+		}
+		expanded, err := t.expand(vs, visited)
+		if err != nil {
+			return nil, err
+		}
+		if codings == nil {
+			codings = expanded
+			continue
+		}
+
+		keys := make(map[string]bool)
+		for _, c := range expanded {
+			keys[c.key()] = true
+		}
+		intersection := make([]jsonCoding, 0, len(codings))
+		for _, c := range codings {
+			if keys[c.key()] {
+				intersection = append(intersection, c)
+			}
+		}
+		codings = intersection
+	}
+	return codings, nil
+}
+
+func expansionCodings(contains []interface{}, codings []jsonCoding) []jsonCoding {
+	for _, v := range contains {
+		value, _ := v.(map[string]interface{})
+		c := jsonCoding{}
+		c.system, _ = value["system"].(string)
+		c.code, _ = value["code"].(string)
+		c.display, _ = value["display"].(string)
+		if len(c.code) > 0 {
+			codings = append(codings, c)
+		}
+		nested, _ := value["contains"].([]interface{})
+		codings = expansionCodings(nested, codings)
+	}
+	return codings
+}
+
+func (t *LocalTerminology) valueSetOf(node interface{}) map[string]interface{} {
+	if url, ok := jsonString(node); ok {
+		return t.valueSet(url)
+	}
+	if vs, ok := JSONValue(node).(map[string]interface{}); ok && vs["resourceType"] == "ValueSet" {
+		return vs
+	}
+	return nil
+}
+
+func (t *LocalTerminology) conceptMapOf(node interface{}) map[string]interface{} {
+	if url, ok := jsonString(node); ok {
+		return t.conceptMaps[canonicalURL(url)]
+	}
+	if cm, ok := JSONValue(node).(map[string]interface{}); ok && cm["resourceType"] == "ConceptMap" {
+		return cm
+	}
+	return nil
+}
+
+func jsonString(node interface{}) (string, bool) {
+	switch v := JSONValue(node).(type) {
+	case string:
+		return v, true
+	case hipathsys.StringAccessor:
+		return v.String(), true
+	}
+	return "", false
+}
+
+func codingsOf(node interface{}) []jsonCoding {
+	if code, ok := jsonString(node); ok {
+		return []jsonCoding{{code: code}}
+	}
+
+	value, _ := JSONValue(node).(map[string]interface{})
+	if value == nil {
+		return nil
+	}
+	if nested, ok := value["coding"].([]interface{}); ok {
+		codings := make([]jsonCoding, 0, len(nested))
+		for _, n := range nested {
+			codings = append(codings, codingsOf(n)...)
+		}
+		return codings
+	}
+
+	c := jsonCoding{}
+	c.system, _ = value["system"].(string)
+	c.code, _ = value["code"].(string)
+	c.display, _ = value["display"].(string)
+	if len(c.code) == 0 {
+		return nil
+	}
+	return []jsonCoding{c}
+}
+
+func parameter(name string, valueName string, value interface{}) map[string]interface{} {
+	return map[string]interface{}{"name": name, valueName: value}
+}
+
+func newParameters(parameters ...interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"resourceType": "Parameters",
+		"parameter":    parameters,
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathfhir
+
+import (
+	"github.com/healthiop/hipath"
+	"github.com/healthiop/hipath/hipathsys"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+const testConceptMap = `{
+  "resourceType": "ConceptMap",
+  "url": "http://example.com/cm",
+  "group": [
+    {"source": "http://example.com/cs", "target": "http://example.com/de",
+      "element": [{"code": "dog", "target": [{"code": "hund", "display": "Hund", "equivalence": "equivalent"}]}]},
+    {"source": "http://example.com/other", "target": "http://example.com/de",
+      "element": [{"code": "dog", "target": [{"code": "wolf", "equivalence": "wider"}]}]}
+  ]
+}`
+
+func executeTestTerminologiesPath(t *testing.T, path string, expected ...interface{}) {
+	terminology := newTestTerminology(t)
+	assert.NoError(t, terminology.Load(strings.NewReader(testConceptMap)), "no error expected")
+
+	res := executeTestPath(t, NewModelAdapter(nil), path, nil,
+		hipathsys.WithTerminologyProvider(terminology),
+		hipathsys.WithEnvVar("coding", decodeTestJSON(t,
+			`{"system": "http://example.com/cs", "code": "dog"}`, false)))
+	if assert.Equal(t, len(expected), res.Count()) {
+		for i, e := range expected {
+			assertSystemEqual(t, e.(hipathsys.AnyAccessor), res.Get(i))
+		}
+	}
+}
+
+func TestTerminologiesExpand(t *testing.T) {
+	executeTestTerminologiesPath(t,
+		"%terminologies.expand('http://example.com/vs/animals').expansion.contains.code",
+		hipathsys.NewString("animal"), hipathsys.NewString("mammal"), hipathsys.NewString("dog"),
+		hipathsys.NewString("bird"), hipathsys.NewString("eagle"))
+}
+
+func TestTerminologiesExpandEnumerated(t *testing.T) {
+	executeTestTerminologiesPath(t,
+		"%terminologies.expand('http://example.com/vs/enumerated').expansion.contains.display",
+		hipathsys.NewString("Dog"), hipathsys.NewString("Plant"))
+}
+
+func TestTerminologiesExpandNested(t *testing.T) {
+	executeTestTerminologiesPath(t,
+		"%terminologies.expand('http://example.com/vs/nested').expansion.contains.code",
+		hipathsys.NewString("dog"))
+}
+
+func TestTerminologiesExpandExpansion(t *testing.T) {
+	executeTestTerminologiesPath(t,
+		"%terminologies.expand('http://example.com/vs/expanded').expansion.contains.code",
+		hipathsys.NewString("x"), hipathsys.NewString("y"))
+}
+
+func TestTerminologiesExpandUnknown(t *testing.T) {
+	executeTestTerminologiesPath(t, "%terminologies.expand('http://example.com/vs/unknown')")
+}
+
+func TestTerminologiesExpandExternal(t *testing.T) {
+	terminology := newTestTerminology(t)
+	ctx := hipathsys.NewContext(NewModelAdapter(nil),
+		hipathsys.WithTerminologyProvider(terminology))
+	res, err := gohipath.Execute(ctx, "%terminologies.expand('http://example.com/vs/external')", nil)
+	assert.NotNil(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestTerminologiesLookup(t *testing.T) {
+	executeTestTerminologiesPath(t,
+		"%terminologies.lookup(%coding).parameter.where(name = 'display').value",
+		hipathsys.NewString("Dog"))
+	executeTestTerminologiesPath(t,
+		"%terminologies.lookup(%coding).parameter.where(name = 'property').part.value",
+		hipathsys.NewString("legs"), hipathsys.NewString("4"))
+	executeTestTerminologiesPath(t, "%terminologies.lookup('dog')")
+}
+
+func TestTerminologiesValidateVS(t *testing.T) {
+	executeTestTerminologiesPath(t,
+		"%terminologies.validateVS('http://example.com/vs/animals', %coding).parameter.where(name = 'result').value",
+		hipathsys.True)
+	executeTestTerminologiesPath(t,
+		"%terminologies.validateVS('http://example.com/vs/descendants', %coding).parameter.value",
+		hipathsys.False)
+}
+
+func TestTerminologiesValidateCS(t *testing.T) {
+	executeTestTerminologiesPath(t,
+		"%terminologies.validateCS('http://example.com/cs', 'eagle').parameter.value",
+		hipathsys.True, hipathsys.NewString("Eagle"))
+	executeTestTerminologiesPath(t,
+		"%terminologies.validateCS('http://example.com/cs', 'fish').parameter.value",
+		hipathsys.False)
+	executeTestTerminologiesPath(t, "%terminologies.validateCS('http://example.com/unknown', 'fish')")
+}
+
+func TestTerminologiesSubsumes(t *testing.T) {
+	executeTestTerminologiesPath(t,
+		"%terminologies.subsumes('http://example.com/cs', 'animal', %coding).parameter.value",
+		hipathsys.NewString("subsumes"))
+	executeTestTerminologiesPath(t,
+		"%terminologies.subsumes('http://example.com/cs', %coding, 'mammal').parameter.value",
+		hipathsys.NewString("subsumed-by"))
+	executeTestTerminologiesPath(t,
+		"%terminologies.subsumes('http://example.com/cs', 'dog', %coding).parameter.value",
+		hipathsys.NewString("equivalent"))
+	executeTestTerminologiesPath(t,
+		"%terminologies.subsumes('http://example.com/cs', 'dog', 'bird').parameter.value",
+		hipathsys.NewString("not-subsumed"))
+	executeTestTerminologiesPath(t, "%terminologies.subsumes('http://example.com/cs', 'dog', 'fish')")
+}
+
+func TestTerminologiesTranslate(t *testing.T) {
+	executeTestTerminologiesPath(t,
+		"%terminologies.translate('http://example.com/cm', %coding).parameter.where(name = 'match').part.where(name = 'concept').value.code",
+		hipathsys.NewString("hund"))
+	executeTestTerminologiesPath(t,
+		"%terminologies.translate('http://example.com/cm', %coding).parameter.where(name = 'match').part.where(name = 'equivalence').value",
+		hipathsys.NewString("equivalent"))
+	executeTestTerminologiesPath(t,
+		"%terminologies.translate('http://example.com/cm', 'dog').parameter.where(name = 'match').count()",
+		hipathsys.NewInteger(2))
+	executeTestTerminologiesPath(t,
+		"%terminologies.translate('http://example.com/cm', 'cat').parameter.value",
+		hipathsys.False)
+	executeTestTerminologiesPath(t, "%terminologies.translate('http://example.com/unknown', 'cat')")
+}
+
+func TestTerminologiesUndefined(t *testing.T) {
+	ctx := hipathsys.NewContext(NewModelAdapter(nil))
+	res, err := gohipath.Execute(ctx, "%terminologies.expand('http://example.com/vs/animals')", nil)
+	assert.NotNil(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestTerminologiesMethodNotGlobal(t *testing.T) {
+	terminology := newTestTerminology(t)
+	ctx := hipathsys.NewContext(NewModelAdapter(nil), hipathsys.WithTerminologyProvider(terminology))
+	for _, path := range []string{"expand('http://example.com/vs/animals')",
+		"%resource.expand('http://example.com/vs/animals')",
+		"%terminologies.memberOf('http://example.com/vs/animals')",
+		"'dog'.subsumes('http://example.com/cs', 'animal', 'dog')"} {
+		res, err := gohipath.Execute(ctx, path, nil)
+		assert.NotNil(t, err, "error expected: %s", path)
+		assert.Nil(t, res, "no result expected: %s", path)
+	}
+}
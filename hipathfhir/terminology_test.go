@@ -0,0 +1,275 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathfhir
+
+import (
+	"github.com/healthiop/hipath"
+	"github.com/healthiop/hipath/hipathsys"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testTerminologyBundle = `{
+  "resourceType": "Bundle",
+  "entry": [
+    {"resource": {"resourceType": "CodeSystem", "url": "http://example.com/cs",
+      "concept": [
+        {"code": "animal", "display": "Animal", "concept": [
+          {"code": "mammal", "display": "Mammal", "concept": [
+            {"code": "dog", "display": "Dog", "property": [{"code": "legs", "valueInteger": 4}]},
+            {"code": "cat", "display": "Cat", "property": [{"code": "legs", "valueInteger": 4}]}
+          ]},
+          {"code": "bird", "display": "Bird", "property": [{"code": "legs", "valueInteger": 2}]}
+        ]},
+        {"code": "eagle", "display": "Eagle", "property": [{"code": "parent", "valueCode": "bird"}]},
+        {"code": "plant", "display": "Plant"}
+      ]}},
+    {"resource": {"resourceType": "ValueSet", "url": "http://example.com/vs/enumerated",
+      "compose": {"include": [{"system": "http://example.com/cs", "concept": [{"code": "dog"}, {"code": "plant"}]}]}}},
+    {"resource": {"resourceType": "ValueSet", "url": "http://example.com/vs/all",
+      "compose": {"include": [{"system": "http://example.com/cs"}]}}},
+    {"resource": {"resourceType": "ValueSet", "url": "http://example.com/vs/animals",
+      "compose": {
+        "include": [{"system": "http://example.com/cs", "filter": [{"property": "concept", "op": "is-a", "value": "animal"}]}],
+        "exclude": [{"system": "http://example.com/cs", "concept": [{"code": "cat"}]}]
+      }}},
+    {"resource": {"resourceType": "ValueSet", "url": "http://example.com/vs/descendants",
+      "compose": {"include": [{"system": "http://example.com/cs", "filter": [{"property": "concept", "op": "descendent-of", "value": "bird"}]}]}}},
+    {"resource": {"resourceType": "ValueSet", "url": "http://example.com/vs/four-legs",
+      "compose": {"include": [{"system": "http://example.com/cs", "filter": [{"property": "legs", "op": "=", "value": "4"}]}]}}},
+    {"resource": {"resourceType": "ValueSet", "url": "http://example.com/vs/nested",
+      "compose": {"include": [{"valueSet": ["http://example.com/vs/animals", "http://example.com/vs/four-legs"]}]}}},
+    {"resource": {"resourceType": "ValueSet", "url": "http://example.com/vs/expanded",
+      "expansion": {"contains": [{"system": "http://example.com/other", "code": "x",
+        "contains": [{"system": "http://example.com/other", "code": "y"}]}]}}},
+    {"resource": {"resourceType": "ValueSet", "url": "http://example.com/vs/external",
+      "compose": {"include": [{"system": "http://example.com/external"}]}}},
+    {"resource": {"resourceType": "ValueSet", "url": "http://example.com/vs/external-filter",
+      "compose": {"include": [{"system": "http://example.com/external", "filter": [{"property": "concept", "op": "is-a", "value": "x"}]}]}}},
+    {"resource": {"resourceType": "ValueSet", "url": "http://example.com/vs/recursive",
+      "compose": {"include": [{"valueSet": ["http://example.com/vs/recursive"]}]}}},
+    {"resource": {"resourceType": "Patient"}}
+  ]
+}`
+
+func newTestTerminology(t *testing.T) *LocalTerminology {
+	terminology := NewLocalTerminology()
+	err := terminology.Load(strings.NewReader(testTerminologyBundle))
+	assert.NoError(t, err, "no error expected")
+	return terminology
+}
+
+func assertMemberOf(t *testing.T, terminology *LocalTerminology, expected bool, url string, system string, code string) {
+	res, err := terminology.MemberOf(url, system, code)
+	assert.NoError(t, err, "no error expected")
+	if assert.NotNil(t, res, "result expected") {
+		assert.Equal(t, expected, res.Bool(), "%s: %s|%s", url, system, code)
+	}
+}
+
+func TestLocalTerminologyMemberOfEnumerated(t *testing.T) {
+	terminology := newTestTerminology(t)
+	assertMemberOf(t, terminology, true, "http://example.com/vs/enumerated", "http://example.com/cs", "dog")
+	assertMemberOf(t, terminology, true, "http://example.com/vs/enumerated|1.0", "", "plant")
+	assertMemberOf(t, terminology, false, "http://example.com/vs/enumerated", "http://example.com/cs", "cat")
+	assertMemberOf(t, terminology, false, "http://example.com/vs/enumerated", "http://example.com/other", "dog")
+}
+
+func TestLocalTerminologyMemberOfCodeSystem(t *testing.T) {
+	terminology := newTestTerminology(t)
+	assertMemberOf(t, terminology, true, "http://example.com/vs/all", "http://example.com/cs", "eagle")
+	assertMemberOf(t, terminology, false, "http://example.com/vs/all", "http://example.com/cs", "fish")
+}
+
+func TestLocalTerminologyMemberOfFilterExclude(t *testing.T) {
+	terminology := newTestTerminology(t)
+	assertMemberOf(t, terminology, true, "http://example.com/vs/animals", "http://example.com/cs", "animal")
+	assertMemberOf(t, terminology, true, "http://example.com/vs/animals", "http://example.com/cs", "dog")
+	assertMemberOf(t, terminology, true, "http://example.com/vs/animals", "http://example.com/cs", "eagle")
+	assertMemberOf(t, terminology, false, "http://example.com/vs/animals", "http://example.com/cs", "cat")
+	assertMemberOf(t, terminology, false, "http://example.com/vs/animals", "http://example.com/cs", "plant")
+}
+
+func TestLocalTerminologyMemberOfDescendants(t *testing.T) {
+	terminology := newTestTerminology(t)
+	assertMemberOf(t, terminology, true, "http://example.com/vs/descendants", "http://example.com/cs", "eagle")
+	assertMemberOf(t, terminology, false, "http://example.com/vs/descendants", "http://example.com/cs", "bird")
+}
+
+func TestLocalTerminologyMemberOfProperty(t *testing.T) {
+	terminology := newTestTerminology(t)
+	assertMemberOf(t, terminology, true, "http://example.com/vs/four-legs", "http://example.com/cs", "cat")
+	assertMemberOf(t, terminology, false, "http://example.com/vs/four-legs", "http://example.com/cs", "bird")
+}
+
+func TestLocalTerminologyMemberOfNested(t *testing.T) {
+	terminology := newTestTerminology(t)
+	assertMemberOf(t, terminology, true, "http://example.com/vs/nested", "http://example.com/cs", "dog")
+	assertMemberOf(t, terminology, false, "http://example.com/vs/nested", "http://example.com/cs", "cat")
+	assertMemberOf(t, terminology, false, "http://example.com/vs/nested", "http://example.com/cs", "bird")
+}
+
+func TestLocalTerminologyMemberOfExpansion(t *testing.T) {
+	terminology := newTestTerminology(t)
+	assertMemberOf(t, terminology, true, "http://example.com/vs/expanded", "http://example.com/other", "y")
+	assertMemberOf(t, terminology, false, "http://example.com/vs/expanded", "http://example.com/cs", "y")
+}
+
+func TestLocalTerminologyMemberOfExternal(t *testing.T) {
+	terminology := newTestTerminology(t)
+	assertMemberOf(t, terminology, true, "http://example.com/vs/external", "http://example.com/external", "x")
+
+	res, err := terminology.MemberOf("http://example.com/vs/external-filter", "http://example.com/external", "x")
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestLocalTerminologyMemberOfRecursive(t *testing.T) {
+	terminology := newTestTerminology(t)
+	res, err := terminology.MemberOf("http://example.com/vs/recursive", "http://example.com/cs", "dog")
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestLocalTerminologyMemberOfUnknown(t *testing.T) {
+	terminology := newTestTerminology(t)
+	res, err := terminology.MemberOf("http://example.com/vs/unknown", "http://example.com/cs", "dog")
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestLocalTerminologySubsumes(t *testing.T) {
+	terminology := newTestTerminology(t)
+
+	res, err := terminology.Subsumes("http://example.com/cs", "animal", "eagle")
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.True, res)
+
+	res, err = terminology.Subsumes("http://example.com/cs", "dog", "dog")
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.True, res)
+
+	res, err = terminology.Subsumes("http://example.com/cs", "dog", "mammal")
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.False, res)
+}
+
+func TestLocalTerminologySubsumesUnknown(t *testing.T) {
+	terminology := newTestTerminology(t)
+
+	res, err := terminology.Subsumes("http://example.com/cs", "animal", "fish")
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "no result expected")
+
+	res, err = terminology.Subsumes("http://example.com/other", "animal", "dog")
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestLocalTerminologyAddInvalid(t *testing.T) {
+	terminology := NewLocalTerminology()
+	assert.Error(t, terminology.Add(map[string]interface{}{"resourceType": "Patient"}), "error expected")
+	assert.Error(t, terminology.Add(map[string]interface{}{"resourceType": "ValueSet"}), "error expected")
+	assert.Error(t, terminology.Add(map[string]interface{}{"resourceType": "CodeSystem"}), "error expected")
+	assert.Error(t, terminology.Add(map[string]interface{}{"resourceType": "ConceptMap"}), "error expected")
+	assert.Error(t, terminology.Load(strings.NewReader("{")), "error expected")
+}
+
+func TestLocalTerminologyLoadDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terminology")
+	if !assert.NoError(t, err, "no error expected") {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "terminology.json"),
+		[]byte(testTerminologyBundle), 0600), "no error expected")
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README.md"),
+		[]byte("test"), 0600), "no error expected")
+
+	terminology := NewLocalTerminology()
+	assert.NoError(t, terminology.LoadDir(dir), "no error expected")
+	assertMemberOf(t, terminology, true, "http://example.com/vs/enumerated", "http://example.com/cs", "dog")
+}
+
+func TestLocalTerminologyLoadDirInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terminology")
+	if !assert.NoError(t, err, "no error expected") {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "invalid.json"),
+		[]byte(`{"resourceType": "Patient"}`), 0600), "no error expected")
+
+	terminology := NewLocalTerminology()
+	err = terminology.LoadDir(dir)
+	if assert.Error(t, err, "error expected") {
+		assert.Contains(t, err.Error(), "invalid.json")
+	}
+	assert.Error(t, terminology.LoadDir(filepath.Join(dir, "missing")), "error expected")
+}
+
+func TestLocalTerminologyFunctions(t *testing.T) {
+	terminology := newTestTerminology(t)
+	node := decodeTestJSON(t, `{"resourceType": "Observation", "status": "final",
+		"code": {"coding": [{"system": "http://example.com/other", "code": "z"},
+		{"system": "http://example.com/cs", "code": "eagle"}]}}`, false)
+
+	tests := []struct {
+		path     string
+		expected hipathsys.BooleanAccessor
+	}{
+		{"Observation.code.memberOf('http://example.com/vs/animals')", hipathsys.True},
+		{"Observation.code.coding.first().memberOf('http://example.com/vs/animals')", hipathsys.False},
+		{"Observation.code.subsumedBy(%coding)", hipathsys.True},
+		{"Observation.code.subsumes(%coding)", hipathsys.False},
+		{"Observation.code.coding.last().subsumes(%resource.code.coding.last())", hipathsys.True},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			ctx := hipathsys.NewContext(NewModelAdapter(nil),
+				hipathsys.WithTerminologyProvider(terminology),
+				hipathsys.WithEnvVar("coding", decodeTestJSON(t,
+					`{"system": "http://example.com/cs", "code": "bird"}`, false)))
+			res, err := gohipath.Execute(ctx, tt.path, node)
+			if assert.Nil(t, err, "no error expected") && assert.Equal(t, 1, res.Count()) {
+				assertSystemEqual(t, tt.expected, res.Get(0))
+			}
+		})
+	}
+
+	res := executeTestPath(t, NewModelAdapter(nil), "Observation.code.memberOf('http://example.com/vs/unknown')", node,
+		hipathsys.WithTerminologyProvider(terminology))
+	assert.Equal(t, 0, res.Count())
+}
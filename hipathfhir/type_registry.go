@@ -0,0 +1,418 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathfhir
+
+import (
+	"fmt"
+	"github.com/healthiop/hipath/hipathsys"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const NamespaceName = "FHIR"
+
+const choiceElementSuffix = "[x]"
+
+type builtInType struct {
+	name     string
+	baseName string
+	elements []ElementDefinitionAccessor
+}
+
+var builtInTypes = []builtInType{
+	{"Element", "", []ElementDefinitionAccessor{
+		NewElementDefinition("id", false, "string"),
+		NewElementDefinition("extension", true, "Extension"),
+	}},
+	{"BackboneElement", "Element", []ElementDefinitionAccessor{
+		NewElementDefinition("modifierExtension", true, "Extension"),
+	}},
+	{"Extension", "Element", []ElementDefinitionAccessor{
+		NewElementDefinition("url", false, "uri"),
+	}},
+	{"Resource", "", []ElementDefinitionAccessor{
+		NewElementDefinition("id", false, "id"),
+		NewElementDefinition("meta", false, "Meta"),
+		NewElementDefinition("implicitRules", false, "uri"),
+		NewElementDefinition("language", false, "code"),
+	}},
+	{"DomainResource", "Resource", []ElementDefinitionAccessor{
+		NewElementDefinition("text", false, "Narrative"),
+		NewElementDefinition("contained", true, "Resource"),
+		NewElementDefinition("extension", true, "Extension"),
+		NewElementDefinition("modifierExtension", true, "Extension"),
+	}},
+	{"Bundle", "Resource", []ElementDefinitionAccessor{
+		NewElementDefinition("identifier", false, "Identifier"),
+		NewElementDefinition("timestamp", false, "instant"),
+		NewElementDefinition("signature", false, "Signature"),
+	}},
+	{"Binary", "Resource", nil},
+	{"Parameters", "Resource", nil},
+	{"boolean", "Element", nil},
+	{"integer", "Element", nil},
+	{"string", "Element", nil},
+	{"decimal", "Element", nil},
+	{"uri", "Element", nil},
+	{"base64Binary", "Element", nil},
+	{"instant", "Element", nil},
+	{"date", "Element", nil},
+	{"dateTime", "Element", nil},
+	{"time", "Element", nil},
+	{"xhtml", "Element", nil},
+	{"url", "uri", nil},
+	{"canonical", "uri", nil},
+	{"oid", "uri", nil},
+	{"uuid", "uri", nil},
+	{"code", "string", nil},
+	{"id", "string", nil},
+	{"markdown", "string", nil},
+	{"unsignedInt", "integer", nil},
+	{"positiveInt", "integer", nil},
+	{"Address", "Element", []ElementDefinitionAccessor{
+		NewElementDefinition("period", false, "Period"),
+	}},
+	{"Annotation", "Element", []ElementDefinitionAccessor{
+		NewElementDefinition("time", false, "dateTime"),
+	}},
+	{"Attachment", "Element", []ElementDefinitionAccessor{
+		NewElementDefinition("creation", false, "dateTime"),
+	}},
+	{"CodeableConcept", "Element", []ElementDefinitionAccessor{
+		NewElementDefinition("coding", true, "Coding"),
+		NewElementDefinition("text", false, "string"),
+	}},
+	{"Coding", "Element", []ElementDefinitionAccessor{
+		NewElementDefinition("system", false, "uri"),
+		NewElementDefinition("version", false, "string"),
+		NewElementDefinition("code", false, "code"),
+		NewElementDefinition("display", false, "string"),
+		NewElementDefinition("userSelected", false, "boolean"),
+	}},
+	{"ContactDetail", "Element", nil},
+	{"ContactPoint", "Element", []ElementDefinitionAccessor{
+		NewElementDefinition("period", false, "Period"),
+	}},
+	{"Contributor", "Element", nil},
+	{"DataRequirement", "Element", nil},
+	{"Dosage", "BackboneElement", nil},
+	{"Expression", "Element", nil},
+	{"HumanName", "Element", []ElementDefinitionAccessor{
+		NewElementDefinition("period", false, "Period"),
+	}},
+	{"Identifier", "Element", []ElementDefinitionAccessor{
+		NewElementDefinition("system", false, "uri"),
+		NewElementDefinition("value", false, "string"),
+		NewElementDefinition("period", false, "Period"),
+	}},
+	{"Meta", "Element", []ElementDefinitionAccessor{
+		NewElementDefinition("versionId", false, "id"),
+		NewElementDefinition("lastUpdated", false, "instant"),
+		NewElementDefinition("source", false, "uri"),
+		NewElementDefinition("profile", true, "canonical"),
+	}},
+	{"Money", "Element", nil},
+	{"Narrative", "Element", nil},
+	{"ParameterDefinition", "Element", nil},
+	{"Period", "Element", []ElementDefinitionAccessor{
+		NewElementDefinition("start", false, "dateTime"),
+		NewElementDefinition("end", false, "dateTime"),
+	}},
+	{"Quantity", "Element", []ElementDefinitionAccessor{
+		NewElementDefinition("value", false, "decimal"),
+		NewElementDefinition("comparator", false, "code"),
+		NewElementDefinition("unit", false, "string"),
+		NewElementDefinition("system", false, "uri"),
+		NewElementDefinition("code", false, "code"),
+	}},
+	{"Range", "Element", nil},
+	{"Ratio", "Element", nil},
+	{"Reference", "Element", nil},
+	{"RelatedArtifact", "Element", nil},
+	{"SampledData", "Element", nil},
+	{"Signature", "Element", []ElementDefinitionAccessor{
+		NewElementDefinition("when", false, "instant"),
+	}},
+	{"Timing", "BackboneElement", []ElementDefinitionAccessor{
+		NewElementDefinition("event", true, "dateTime"),
+	}},
+	{"TriggerDefinition", "Element", nil},
+	{"UsageContext", "Element", nil},
+	{"Age", "Quantity", nil},
+	{"Count", "Quantity", nil},
+	{"Distance", "Quantity", nil},
+	{"Duration", "Quantity", nil},
+	{"MoneyQuantity", "Quantity", nil},
+	{"SimpleQuantity", "Quantity", nil},
+}
+
+var integerTypeName = hipathsys.NewFQTypeName("integer", NamespaceName)
+var dateTypeName = hipathsys.NewFQTypeName("date", NamespaceName)
+var dateTimeTypeName = hipathsys.NewFQTypeName("dateTime", NamespaceName)
+var instantTypeName = hipathsys.NewFQTypeName("instant", NamespaceName)
+var timeTypeName = hipathsys.NewFQTypeName("time", NamespaceName)
+var quantityTypeName = hipathsys.NewFQTypeName("Quantity", NamespaceName)
+
+const UnboundedCardinality = -1
+
+type elementDefinition struct {
+	name      string
+	choice    bool
+	min       int
+	max       int
+	typeNames []string
+}
+
+type ElementDefinitionAccessor interface {
+	Name() string
+	Choice() bool
+	Min() int
+	Max() int
+	Multiple() bool
+	TypeNames() []string
+}
+
+type typeDefinition struct {
+	baseName    string
+	typeSpec    hipathsys.TypeSpecAccessor
+	elements    map[string]ElementDefinitionAccessor
+	elementList []ElementDefinitionAccessor
+}
+
+type TypeRegistry struct {
+	types map[string]*typeDefinition
+}
+
+func NewElementDefinition(name string, multiple bool, typeNames ...string) ElementDefinitionAccessor {
+	max := 1
+	if multiple {
+		max = UnboundedCardinality
+	}
+	return NewElementDefinitionWithCardinality(name, 0, max, typeNames...)
+}
+
+func NewElementDefinitionWithCardinality(name string, min int, max int, typeNames ...string) ElementDefinitionAccessor {
+	choice := strings.HasSuffix(name, choiceElementSuffix)
+	if choice {
+		name = name[:len(name)-len(choiceElementSuffix)]
+	}
+
+	return &elementDefinition{
+		name:      name,
+		choice:    choice,
+		min:       min,
+		max:       max,
+		typeNames: typeNames,
+	}
+}
+
+func (e *elementDefinition) Name() string {
+	return e.name
+}
+
+func (e *elementDefinition) Choice() bool {
+	return e.choice
+}
+
+func (e *elementDefinition) Min() int {
+	return e.min
+}
+
+func (e *elementDefinition) Max() int {
+	return e.max
+}
+
+func (e *elementDefinition) Multiple() bool {
+	return e.max < 0 || e.max > 1
+}
+
+func (e *elementDefinition) TypeNames() []string {
+	return e.typeNames
+}
+
+// NewTypeRegistry returns a type registry with the FHIR R4 data types and
+// the elements of a limited set of commonly used resources. Elements of other
+// resources (e.g. DeviceRequest.authoredOn) are not known to the registry and
+// their primitive values are kept as strings, so that temporal values cannot
+// be compared as such. The StructureDefinitions of the required resources
+// must be loaded (e.g. by LoadFile) to provide their element types.
+func NewTypeRegistry() *TypeRegistry {
+	r := &TypeRegistry{types: make(map[string]*typeDefinition)}
+	for _, types := range [][]builtInType{builtInTypes, coreResourceTypes} {
+		for _, t := range types {
+			if _, err := r.RegisterType(t.name, t.baseName, t.elements...); err != nil {
+				panic(err.Error())
+			}
+		}
+	}
+	return r
+}
+
+func (r *TypeRegistry) RegisterType(name string, baseName string, elements ...ElementDefinitionAccessor) (hipathsys.TypeSpecAccessor, error) {
+	if len(name) == 0 {
+		return nil, fmt.Errorf("type name must not be empty")
+	}
+
+	var base hipathsys.TypeSpecAccessor
+	if len(baseName) > 0 {
+		if bt, found := r.types[baseName]; !found {
+			return nil, fmt.Errorf("base type %s of type %s has not been registered", baseName, name)
+		} else {
+			base = bt.typeSpec
+		}
+	}
+
+	t := newTypeDefinition(baseName,
+		hipathsys.NewTypeSpecWithBase(hipathsys.NewFQTypeName(name, NamespaceName), base), elements)
+	r.types[name] = t
+
+	return t.typeSpec, nil
+}
+
+func (r *TypeRegistry) RegisterBackboneElement(path string, baseName string, elements ...ElementDefinitionAccessor) error {
+	bt, found := r.types[baseName]
+	if !found {
+		return fmt.Errorf("base type %s of element %s has not been registered", baseName, path)
+	}
+
+	r.types[path] = newTypeDefinition(baseName, bt.typeSpec, elements)
+
+	return nil
+}
+
+func newTypeDefinition(baseName string, typeSpec hipathsys.TypeSpecAccessor, elements []ElementDefinitionAccessor) *typeDefinition {
+	t := &typeDefinition{
+		baseName:    baseName,
+		typeSpec:    typeSpec,
+		elements:    make(map[string]ElementDefinitionAccessor),
+		elementList: elements,
+	}
+	for _, e := range elements {
+		t.elements[e.Name()] = e
+	}
+	return t
+}
+
+func (r *TypeRegistry) TypeSpec(name string) hipathsys.TypeSpecAccessor {
+	if t, found := r.types[name]; found {
+		return t.typeSpec
+	}
+	return nil
+}
+
+func (r *TypeRegistry) ElementDefinition(typeName string, name string) ElementDefinitionAccessor {
+	for t := r.types[typeName]; t != nil; {
+		if e, found := t.elements[name]; found {
+			return e
+		}
+		t = r.types[t.baseName]
+	}
+	return nil
+}
+
+func (r *TypeRegistry) ElementDefinitions(typeName string) []ElementDefinitionAccessor {
+	t, found := r.types[typeName]
+	if !found {
+		return nil
+	}
+
+	elements := r.ElementDefinitions(t.baseName)
+	for _, e := range t.elementList {
+		overridden := false
+		for pos, be := range elements {
+			if be.Name() == e.Name() {
+				elements[pos] = e
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			elements = append(elements, e)
+		}
+	}
+	return elements
+}
+
+func (r *TypeRegistry) ChoiceElementDefinition(typeName string, name string) (ElementDefinitionAccessor, string) {
+	for t := r.types[typeName]; t != nil; {
+		for _, e := range t.elements {
+			if !e.Choice() || !strings.HasPrefix(name, e.Name()) {
+				continue
+			}
+			suffix := name[len(e.Name()):]
+			for _, tn := range e.TypeNames() {
+				if suffix == ChoiceTypeSuffix(tn) {
+					return e, tn
+				}
+			}
+		}
+		t = r.types[t.baseName]
+	}
+	return nil, ""
+}
+
+func (r *TypeRegistry) choiceSuffixTypeName(suffix string) string {
+	c, size := utf8.DecodeRuneInString(suffix)
+	if size == 0 || !unicode.IsUpper(c) {
+		return ""
+	}
+
+	if name := string(unicode.ToLower(c)) + suffix[size:]; r.TypeSpec(name) != nil {
+		return name
+	}
+	if r.TypeSpec(suffix) != nil {
+		return suffix
+	}
+	return ""
+}
+
+func ChoiceTypeSuffix(typeName string) string {
+	c, size := utf8.DecodeRuneInString(typeName)
+	if size == 0 {
+		return typeName
+	}
+	return string(unicode.ToUpper(c)) + typeName[size:]
+}
+
+func (r *TypeRegistry) resourceTypeSpec(name string) (string, hipathsys.TypeSpecAccessor) {
+	if t := r.TypeSpec(name); t != nil {
+		return name, t
+	}
+	return "DomainResource", hipathsys.NewTypeSpecWithBase(
+		hipathsys.NewFQTypeName(name, NamespaceName), r.TypeSpec("DomainResource"))
+}
+
+func (r *TypeRegistry) elementTypeSpec(name string) hipathsys.TypeSpecAccessor {
+	if t := r.TypeSpec(name); t != nil {
+		return t
+	}
+	return hipathsys.NewTypeSpecWithBase(hipathsys.NewFQTypeName(name, NamespaceName),
+		r.TypeSpec("Element"))
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathfhir
+
+import (
+	"github.com/healthiop/hipath/hipathsys"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewTypeRegistryBuiltIn(t *testing.T) {
+	r := NewTypeRegistry()
+
+	ts := r.TypeSpec("code")
+	if assert.NotNil(t, ts) {
+		assert.Equal(t, "FHIR.code", ts.String())
+		assert.True(t, ts.ExtendsName(hipathsys.NewFQTypeName("string", "FHIR")))
+		assert.True(t, ts.ExtendsName(hipathsys.NewFQTypeName("Element", "FHIR")))
+		assert.False(t, ts.ExtendsName(hipathsys.NewFQTypeName("uri", "FHIR")))
+	}
+
+	ts = r.TypeSpec("DomainResource")
+	if assert.NotNil(t, ts) {
+		assert.True(t, ts.ExtendsName(hipathsys.NewTypeName("Resource")))
+	}
+}
+
+func TestTypeRegistryTypeSpecNotFound(t *testing.T) {
+	r := NewTypeRegistry()
+	assert.Nil(t, r.TypeSpec("Library"))
+}
+
+func TestTypeRegistryRegisterType(t *testing.T) {
+	r := NewTypeRegistry()
+	ts, err := r.RegisterType("Patient", "DomainResource",
+		NewElementDefinition("name", true, "HumanName"))
+
+	assert.NoError(t, err, "no error expected")
+	if assert.NotNil(t, ts) {
+		assert.Same(t, ts, r.TypeSpec("Patient"))
+		assert.Equal(t, "FHIR.Patient", ts.String())
+		assert.True(t, ts.ExtendsName(hipathsys.NewTypeName("Resource")))
+	}
+}
+
+func TestTypeRegistryRegisterTypeEmptyName(t *testing.T) {
+	r := NewTypeRegistry()
+	ts, err := r.RegisterType("", "Element")
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, ts)
+}
+
+func TestTypeRegistryRegisterTypeBaseNotFound(t *testing.T) {
+	r := NewTypeRegistry()
+	ts, err := r.RegisterType("Library", "Other")
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, ts)
+	assert.Nil(t, r.TypeSpec("Library"))
+}
+
+func TestTypeRegistryElementDefinition(t *testing.T) {
+	r := NewTypeRegistry()
+	_, err := r.RegisterType("Patient", "DomainResource",
+		NewElementDefinition("name", true, "HumanName"))
+	assert.NoError(t, err, "no error expected")
+
+	e := r.ElementDefinition("Patient", "name")
+	if assert.NotNil(t, e) {
+		assert.Equal(t, "name", e.Name())
+		assert.True(t, e.Multiple())
+		assert.Equal(t, []string{"HumanName"}, e.TypeNames())
+	}
+}
+
+func TestTypeRegistryElementDefinitionInherited(t *testing.T) {
+	r := NewTypeRegistry()
+	_, err := r.RegisterType("Patient", "DomainResource")
+	assert.NoError(t, err, "no error expected")
+
+	e := r.ElementDefinition("Patient", "id")
+	if assert.NotNil(t, e) {
+		assert.Equal(t, "id", e.Name())
+		assert.False(t, e.Multiple())
+		assert.Equal(t, []string{"id"}, e.TypeNames())
+	}
+}
+
+func TestTypeRegistryElementDefinitionNotFound(t *testing.T) {
+	r := NewTypeRegistry()
+	assert.Nil(t, r.ElementDefinition("Element", "other"))
+	assert.Nil(t, r.ElementDefinition("Other", "id"))
+}
+
+func TestTypeRegistryRegisterBackboneElement(t *testing.T) {
+	r := NewTypeRegistry()
+	err := r.RegisterBackboneElement("Patient.contact", "BackboneElement",
+		NewElementDefinition("name", false, "HumanName"))
+	assert.NoError(t, err, "no error expected")
+
+	assert.Same(t, r.TypeSpec("BackboneElement"), r.TypeSpec("Patient.contact"))
+	assert.NotNil(t, r.ElementDefinition("Patient.contact", "name"))
+	assert.NotNil(t, r.ElementDefinition("Patient.contact", "modifierExtension"))
+	assert.NotNil(t, r.ElementDefinition("Patient.contact", "extension"))
+}
+
+func TestTypeRegistryRegisterBackboneElementBaseNotFound(t *testing.T) {
+	r := NewTypeRegistry()
+	err := r.RegisterBackboneElement("Patient.contact", "Other")
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, r.TypeSpec("Patient.contact"))
+}
+
+func TestNewElementDefinitionChoice(t *testing.T) {
+	e := NewElementDefinition("value[x]", false, "Quantity", "string")
+	assert.Equal(t, "value", e.Name())
+	assert.True(t, e.Choice())
+	assert.Equal(t, []string{"Quantity", "string"}, e.TypeNames())
+}
+
+func TestNewElementDefinitionNoChoice(t *testing.T) {
+	e := NewElementDefinition("value", false, "Quantity")
+	assert.Equal(t, "value", e.Name())
+	assert.False(t, e.Choice())
+}
+
+func TestChoiceTypeSuffix(t *testing.T) {
+	assert.Equal(t, "DateTime", ChoiceTypeSuffix("dateTime"))
+	assert.Equal(t, "Quantity", ChoiceTypeSuffix("Quantity"))
+	assert.Equal(t, "", ChoiceTypeSuffix(""))
+}
+
+func TestTypeRegistryChoiceElementDefinition(t *testing.T) {
+	r := NewTypeRegistry()
+	_, err := r.RegisterType("Observation", "DomainResource",
+		NewElementDefinition("value[x]", false, "Quantity", "string", "dateTime"))
+	assert.NoError(t, err, "no error expected")
+
+	e, typeName := r.ChoiceElementDefinition("Observation", "valueDateTime")
+	if assert.NotNil(t, e) {
+		assert.Equal(t, "value", e.Name())
+		assert.Equal(t, "dateTime", typeName)
+	}
+
+	e, typeName = r.ChoiceElementDefinition("Observation", "valueQuantity")
+	if assert.NotNil(t, e) {
+		assert.Equal(t, "Quantity", typeName)
+	}
+
+	e, typeName = r.ChoiceElementDefinition("Observation", "valueBoolean")
+	assert.Nil(t, e)
+	assert.Equal(t, "", typeName)
+
+	e, typeName = r.ChoiceElementDefinition("Observation", "id")
+	assert.Nil(t, e)
+	assert.Equal(t, "", typeName)
+}
+
+func TestTypeRegistryChoiceSuffixTypeName(t *testing.T) {
+	r := NewTypeRegistry()
+	assert.Equal(t, "dateTime", r.choiceSuffixTypeName("DateTime"))
+	assert.Equal(t, "Quantity", r.choiceSuffixTypeName("Quantity"))
+	assert.Equal(t, "", r.choiceSuffixTypeName("quantity"))
+	assert.Equal(t, "", r.choiceSuffixTypeName("Option"))
+	assert.Equal(t, "", r.choiceSuffixTypeName(""))
+}
+
+func TestNewElementDefinitionWithCardinality(t *testing.T) {
+	d := NewElementDefinitionWithCardinality("value[x]", 1, UnboundedCardinality, "string", "Quantity")
+	assert.Equal(t, "value", d.Name())
+	assert.True(t, d.Choice())
+	assert.Equal(t, 1, d.Min())
+	assert.Equal(t, UnboundedCardinality, d.Max())
+	assert.True(t, d.Multiple())
+	assert.Equal(t, []string{"string", "Quantity"}, d.TypeNames())
+}
+
+func TestNewElementDefinitionCardinality(t *testing.T) {
+	d := NewElementDefinition("gender", false, "code")
+	assert.Equal(t, 0, d.Min())
+	assert.Equal(t, 1, d.Max())
+	assert.False(t, d.Multiple())
+}
+
+func TestTypeRegistryElementDefinitions(t *testing.T) {
+	r := NewTypeRegistry()
+	_, err := r.RegisterType("Patient", "DomainResource",
+		NewElementDefinition("id", false, "string"),
+		NewElementDefinition("gender", false, "code"))
+	assert.NoError(t, err, "no error expected")
+
+	defs := r.ElementDefinitions("Patient")
+	names := make([]string, len(defs))
+	for i, d := range defs {
+		names[i] = d.Name()
+	}
+	assert.Equal(t, []string{"id", "meta", "implicitRules", "language", "text", "contained",
+		"extension", "modifierExtension", "gender"}, names)
+	assert.Equal(t, []string{"string"}, defs[0].TypeNames())
+}
+
+func TestTypeRegistryElementDefinitionsUnknown(t *testing.T) {
+	r := NewTypeRegistry()
+	assert.Empty(t, r.ElementDefinitions("Library"))
+}
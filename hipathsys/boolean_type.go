@@ -28,10 +28,6 @@
 
 package hipathsys
 
-import (
-	"fmt"
-)
-
 var BooleanTypeSpec = newAnyTypeSpec("Boolean")
 
 type booleanType struct {
@@ -83,7 +79,7 @@ func ParseBoolean(value string) (BooleanAccessor, error) {
 	case "false":
 		return False, nil
 	}
-	return nil, fmt.Errorf("not a boolean: %s", value)
+	return nil, NewCodedError(InvalidValueErrorCode, "not a boolean: %s", value)
 }
 
 func (t *booleanType) DataType() DataTypes {
@@ -37,6 +37,7 @@ type collectionType struct {
 	adapter      ModelAdapter
 	itemTypeSpec TypeSpecAccessor
 	items        []interface{}
+	maxSize      int
 }
 
 type CollectionAccessor interface {
@@ -61,15 +62,25 @@ type CollectionModifier interface {
 	AddUnique(item interface{}) (bool, error)
 	AddAll(collection CollectionAccessor) (int, error)
 	AddAllUnique(collection CollectionAccessor) (int, error)
-	MustAdd(item interface{})
 }
 
 func NewCollection(adapter ModelAdapter) CollectionModifier {
 	return NewCollectionWithSource(adapter, nil)
 }
 
+func NewCollectionWithMaxSize(adapter ModelAdapter, maxSize int) CollectionModifier {
+	c := newCollection(adapter, nil, nil)
+	c.maxSize = maxSize
+	return c
+}
+
 func NewCollectionWithItem(adapter ModelAdapter, item interface{}) (CollectionModifier, error) {
+	return NewCollectionWithItemAndMaxSize(adapter, item, 0)
+}
+
+func NewCollectionWithItemAndMaxSize(adapter ModelAdapter, item interface{}, maxSize int) (CollectionModifier, error) {
 	c := newCollection(adapter, nil, nil)
+	c.maxSize = maxSize
 	c.items = make([]interface{}, 1)
 	pi, err := c.convertItem(item)
 	if err != nil {
@@ -140,13 +151,6 @@ func (c *collectionType) Contains(item interface{}) bool {
 	return false
 }
 
-func (c *collectionType) MustAdd(item interface{}) {
-	err := c.Add(item)
-	if err != nil {
-		panic(err.Error())
-	}
-}
-
 func (c *collectionType) Add(item interface{}) error {
 	return c.addWithConversion(item)
 }
@@ -155,6 +159,9 @@ func (c *collectionType) addWithConversion(item interface{}) error {
 	if c.items == nil {
 		c.items = make([]interface{}, 0)
 	}
+	if err := c.checkSize(); err != nil {
+		return err
+	}
 	pi, err := c.convertItem(item)
 	if err != nil {
 		return err
@@ -172,7 +179,7 @@ func (c *collectionType) convertItem(item interface{}) (interface{}, error) {
 		var err error
 		item, err = c.adapter.ConvertToSystem(item)
 		if err != nil {
-			return nil, err
+			return nil, NewAdapterError(err)
 		}
 	}
 
@@ -205,7 +212,7 @@ func (c *collectionType) AddUnique(item interface{}) (bool, error) {
 			var err error
 			item, err = c.adapter.ConvertToSystem(item)
 			if err != nil {
-				return false, err
+				return false, NewAdapterError(err)
 			}
 			for _, o := range c.items {
 				if o != nil && ModelEqual(c.adapter, item, o) {
@@ -215,10 +222,20 @@ func (c *collectionType) AddUnique(item interface{}) (bool, error) {
 		}
 	}
 
+	if err := c.checkSize(); err != nil {
+		return false, err
+	}
 	c.items = append(c.items, item)
 	return true, nil
 }
 
+func (c *collectionType) checkSize() error {
+	if c.maxSize > 0 && len(c.items) >= c.maxSize {
+		return NewLimitError(CollectionSizeLimitName, c.maxSize)
+	}
+	return nil
+}
+
 func (c *collectionType) AddAll(collection CollectionAccessor) (int, error) {
 	count := collection.Count()
 	for i := 0; i < count; i++ {
@@ -113,24 +113,11 @@ func TestCollectionAddGet(t *testing.T) {
 	assert.Nil(t, c.ItemTypeSpec())
 }
 
-func TestCollectionMustAddGet(t *testing.T) {
-	ctx := newTestContext(t)
-	item1 := NewString("test1")
-	item2 := NewString("test2")
-	c := ctx.NewCollection()
-	c.MustAdd(item1)
-	c.MustAdd(item2)
-	assert.False(t, c.Empty(), "collection contains elements")
-	assert.Equal(t, 2, c.Count())
-	assert.Same(t, item1, c.Get(0))
-	assert.Same(t, item2, c.Get(1))
-	assert.Nil(t, c.ItemTypeSpec())
-}
-
-func TestCollectionMustAddError(t *testing.T) {
+func TestCollectionAddError(t *testing.T) {
 	ctx := newTestContext(t)
 	c := ctx.NewCollection()
-	assert.Panics(t, func() { c.MustAdd(newTestModelErrorNode()) })
+	assert.Error(t, c.Add(newTestModelErrorNode()), "error expected")
+	assert.Equal(t, 0, c.Count())
 }
 
 func TestNewCollectionWithItem(t *testing.T) {
@@ -548,8 +535,8 @@ func TestIsCollectionNot(t *testing.T) {
 func TestCollectionContains(t *testing.T) {
 	ctx := newTestContext(t)
 	col := ctx.NewCollection()
-	col.MustAdd(NewInteger(10))
-	col.MustAdd(NewInteger(12))
+	col.Add(NewInteger(10))
+	col.Add(NewInteger(12))
 	assert.True(t, col.Contains(NewInteger(12)))
 }
 
@@ -562,16 +549,16 @@ func TestCollectionEmptyContains(t *testing.T) {
 func TestCollectionContainsNot(t *testing.T) {
 	ctx := newTestContext(t)
 	col := ctx.NewCollection()
-	col.MustAdd(NewInteger(10))
-	col.MustAdd(NewInteger(12))
+	col.Add(NewInteger(10))
+	col.Add(NewInteger(12))
 	assert.False(t, col.Contains(NewInteger(14)))
 }
 
 func TestCollectionContainsModel(t *testing.T) {
 	ctx := newTestContext(t)
 	col := ctx.NewCollection()
-	col.MustAdd(newTestModelNode(10.0, false, testTypeSpec))
-	col.MustAdd(newTestModelNode(12.1, false, testTypeSpec))
+	col.Add(newTestModelNode(10.0, false, testTypeSpec))
+	col.Add(newTestModelNode(12.1, false, testTypeSpec))
 	assert.True(t, col.Contains(newTestModelNode(12.1, false, testTypeSpec)))
 }
 
@@ -689,3 +676,40 @@ func (c *errorCollection) ItemTypeSpec() TypeSpecAccessor {
 func (c *errorCollection) Contains(_ interface{}) bool {
 	panic("implement me")
 }
+
+func TestCollectionMaxSize(t *testing.T) {
+	c := NewCollectionWithMaxSize(newTestModel(t), 2)
+	assert.NoError(t, c.Add(NewString("test1")), "no error expected")
+	assert.NoError(t, c.Add(NewString("test2")), "no error expected")
+	err := c.Add(NewString("test3"))
+	if assert.IsType(t, (*LimitError)(nil), err) {
+		assert.Equal(t, CollectionSizeLimitName, err.(*LimitError).Name())
+		assert.Equal(t, 2, err.(*LimitError).Limit())
+	}
+	assert.Equal(t, 2, c.Count())
+}
+
+func TestCollectionMaxSizeUnique(t *testing.T) {
+	c := NewCollectionWithMaxSize(newTestModel(t), 1)
+	added, err := c.AddUnique(NewString("test1"))
+	assert.NoError(t, err, "no error expected")
+	assert.True(t, added)
+	added, err = c.AddUnique(NewString("test1"))
+	assert.NoError(t, err, "no error expected")
+	assert.False(t, added)
+	added, err = c.AddUnique(NewString("test2"))
+	assert.Error(t, err, "error expected")
+	assert.False(t, added)
+	assert.Equal(t, 1, c.Count())
+}
+
+func TestCollectionMaxSizeAddAll(t *testing.T) {
+	other := NewCollection(newTestModel(t))
+	other.Add(NewString("test1"))
+	other.Add(NewString("test2"))
+
+	c := NewCollectionWithMaxSize(newTestModel(t), 1)
+	count, err := c.AddAll(other)
+	assert.Error(t, err, "error expected")
+	assert.Equal(t, 1, count)
+}
@@ -82,11 +82,18 @@ func CastModelType(adapter ModelAdapter, node interface{}, name FQTypeNameAccess
 		return node, nil
 	}
 	if sys {
-		// system node cannot be casted by model adapter
+		// system node cannot be casted by model adapter, but it may have a model type
+		if name.Namespace() != NamespaceName && adapter.TypeSpec(node).ExtendsName(name) {
+			return node, nil
+		}
 		return nil, nil
 	}
 
-	return adapter.Cast(node, name)
+	res, err := adapter.Cast(node, name)
+	if err != nil {
+		return nil, NewAdapterError(err)
+	}
+	return res, nil
 }
 
 func ModelEqual(adapter ModelAdapter, node1 interface{}, node2 interface{}) bool {
@@ -160,6 +167,47 @@ type Tracer interface {
 	Trace(name string, col CollectionAccessor)
 }
 
+type ReferenceResolver interface {
+	Resolve(ctx ContextAccessor, node interface{}, reference string) (interface{}, error)
+}
+
+const TerminologiesEnvVarName = "terminologies"
+
+// TerminologyProvider provides the terminology operations of the FHIR
+// functions memberOf, subsumes and subsumedBy, and of the methods of the
+// %terminologies object. The latter return FHIR resources (e.g. Parameters)
+// in the representation of the used model.
+type TerminologyProvider interface {
+	MemberOf(valueSetURL string, system string, code string) (BooleanAccessor, error)
+	Subsumes(system string, code1 string, code2 string) (BooleanAccessor, error)
+	Expand(ctx ContextAccessor, valueSet interface{}, params interface{}) (interface{}, error)
+	Lookup(ctx ContextAccessor, coded interface{}, params interface{}) (interface{}, error)
+	ValidateVS(ctx ContextAccessor, valueSet interface{}, coded interface{}, params interface{}) (interface{}, error)
+	ValidateCS(ctx ContextAccessor, codeSystem interface{}, coded interface{}, params interface{}) (interface{}, error)
+	SubsumesOutcome(ctx ContextAccessor, system interface{}, coding1 interface{}, coding2 interface{}, params interface{}) (interface{}, error)
+	Translate(ctx ContextAccessor, conceptMap interface{}, coded interface{}, params interface{}) (interface{}, error)
+}
+
+type ProfileValidator interface {
+	ConformsTo(ctx ContextAccessor, node interface{}, profile string) (BooleanAccessor, error)
+}
+
+type ReferenceResolverAccessor interface {
+	ReferenceResolver() ReferenceResolver
+}
+
+type TerminologyProviderAccessor interface {
+	TerminologyProvider() TerminologyProvider
+}
+
+type ProfileValidatorAccessor interface {
+	ProfileValidator() ProfileValidator
+}
+
+type LazyErrorContext interface {
+	LazyErrors() bool
+}
+
 type ContextAccessor interface {
 	EnvVar(name string) (interface{}, bool)
 	ContextNode() interface{}
@@ -169,6 +217,34 @@ type ContextAccessor interface {
 	Tracer() Tracer
 }
 
+func ContextReferenceResolver(ctx ContextAccessor) ReferenceResolver {
+	if c, ok := ctx.(ReferenceResolverAccessor); ok {
+		return c.ReferenceResolver()
+	}
+	return nil
+}
+
+func ContextTerminologyProvider(ctx ContextAccessor) TerminologyProvider {
+	if c, ok := ctx.(TerminologyProviderAccessor); ok {
+		return c.TerminologyProvider()
+	}
+	return nil
+}
+
+func ContextProfileValidator(ctx ContextAccessor) ProfileValidator {
+	if c, ok := ctx.(ProfileValidatorAccessor); ok {
+		return c.ProfileValidator()
+	}
+	return nil
+}
+
+func LazyErrors(ctx ContextAccessor) bool {
+	if c, ok := ctx.(LazyErrorContext); ok {
+		return c.LazyErrors()
+	}
+	return false
+}
+
 func systemNamespace(name string) bool {
 	return len(name) == 0 || name == NamespaceName
 }
@@ -259,6 +259,24 @@ func TestCastModelTypeSystemIncompatible(t *testing.T) {
 	assert.Nil(t, res, "empty result expected")
 }
 
+func TestCastModelTypeSystemWithModelType(t *testing.T) {
+	ctx := newTestContext(t)
+	n := NewString("Test 123")
+	res, err := CastModelType(ctx.ModelAdapter(), n,
+		NewFQTypeName("string", "TEST"))
+	assert.NoError(t, err, "no error expected")
+	assert.Same(t, n, res)
+}
+
+func TestCastModelTypeSystemWithModelTypeIncompatible(t *testing.T) {
+	ctx := newTestContext(t)
+	n := NewString("Test 123")
+	res, err := CastModelType(ctx.ModelAdapter(), n,
+		NewTypeName("integer"))
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
 func TestCastModelTypeModelSelf(t *testing.T) {
 	ctx := newTestContext(t)
 	n := newTestModelNode(17.4, false, testTypeSpec)
@@ -296,3 +314,10 @@ func TestCastModelTypeModelError(t *testing.T) {
 	assert.Error(t, err, "error expected")
 	assert.Nil(t, res, "empty result expected")
 }
+
+func TestContextServicesNotAccessible(t *testing.T) {
+	ctx := newTestContext(t)
+	assert.Nil(t, ContextReferenceResolver(ctx))
+	assert.Nil(t, ContextTerminologyProvider(ctx))
+	assert.Nil(t, ContextProfileValidator(ctx))
+}
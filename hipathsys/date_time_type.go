@@ -101,7 +101,7 @@ func ParseDateTime(value string) (DateTimeAccessor, error) {
 func ParseDateTimeWithSource(value string, source interface{}) (DateTimeAccessor, error) {
 	parts := dateTimeRegexp.FindStringSubmatch(value)
 	if parts == nil {
-		return nil, fmt.Errorf("not a valid fluent date/time string: %s", value)
+		return nil, NewCodedError(InvalidValueErrorCode, "not a valid fluent date/time string: %s", value)
 	}
 	return newDateTimeFromParts(parts, source), nil
 }
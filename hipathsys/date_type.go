@@ -96,7 +96,7 @@ func ParseDate(value string) (DateAccessor, error) {
 func ParseDateWithSource(value string, source interface{}) (DateAccessor, error) {
 	parts := dateRegexp.FindStringSubmatch(value)
 	if parts == nil {
-		return nil, fmt.Errorf("not a valid date string: %s", value)
+		return nil, NewCodedError(InvalidValueErrorCode, "not a valid date string: %s", value)
 	}
 	return newDateFromParts(parts, source), nil
 }
@@ -223,7 +223,7 @@ func (t *dateType) Add(quantity QuantityAccessor) (TemporalAccessor, error) {
 	}
 
 	if precision > DayDatePrecision {
-		return nil, fmt.Errorf("quantity precision not allowd for date type: %s", quantity.String())
+		return nil, NewCodedError(InvalidValueErrorCode, "quantity precision not allowd for date type: %s", quantity.String())
 	}
 
 	res, err := addQuantityTemporalDuration(t, value, precision)
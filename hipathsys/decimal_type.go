@@ -109,7 +109,7 @@ func ParseDecimal(value string) (DecimalAccessor, error) {
 
 func ParseDecimalWithSource(value string, source interface{}) (DecimalAccessor, error) {
 	if d, err := decimal.NewFromString(value); err != nil {
-		return nil, fmt.Errorf("not a decimal: %s", value)
+		return nil, NewCodedError(InvalidValueErrorCode, "not a decimal: %s", value)
 	} else {
 		return newDecimal(d, source), nil
 	}
@@ -274,17 +274,17 @@ func (t *decimalType) Floor() NumberAccessor {
 
 func (t *decimalType) Ln() (NumberAccessor, error) {
 	if !t.value.IsPositive() {
-		return nil, fmt.Errorf("logarithmus cannot be applied to non-positive values %d", t.value)
+		return nil, NewCodedError(InvalidValueErrorCode, "logarithmus cannot be applied to non-positive values %d", t.value)
 	}
 	return NewDecimalFloat64(math.Log(t.Float64())), nil
 }
 
 func (t *decimalType) Log(base NumberAccessor) (NumberAccessor, error) {
 	if !t.value.IsPositive() {
-		return nil, fmt.Errorf("logarithmus cannot be applied to non-positive values %f", t.Float64())
+		return nil, NewCodedError(InvalidValueErrorCode, "logarithmus cannot be applied to non-positive values %f", t.Float64())
 	}
 	if !base.Positive() {
-		return nil, fmt.Errorf("logarithmus cannot be applied to non-positive base %f", base.Float64())
+		return nil, NewCodedError(InvalidValueErrorCode, "logarithmus cannot be applied to non-positive base %f", base.Float64())
 	}
 	return NewDecimalFloat64(math.Log(t.Float64()) / math.Log(base.Float64())), nil
 }
@@ -305,7 +305,7 @@ func (t *decimalType) Power(exponent NumberAccessor) (NumberAccessor, bool) {
 
 func (t *decimalType) Round(precision int32) (NumberAccessor, error) {
 	if precision < 0 {
-		return nil, fmt.Errorf("precision must not be negative %d", precision)
+		return nil, NewCodedError(InvalidValueErrorCode, "precision must not be negative %d", precision)
 	}
 	return NewDecimal(t.value.Round(precision)), nil
 }
@@ -324,7 +324,10 @@ func (t *decimalType) Calc(operand DecimalValueAccessor, op ArithmeticOps) (Deci
 	}
 
 	if !t.ArithmeticOpSupported(op) || !operand.ArithmeticOpSupported(op) {
-		return nil, fmt.Errorf("arithmetic operator not supported: %c", op)
+		return nil, NewCodedError(NotSupportedErrorCode, "arithmetic operator not supported: %c", op)
+	}
+	if err := checkSpecialQuantityOperand(operand, op); err != nil {
+		return nil, err
 	}
 
 	return operand.WithValue(decimalCalc(t, operand.Value(), op)), nil
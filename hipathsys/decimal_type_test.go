@@ -374,6 +374,13 @@ func TestDecimalCalcQuantity(t *testing.T) {
 	assert.True(t, e.Equal(r))
 }
 
+func TestDecimalCalcQuantitySpecialUnit(t *testing.T) {
+	q := NewQuantity(NewDecimalFloat64(37.2), NewString("Cel"))
+	r, err := NewDecimalFloat64(1.5).Calc(q, MultiplicationOp)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, r)
+}
+
 func TestDecimalCalcNotSupportedOp(t *testing.T) {
 	q := NewQuantity(NewDecimalFloat64(47.2), NewString("m"))
 	r, err := NewDecimalFloat64(1.5).Calc(q, ModOp)
@@ -0,0 +1,255 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathsys
+
+import "context"
+
+var SNOMEDCTSystemURI = NewString("http://snomed.info/sct")
+var LOINCSystemURI = NewString("http://loinc.org")
+
+var predefinedEnvVars = map[string]interface{}{
+	"ucum":  UCUMSystemURI,
+	"sct":   SNOMEDCTSystemURI,
+	"loinc": LOINCSystemURI,
+}
+
+type defaultContext struct {
+	modelAdapter        ModelAdapter
+	envVars             map[string]interface{}
+	tracer              Tracer
+	referenceResolver   ReferenceResolver
+	terminologyProvider TerminologyProvider
+	profileValidator    ProfileValidator
+	lazyErrors          bool
+	goCtx               context.Context
+	limits              *Limits
+	limiter             *Limiter
+	node                interface{}
+	nodeDefined         bool
+	resource            interface{}
+	rootResource        interface{}
+}
+
+type ContextOption func(c *defaultContext)
+
+type ContextNodeBinder interface {
+	BindContextNode(node interface{}) ContextAccessor
+}
+
+func WithEnvVar(name string, value interface{}) ContextOption {
+	return func(c *defaultContext) {
+		if c.envVars == nil {
+			c.envVars = make(map[string]interface{})
+		}
+		c.envVars[name] = value
+	}
+}
+
+func WithEnvVars(envVars map[string]interface{}) ContextOption {
+	return func(c *defaultContext) {
+		for name, value := range envVars {
+			WithEnvVar(name, value)(c)
+		}
+	}
+}
+
+func WithTracer(tracer Tracer) ContextOption {
+	return func(c *defaultContext) {
+		c.tracer = tracer
+	}
+}
+
+func WithReferenceResolver(resolver ReferenceResolver) ContextOption {
+	return func(c *defaultContext) {
+		c.referenceResolver = resolver
+	}
+}
+
+func WithTerminologyProvider(provider TerminologyProvider) ContextOption {
+	return func(c *defaultContext) {
+		c.terminologyProvider = provider
+	}
+}
+
+func WithProfileValidator(validator ProfileValidator) ContextOption {
+	return func(c *defaultContext) {
+		c.profileValidator = validator
+	}
+}
+
+func WithLazyErrors(lazy bool) ContextOption {
+	return func(c *defaultContext) {
+		c.lazyErrors = lazy
+	}
+}
+
+func WithGoContext(goCtx context.Context) ContextOption {
+	return func(c *defaultContext) {
+		c.goCtx = goCtx
+	}
+}
+
+func WithLimits(limits Limits) ContextOption {
+	return func(c *defaultContext) {
+		c.limits = &limits
+	}
+}
+
+func WithContextNode(node interface{}) ContextOption {
+	return func(c *defaultContext) {
+		c.node = node
+		c.nodeDefined = true
+	}
+}
+
+func WithResource(node interface{}) ContextOption {
+	return func(c *defaultContext) {
+		c.resource = node
+	}
+}
+
+func WithRootResource(node interface{}) ContextOption {
+	return func(c *defaultContext) {
+		c.rootResource = node
+	}
+}
+
+func NewContext(adapter ModelAdapter, opts ...ContextOption) ContextAccessor {
+	if adapter == nil {
+		panic("no adapter has been specified")
+	}
+
+	c := &defaultContext{modelAdapter: adapter}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.limits != nil {
+		c.limiter = NewLimiter(*c.limits)
+		c.modelAdapter = BindMaxCollectionSize(adapter, c.limits.MaxCollectionSize)
+	}
+	return c
+}
+
+func (c *defaultContext) BindContextNode(node interface{}) ContextAccessor {
+	if c.nodeDefined {
+		return c
+	}
+
+	bc := *c
+	bc.node = node
+	bc.nodeDefined = true
+	return &bc
+}
+
+func (c *defaultContext) BindGoContext(goCtx context.Context) ContextAccessor {
+	bc := *c
+	bc.goCtx = goCtx
+	return &bc
+}
+
+func (c *defaultContext) BindLimiter() ContextAccessor {
+	if c.limits == nil {
+		return c
+	}
+
+	bc := *c
+	bc.limiter = NewLimiter(*c.limits)
+	return &bc
+}
+
+func (c *defaultContext) EnvVar(name string) (interface{}, bool) {
+	switch name {
+	case "context":
+		return c.node, true
+	case "resource":
+		return c.resourceNode(), true
+	case "rootResource":
+		if c.rootResource != nil {
+			return c.rootResource, true
+		}
+		return c.resourceNode(), true
+	}
+
+	if value, found := c.envVars[name]; found {
+		return value, true
+	}
+	value, found := predefinedEnvVars[name]
+	return value, found
+}
+
+func (c *defaultContext) resourceNode() interface{} {
+	if c.resource != nil {
+		return c.resource
+	}
+	return c.node
+}
+
+func (c *defaultContext) ContextNode() interface{} {
+	return c.node
+}
+
+func (c *defaultContext) ModelAdapter() ModelAdapter {
+	return c.modelAdapter
+}
+
+func (c *defaultContext) NewCollection() CollectionModifier {
+	return NewCollectionWithMaxSize(c.modelAdapter, c.limiter.MaxCollectionSize())
+}
+
+func (c *defaultContext) NewCollectionWithItem(item interface{}) (CollectionModifier, error) {
+	return NewCollectionWithItemAndMaxSize(c.modelAdapter, item, c.limiter.MaxCollectionSize())
+}
+
+func (c *defaultContext) Tracer() Tracer {
+	return c.tracer
+}
+
+func (c *defaultContext) ReferenceResolver() ReferenceResolver {
+	return c.referenceResolver
+}
+
+func (c *defaultContext) TerminologyProvider() TerminologyProvider {
+	return c.terminologyProvider
+}
+
+func (c *defaultContext) ProfileValidator() ProfileValidator {
+	return c.profileValidator
+}
+
+func (c *defaultContext) LazyErrors() bool {
+	return c.lazyErrors
+}
+
+func (c *defaultContext) GoContext() context.Context {
+	return c.goCtx
+}
+
+func (c *defaultContext) Limiter() *Limiter {
+	return c.limiter
+}
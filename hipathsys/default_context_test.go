@@ -0,0 +1,269 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathsys
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type testTracer struct {
+}
+
+func (t *testTracer) Enabled(string) bool {
+	return true
+}
+
+func (t *testTracer) Trace(string, CollectionAccessor) {
+}
+
+type testReferenceResolver struct {
+}
+
+func (r *testReferenceResolver) Resolve(ContextAccessor, interface{}, string) (interface{}, error) {
+	return nil, nil
+}
+
+type testTerminologyProvider struct {
+}
+
+func (p *testTerminologyProvider) MemberOf(string, string, string) (BooleanAccessor, error) {
+	return nil, nil
+}
+
+func (p *testTerminologyProvider) Subsumes(string, string, string) (BooleanAccessor, error) {
+	return nil, nil
+}
+
+func (p *testTerminologyProvider) Expand(ContextAccessor, interface{}, interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (p *testTerminologyProvider) Lookup(ContextAccessor, interface{}, interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (p *testTerminologyProvider) ValidateVS(ContextAccessor, interface{}, interface{}, interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (p *testTerminologyProvider) ValidateCS(ContextAccessor, interface{}, interface{}, interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (p *testTerminologyProvider) SubsumesOutcome(ContextAccessor, interface{}, interface{}, interface{}, interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (p *testTerminologyProvider) Translate(ContextAccessor, interface{}, interface{}, interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+type testProfileValidator struct {
+}
+
+func (v *testProfileValidator) ConformsTo(ContextAccessor, interface{}, string) (BooleanAccessor, error) {
+	return nil, nil
+}
+
+func TestNewContextNoAdapter(t *testing.T) {
+	assert.Panics(t, func() { NewContext(nil) })
+}
+
+func TestNewContext(t *testing.T) {
+	adapter := newTestModel(t)
+	ctx := NewContext(adapter)
+	assert.Same(t, adapter, ctx.ModelAdapter())
+	assert.Nil(t, ctx.ContextNode())
+	assert.Nil(t, ctx.Tracer())
+	assert.Nil(t, ContextReferenceResolver(ctx))
+	assert.Nil(t, ContextTerminologyProvider(ctx))
+	assert.Nil(t, ContextProfileValidator(ctx))
+}
+
+func TestNewContextTracer(t *testing.T) {
+	tracer := &testTracer{}
+	ctx := NewContext(newTestModel(t), WithTracer(tracer))
+	assert.Same(t, tracer, ctx.Tracer())
+}
+
+func TestNewContextReferenceResolver(t *testing.T) {
+	resolver := &testReferenceResolver{}
+	ctx := NewContext(newTestModel(t), WithReferenceResolver(resolver))
+	assert.Same(t, resolver, ContextReferenceResolver(ctx))
+}
+
+func TestNewContextTerminologyProvider(t *testing.T) {
+	provider := &testTerminologyProvider{}
+	ctx := NewContext(newTestModel(t), WithTerminologyProvider(provider))
+	assert.Same(t, provider, ContextTerminologyProvider(ctx))
+}
+
+func TestNewContextProfileValidator(t *testing.T) {
+	validator := &testProfileValidator{}
+	ctx := NewContext(newTestModel(t), WithProfileValidator(validator))
+	assert.Same(t, validator, ContextProfileValidator(ctx))
+}
+
+func TestNewContextNewCollection(t *testing.T) {
+	ctx := NewContext(newTestModel(t))
+	col := ctx.NewCollection()
+	if assert.NotNil(t, col) {
+		assert.Equal(t, 0, col.Count())
+	}
+}
+
+func TestNewContextNewCollectionWithItem(t *testing.T) {
+	ctx := NewContext(newTestModel(t))
+	col, err := ctx.NewCollectionWithItem(NewString("test"))
+	assert.NoError(t, err, "no error expected")
+	if assert.NotNil(t, col) && assert.Equal(t, 1, col.Count()) {
+		assert.Equal(t, NewString("test"), col.Get(0))
+	}
+}
+
+func TestNewContextEnvVar(t *testing.T) {
+	ctx := NewContext(newTestModel(t), WithEnvVar("test1", NewString("value1")),
+		WithEnvVars(map[string]interface{}{"test2": NewString("value2")}))
+
+	v, found := ctx.EnvVar("test1")
+	assert.True(t, found)
+	assert.Equal(t, NewString("value1"), v)
+	v, found = ctx.EnvVar("test2")
+	assert.True(t, found)
+	assert.Equal(t, NewString("value2"), v)
+	v, found = ctx.EnvVar("test3")
+	assert.False(t, found)
+	assert.Nil(t, v)
+}
+
+func TestNewContextPredefinedEnvVar(t *testing.T) {
+	ctx := NewContext(newTestModel(t))
+
+	v, found := ctx.EnvVar("ucum")
+	assert.True(t, found)
+	assert.Same(t, UCUMSystemURI, v)
+	v, found = ctx.EnvVar("sct")
+	assert.True(t, found)
+	assert.Same(t, SNOMEDCTSystemURI, v)
+	v, found = ctx.EnvVar("loinc")
+	assert.True(t, found)
+	assert.Same(t, LOINCSystemURI, v)
+}
+
+func TestNewContextPredefinedEnvVarOverridden(t *testing.T) {
+	ctx := NewContext(newTestModel(t), WithEnvVar("ucum", NewString("test")))
+
+	v, found := ctx.EnvVar("ucum")
+	assert.True(t, found)
+	assert.Equal(t, NewString("test"), v)
+}
+
+func TestNewContextNode(t *testing.T) {
+	node := NewString("test")
+	ctx := NewContext(newTestModel(t), WithContextNode(node))
+	assert.Same(t, node, ctx.ContextNode())
+
+	for _, name := range []string{"context", "resource", "rootResource"} {
+		v, found := ctx.EnvVar(name)
+		assert.True(t, found, name)
+		assert.Same(t, node, v, name)
+	}
+}
+
+func TestNewContextResource(t *testing.T) {
+	node := NewString("test1")
+	resource := NewString("test2")
+	ctx := NewContext(newTestModel(t), WithContextNode(node), WithResource(resource))
+
+	v, _ := ctx.EnvVar("context")
+	assert.Same(t, node, v)
+	v, _ = ctx.EnvVar("resource")
+	assert.Same(t, resource, v)
+	v, _ = ctx.EnvVar("rootResource")
+	assert.Same(t, resource, v)
+}
+
+func TestNewContextRootResource(t *testing.T) {
+	node := NewString("test1")
+	resource := NewString("test2")
+	rootResource := NewString("test3")
+	ctx := NewContext(newTestModel(t), WithContextNode(node),
+		WithResource(resource), WithRootResource(rootResource))
+
+	v, _ := ctx.EnvVar("context")
+	assert.Same(t, node, v)
+	v, _ = ctx.EnvVar("resource")
+	assert.Same(t, resource, v)
+	v, _ = ctx.EnvVar("rootResource")
+	assert.Same(t, rootResource, v)
+}
+
+func TestNewContextNoNode(t *testing.T) {
+	ctx := NewContext(newTestModel(t))
+
+	v, found := ctx.EnvVar("context")
+	assert.True(t, found)
+	assert.Nil(t, v)
+}
+
+func TestBindContextNode(t *testing.T) {
+	node := NewString("test")
+	ctx := NewContext(newTestModel(t), WithEnvVar("test", True))
+
+	bc := ctx.(ContextNodeBinder).BindContextNode(node)
+	assert.NotSame(t, ctx, bc)
+	assert.Nil(t, ctx.ContextNode())
+	assert.Same(t, node, bc.ContextNode())
+	v, _ := bc.EnvVar("resource")
+	assert.Same(t, node, v)
+	v, _ = bc.EnvVar("test")
+	assert.Same(t, True, v)
+}
+
+func TestBindContextNodeDefined(t *testing.T) {
+	node := NewString("test")
+	ctx := NewContext(newTestModel(t), WithContextNode(node))
+
+	bc := ctx.(ContextNodeBinder).BindContextNode(NewString("other"))
+	assert.Same(t, ctx, bc)
+	assert.Same(t, node, bc.ContextNode())
+}
+
+func TestNewContextLazyErrors(t *testing.T) {
+	ctx := NewContext(newTestModel(t), WithLazyErrors(true))
+	assert.True(t, LazyErrors(ctx))
+	bc := ctx.(ContextNodeBinder).BindContextNode(NewString("test"))
+	assert.True(t, LazyErrors(bc))
+}
+
+func TestNewContextNoLazyErrors(t *testing.T) {
+	ctx := NewContext(newTestModel(t))
+	assert.False(t, LazyErrors(ctx))
+}
@@ -28,29 +28,76 @@
 
 package hipathsys
 
+import (
+	"errors"
+	"fmt"
+)
+
 type Error struct {
 	msg   string
 	items []*ErrorItem
+	cause error
 }
 
 type ErrorItem struct {
 	line   int
 	column int
 	msg    string
+	code   ErrorCode
+}
+
+type SourceRange struct {
+	line   int
+	column int
+	text   string
+}
+
+type EvaluationError struct {
+	source *SourceRange
+	err    error
 }
 
 func NewError(msg string, items []*ErrorItem) *Error {
-	return &Error{msg, items}
+	return &Error{msg, items, nil}
+}
+
+func NewErrorWithCause(msg string, items []*ErrorItem, cause error) *Error {
+	return &Error{msg, items, cause}
 }
 
 func NewErrorItem(line int, column int, msg string) *ErrorItem {
-	return &ErrorItem{line, column, msg}
+	return &ErrorItem{line, column, msg, SyntaxErrorCode}
+}
+
+func NewErrorItemWithCode(line int, column int, msg string, code ErrorCode) *ErrorItem {
+	return &ErrorItem{line, column, msg, code}
 }
 
 func (e *Error) Error() string {
 	return e.msg
 }
 
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// ErrorCode returns the error code of the cause of the error or, if there is
+// no cause, the error code of the first error item.
+func (e *Error) ErrorCode() ErrorCode {
+	if e.cause != nil {
+		return ErrorCodeOf(e.cause)
+	}
+	if len(e.items) > 0 {
+		return e.items[0].code
+	}
+	return EvaluationErrorCode
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*CodedError)
+	return ok && t.err == nil && t.code == e.ErrorCode()
+}
+
 func (e *Error) Items() []*ErrorItem {
 	return e.items
 }
@@ -66,3 +113,51 @@ func (e *ErrorItem) Column() int {
 func (e *ErrorItem) Msg() string {
 	return e.msg
 }
+
+func (e *ErrorItem) ErrorCode() ErrorCode {
+	return e.code
+}
+
+func NewSourceRange(line int, column int, text string) *SourceRange {
+	return &SourceRange{line, column, text}
+}
+
+func (r *SourceRange) Line() int {
+	return r.line
+}
+
+func (r *SourceRange) Column() int {
+	return r.column
+}
+
+func (r *SourceRange) Text() string {
+	return r.text
+}
+
+// NewEvaluationError returns an error that reports the specified source range
+// as origin of the error. If the error has already been reported for a
+// nested source range, the error is returned unchanged.
+func NewEvaluationError(source *SourceRange, err error) error {
+	if err == nil || source == nil {
+		return err
+	}
+
+	var evalErr *EvaluationError
+	if errors.As(err, &evalErr) {
+		return err
+	}
+	return &EvaluationError{source, err}
+}
+
+func (e *EvaluationError) Source() *SourceRange {
+	return e.source
+}
+
+func (e *EvaluationError) Unwrap() error {
+	return e.err
+}
+
+func (e *EvaluationError) Error() string {
+	return fmt.Sprintf("%s (at line %d, column %d: %s)",
+		e.err.Error(), e.source.line, e.source.column, e.source.text)
+}
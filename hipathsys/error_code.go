@@ -0,0 +1,170 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathsys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+type ErrorCode string
+
+const (
+	SyntaxErrorCode            ErrorCode = "syntax-error"
+	UnknownFunctionErrorCode   ErrorCode = "unknown-function"
+	InvalidArgumentsErrorCode  ErrorCode = "invalid-arguments"
+	TypeMismatchErrorCode      ErrorCode = "type-mismatch"
+	SingletonErrorCode         ErrorCode = "singleton-expected"
+	InvalidValueErrorCode      ErrorCode = "invalid-value"
+	UndefinedVariableErrorCode ErrorCode = "undefined-variable"
+	NotSupportedErrorCode      ErrorCode = "not-supported"
+	AdapterErrorCode           ErrorCode = "adapter-error"
+	LimitExceededErrorCode     ErrorCode = "limit-exceeded"
+	CanceledErrorCode          ErrorCode = "canceled"
+	TimeoutErrorCode           ErrorCode = "timeout"
+	EvaluationErrorCode        ErrorCode = "evaluation-error"
+)
+
+var (
+	ErrSyntax            = &CodedError{code: SyntaxErrorCode}
+	ErrUnknownFunction   = &CodedError{code: UnknownFunctionErrorCode}
+	ErrInvalidArguments  = &CodedError{code: InvalidArgumentsErrorCode}
+	ErrTypeMismatch      = &CodedError{code: TypeMismatchErrorCode}
+	ErrSingleton         = &CodedError{code: SingletonErrorCode}
+	ErrInvalidValue      = &CodedError{code: InvalidValueErrorCode}
+	ErrUndefinedVariable = &CodedError{code: UndefinedVariableErrorCode}
+	ErrNotSupported      = &CodedError{code: NotSupportedErrorCode}
+	ErrAdapter           = &CodedError{code: AdapterErrorCode}
+	ErrLimitExceeded     = &CodedError{code: LimitExceededErrorCode}
+	ErrCanceled          = &CodedError{code: CanceledErrorCode}
+	ErrTimeout           = &CodedError{code: TimeoutErrorCode}
+	ErrEvaluation        = &CodedError{code: EvaluationErrorCode}
+)
+
+// issueTypes maps error codes to codes of FHIR value set IssueType.
+var issueTypes = map[ErrorCode]string{
+	SyntaxErrorCode:            "invalid",
+	UnknownFunctionErrorCode:   "not-supported",
+	InvalidArgumentsErrorCode:  "invalid",
+	TypeMismatchErrorCode:      "value",
+	SingletonErrorCode:         "multiple-matches",
+	InvalidValueErrorCode:      "value",
+	UndefinedVariableErrorCode: "not-found",
+	NotSupportedErrorCode:      "not-supported",
+	AdapterErrorCode:           "exception",
+	LimitExceededErrorCode:     "too-costly",
+	CanceledErrorCode:          "incomplete",
+	TimeoutErrorCode:           "timeout",
+	EvaluationErrorCode:        "processing",
+}
+
+type ErrorCodeAccessor interface {
+	ErrorCode() ErrorCode
+}
+
+// CodedError is an error with a stable error code. A coded error without an
+// underlying error is a sentinel that matches all errors with the same code
+// when using errors.Is.
+type CodedError struct {
+	code ErrorCode
+	err  error
+}
+
+func NewCodedError(code ErrorCode, format string, a ...interface{}) error {
+	return &CodedError{code, fmt.Errorf(format, a...)}
+}
+
+func WrapCodedError(code ErrorCode, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &CodedError{code, err}
+}
+
+func NewAdapterError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var coded ErrorCodeAccessor
+	if errors.As(err, &coded) {
+		return err
+	}
+	return &CodedError{AdapterErrorCode, err}
+}
+
+func (e *CodedError) ErrorCode() ErrorCode {
+	return e.code
+}
+
+func (e *CodedError) Error() string {
+	if e.err == nil {
+		return string(e.code)
+	}
+	return e.err.Error()
+}
+
+func (e *CodedError) Unwrap() error {
+	return e.err
+}
+
+func (e *CodedError) Is(target error) bool {
+	t, ok := target.(*CodedError)
+	return ok && t.err == nil && t.code == e.code
+}
+
+// ErrorCodeOf returns the error code of the specified error. Errors without
+// an error code result in EvaluationErrorCode.
+func ErrorCodeOf(err error) ErrorCode {
+	if err == nil {
+		return ""
+	}
+
+	var coded ErrorCodeAccessor
+	if errors.As(err, &coded) {
+		return coded.ErrorCode()
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return TimeoutErrorCode
+	}
+	if errors.Is(err, context.Canceled) {
+		return CanceledErrorCode
+	}
+	return EvaluationErrorCode
+}
+
+// IssueType returns the code of FHIR value set IssueType that corresponds to
+// the error code.
+func (c ErrorCode) IssueType() string {
+	if t, found := issueTypes[c]; found {
+		return t
+	}
+	return "exception"
+}
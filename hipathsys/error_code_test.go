@@ -0,0 +1,137 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathsys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCodedError(t *testing.T) {
+	err := NewCodedError(TypeMismatchErrorCode, "not a string: %s", "test")
+	assert.Equal(t, "not a string: test", err.Error())
+	assert.Equal(t, TypeMismatchErrorCode, ErrorCodeOf(err))
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+	assert.False(t, errors.Is(err, ErrSingleton))
+
+	var coded *CodedError
+	if assert.True(t, errors.As(err, &coded), "coded error expected") {
+		assert.Equal(t, TypeMismatchErrorCode, coded.ErrorCode())
+		assert.NotNil(t, coded.Unwrap())
+	}
+}
+
+func TestCodedErrorWrapped(t *testing.T) {
+	cause := fmt.Errorf("test cause")
+	err := fmt.Errorf("wrapped: %w", NewCodedError(SingletonErrorCode, "test: %w", cause))
+	assert.Equal(t, SingletonErrorCode, ErrorCodeOf(err))
+	assert.True(t, errors.Is(err, ErrSingleton))
+	assert.True(t, errors.Is(err, cause))
+}
+
+func TestCodedErrorSentinel(t *testing.T) {
+	assert.Equal(t, "syntax-error", ErrSyntax.Error())
+	assert.True(t, errors.Is(ErrSyntax, ErrSyntax))
+	assert.False(t, errors.Is(ErrSyntax, ErrTimeout))
+}
+
+func TestWrapCodedError(t *testing.T) {
+	cause := fmt.Errorf("test cause")
+	err := WrapCodedError(InvalidValueErrorCode, cause)
+	assert.Equal(t, "test cause", err.Error())
+	assert.True(t, errors.Is(err, ErrInvalidValue))
+	assert.True(t, errors.Is(err, cause))
+}
+
+func TestWrapCodedErrorNil(t *testing.T) {
+	assert.Nil(t, WrapCodedError(InvalidValueErrorCode, nil))
+}
+
+func TestNewAdapterError(t *testing.T) {
+	cause := fmt.Errorf("test cause")
+	err := NewAdapterError(cause)
+	assert.Equal(t, AdapterErrorCode, ErrorCodeOf(err))
+	assert.True(t, errors.Is(err, ErrAdapter))
+	assert.True(t, errors.Is(err, cause))
+}
+
+func TestNewAdapterErrorCoded(t *testing.T) {
+	cause := NewCodedError(TypeMismatchErrorCode, "test cause")
+	assert.Same(t, cause, NewAdapterError(cause))
+}
+
+func TestNewAdapterErrorNil(t *testing.T) {
+	assert.Nil(t, NewAdapterError(nil))
+}
+
+func TestErrorCodeOf(t *testing.T) {
+	assert.Equal(t, ErrorCode(""), ErrorCodeOf(nil))
+	assert.Equal(t, EvaluationErrorCode, ErrorCodeOf(fmt.Errorf("test")))
+	assert.Equal(t, CanceledErrorCode, ErrorCodeOf(context.Canceled))
+	assert.Equal(t, TimeoutErrorCode, ErrorCodeOf(context.DeadlineExceeded))
+	assert.Equal(t, LimitExceededErrorCode, ErrorCodeOf(NewLimitError(StepsLimitName, 10)))
+	assert.True(t, errors.Is(NewLimitError(StepsLimitName, 10), ErrLimitExceeded))
+}
+
+func TestErrorCodeCanceled(t *testing.T) {
+	goCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := CheckCanceled(BindGoContext(newTestContext(t), goCtx))
+	assert.Equal(t, CanceledErrorCode, ErrorCodeOf(err))
+	assert.True(t, errors.Is(err, ErrCanceled))
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestErrorCodeIssueType(t *testing.T) {
+	assert.Equal(t, "invalid", SyntaxErrorCode.IssueType())
+	assert.Equal(t, "not-supported", UnknownFunctionErrorCode.IssueType())
+	assert.Equal(t, "value", TypeMismatchErrorCode.IssueType())
+	assert.Equal(t, "multiple-matches", SingletonErrorCode.IssueType())
+	assert.Equal(t, "too-costly", LimitExceededErrorCode.IssueType())
+	assert.Equal(t, "timeout", TimeoutErrorCode.IssueType())
+	assert.Equal(t, "processing", EvaluationErrorCode.IssueType())
+	assert.Equal(t, "exception", ErrorCode("other").IssueType())
+}
+
+func TestErrorCodeModelCast(t *testing.T) {
+	res, err := CastModelType(newTestModel(t), newTestModelNode(10, false, nil), NewFQTypeName("test", "Other"))
+	assert.Nil(t, res)
+	assert.Equal(t, AdapterErrorCode, ErrorCodeOf(err))
+	assert.Equal(t, "unsupported conversion", err.Error())
+}
+
+func TestErrorCodeModelConvert(t *testing.T) {
+	c := NewCollection(newTestModel(t))
+	err := c.Add(newTestModelErrorNode())
+	assert.Equal(t, AdapterErrorCode, ErrorCodeOf(err))
+}
@@ -29,6 +29,8 @@
 package hipathsys
 
 import (
+	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -49,6 +51,20 @@ func TestError(t *testing.T) {
 	}
 }
 
+func TestErrorWithCause(t *testing.T) {
+	cause := fmt.Errorf("test cause")
+	err := NewErrorWithCause("this is an error", nil, cause)
+	assert.Equal(t, "this is an error", err.Error())
+	assert.Nil(t, err.Items())
+	assert.Same(t, cause, err.Unwrap())
+	assert.True(t, errors.Is(err, cause))
+}
+
+func TestErrorWithoutCause(t *testing.T) {
+	err := NewError("this is an error", nil)
+	assert.Nil(t, err.Unwrap())
+}
+
 func TestErrorItem(t *testing.T) {
 	item := NewErrorItem(28, 12, "Test Error")
 
@@ -56,3 +72,61 @@ func TestErrorItem(t *testing.T) {
 	assert.Equal(t, 12, item.Column())
 	assert.Equal(t, "Test Error", item.Msg())
 }
+
+func TestSourceRange(t *testing.T) {
+	r := NewSourceRange(2, 7, "single()")
+	assert.Equal(t, 2, r.Line())
+	assert.Equal(t, 7, r.Column())
+	assert.Equal(t, "single()", r.Text())
+}
+
+func TestEvaluationError(t *testing.T) {
+	cause := fmt.Errorf("test cause")
+	source := NewSourceRange(2, 7, "single()")
+
+	err := NewEvaluationError(source, cause)
+	var evalErr *EvaluationError
+	if assert.True(t, errors.As(err, &evalErr), "evaluation error expected") {
+		assert.Same(t, source, evalErr.Source())
+		assert.Same(t, cause, evalErr.Unwrap())
+	}
+	assert.Equal(t, "test cause (at line 2, column 7: single())", err.Error())
+}
+
+func TestEvaluationErrorNested(t *testing.T) {
+	err := NewEvaluationError(NewSourceRange(1, 2, "inner"), fmt.Errorf("test cause"))
+	wrapped := fmt.Errorf("wrapped: %w", err)
+
+	assert.Same(t, wrapped, NewEvaluationError(NewSourceRange(1, 0, "outer"), wrapped))
+}
+
+func TestEvaluationErrorNoSource(t *testing.T) {
+	cause := fmt.Errorf("test cause")
+	assert.Same(t, cause, NewEvaluationError(nil, cause))
+}
+
+func TestEvaluationErrorNil(t *testing.T) {
+	assert.Nil(t, NewEvaluationError(NewSourceRange(1, 2, "test"), nil))
+}
+
+func TestErrorCodeCause(t *testing.T) {
+	err := NewErrorWithCause("test", nil, NewCodedError(SingletonErrorCode, "test"))
+	assert.Equal(t, SingletonErrorCode, err.ErrorCode())
+	assert.True(t, errors.Is(err, ErrSingleton))
+	assert.False(t, errors.Is(err, ErrSyntax))
+}
+
+func TestErrorCodeItems(t *testing.T) {
+	err := NewError("test", []*ErrorItem{
+		NewErrorItemWithCode(1, 2, "test", UnknownFunctionErrorCode),
+		NewErrorItem(1, 3, "test"),
+	})
+	assert.Equal(t, UnknownFunctionErrorCode, err.ErrorCode())
+	assert.True(t, errors.Is(err, ErrUnknownFunction))
+	assert.Equal(t, SyntaxErrorCode, err.Items()[1].ErrorCode())
+}
+
+func TestErrorCodeNone(t *testing.T) {
+	err := NewError("test", nil)
+	assert.Equal(t, EvaluationErrorCode, err.ErrorCode())
+}
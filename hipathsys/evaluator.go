@@ -30,11 +30,29 @@ package hipathsys
 
 type loop struct {
 	evaluator Evaluator
+	outer     Looper
 	this      interface{}
 	index     int
 	total     interface{}
 }
 
+// lambdaLoop binds the named lambda parameters. The loop variables $index and
+// $total are the ones of the loop that evaluates the lambda, whereas $this
+// is the one of the enclosing loop in which the lambda has been defined.
+type lambdaLoop struct {
+	loop      Looper
+	enclosing Looper
+	params    map[string]interface{}
+}
+
+// variableLoop is the scope of the variables that are defined by the
+// expressions that are evaluated within this loop. All other information is
+// provided by the wrapped loop.
+type variableLoop struct {
+	loop      Looper
+	variables map[string]interface{}
+}
+
 type Looper interface {
 	Evaluator() Evaluator
 	This() interface{}
@@ -44,18 +62,123 @@ type Looper interface {
 	SetTotal(total interface{})
 }
 
+// OuterLooper is implemented by loops that are nested into an outer loop.
+type OuterLooper interface {
+	Outer() Looper
+}
+
+// ParamLooper is implemented by loops that provide named lambda parameters.
+type ParamLooper interface {
+	Param(name string) (interface{}, bool)
+}
+
+// VariableLooper is implemented by loops that provide the scope of variables
+// that are defined by defineVariable.
+type VariableLooper interface {
+	Variable(name string) (interface{}, bool)
+	DefineVariable(name string, value interface{}) bool
+}
+
 func NewLoop(evaluator Evaluator) Looper {
-	return &loop{evaluator, nil, -1, nil}
+	return NewLoopWithOuter(evaluator, nil)
 }
 
 func NewLoopWithIndex(evaluator Evaluator, index int) Looper {
-	return &loop{evaluator, nil, index - 1, nil}
+	return NewLoopWithIndexAndOuter(evaluator, index, nil)
+}
+
+func NewLoopWithOuter(evaluator Evaluator, outer Looper) Looper {
+	return &loop{evaluator, outer, nil, -1, nil}
+}
+
+func NewLoopWithIndexAndOuter(evaluator Evaluator, index int, outer Looper) Looper {
+	return &loop{evaluator, outer, nil, index - 1, nil}
+}
+
+func NewLambdaLoop(loop Looper, enclosing Looper, params map[string]interface{}) Looper {
+	return &lambdaLoop{loop, enclosing, params}
+}
+
+func NewVariableLoop(loop Looper) Looper {
+	return &variableLoop{loop, make(map[string]interface{})}
+}
+
+// LoopOuter returns the outer loop of the specified loop or nil if the loop
+// is not nested into an outer loop.
+func LoopOuter(loop Looper) Looper {
+	if o, ok := loop.(OuterLooper); ok {
+		return o.Outer()
+	}
+	return nil
+}
+
+// LoopParam returns the value of the lambda parameter with the specified name
+// that is visible within the specified loop. Loops that do not provide lambda
+// parameters are skipped.
+func LoopParam(loop Looper, name string) (interface{}, bool) {
+	for l := loop; l != nil; l = LoopOuter(l) {
+		if p, ok := l.(ParamLooper); ok {
+			return p.Param(name)
+		}
+	}
+	return nil, false
+}
+
+// LoopVariable returns the value of the variable with the specified name that
+// is visible within the specified loop. Loops that do not provide variables
+// are skipped.
+func LoopVariable(loop Looper, name string) (interface{}, bool) {
+	for l := loop; l != nil; l = LoopOuter(l) {
+		if v, ok := l.(VariableLooper); ok {
+			return v.Variable(name)
+		}
+	}
+	return nil, false
+}
+
+// DefineLoopVariable defines the variable with the specified name in the
+// nearest variable scope of the specified loop. It returns false if there is
+// no such scope.
+func DefineLoopVariable(loop Looper, name string, value interface{}) bool {
+	for l := loop; l != nil; l = LoopOuter(l) {
+		if v, ok := l.(VariableLooper); ok {
+			return v.DefineVariable(name, value)
+		}
+	}
+	return false
+}
+
+// HasThis returns if $this is defined by the specified loop.
+func HasThis(loop Looper) bool {
+	switch l := loop.(type) {
+	case *lambdaLoop:
+		return l.enclosing != nil && HasThis(l.enclosing)
+	case *variableLoop:
+		return HasThis(l.loop)
+	}
+	return loop != nil
+}
+
+// HasIndex returns if $index and $total are defined by the specified loop,
+// i.e. the loop belongs to an iteration.
+func HasIndex(loop Looper) bool {
+	switch l := loop.(type) {
+	case *lambdaLoop:
+		return HasIndex(l.loop)
+	case *variableLoop:
+		return HasIndex(l.loop)
+	}
+	return loop != nil
 }
 
 func (c *loop) Evaluator() Evaluator {
 	return c.evaluator
 }
 
+func (c *loop) Outer() Looper {
+	return c.outer
+}
+
 func (c *loop) This() interface{} {
 	if c.index < 0 {
 		panic("index has not yet been incremented")
@@ -81,10 +204,86 @@ func (c *loop) SetTotal(total interface{}) {
 	c.total = total
 }
 
+func (c *loop) Param(name string) (interface{}, bool) {
+	return LoopParam(c.outer, name)
+}
+
+func (c *loop) Variable(name string) (interface{}, bool) {
+	return LoopVariable(c.outer, name)
+}
+
+func (c *loop) DefineVariable(name string, value interface{}) bool {
+	return DefineLoopVariable(c.outer, name, value)
+}
+
+func (c *lambdaLoop) Evaluator() Evaluator {
+	if c.loop == nil {
+		return nil
+	}
+	return c.loop.Evaluator()
+}
+
+func (c *lambdaLoop) Outer() Looper {
+	return c.enclosing
+}
+
+func (c *lambdaLoop) This() interface{} {
+	if c.enclosing == nil {
+		return nil
+	}
+	return c.enclosing.This()
+}
+
+func (c *lambdaLoop) Index() int {
+	if c.loop == nil {
+		return -1
+	}
+	return c.loop.Index()
+}
+
+func (c *lambdaLoop) IncIndex(this interface{}) int {
+	if c.loop == nil {
+		return -1
+	}
+	return c.loop.IncIndex(this)
+}
+
+func (c *lambdaLoop) Total() interface{} {
+	if c.loop == nil {
+		return nil
+	}
+	return c.loop.Total()
+}
+
+func (c *lambdaLoop) SetTotal(total interface{}) {
+	if c.loop != nil {
+		c.loop.SetTotal(total)
+	}
+}
+
+func (c *lambdaLoop) Param(name string) (interface{}, bool) {
+	if value, found := c.params[name]; found {
+		return value, true
+	}
+	return LoopParam(c.enclosing, name)
+}
+
+func (c *lambdaLoop) Variable(name string) (interface{}, bool) {
+	return LoopVariable(c.enclosing, name)
+}
+
+func (c *lambdaLoop) DefineVariable(name string, value interface{}) bool {
+	return DefineLoopVariable(c.enclosing, name, value)
+}
+
 type Evaluator interface {
 	Evaluate(ctx ContextAccessor, node interface{}, loop Looper) (interface{}, error)
 }
 
+// AllEvaluatorParams is used as evaluator parameter of functions that receive
+// all their arguments as unevaluated evaluators.
+const AllEvaluatorParams = -2
+
 type BaseFunction struct {
 	name           string
 	evaluatorParam int
@@ -119,3 +318,64 @@ func (f *BaseFunction) MinParams() int {
 func (f *BaseFunction) MaxParams() int {
 	return f.maxParams
 }
+
+func (c *variableLoop) Evaluator() Evaluator {
+	if c.loop == nil {
+		return nil
+	}
+	return c.loop.Evaluator()
+}
+
+func (c *variableLoop) Outer() Looper {
+	return LoopOuter(c.loop)
+}
+
+func (c *variableLoop) This() interface{} {
+	if c.loop == nil {
+		return nil
+	}
+	return c.loop.This()
+}
+
+func (c *variableLoop) Index() int {
+	if c.loop == nil {
+		return -1
+	}
+	return c.loop.Index()
+}
+
+func (c *variableLoop) IncIndex(this interface{}) int {
+	if c.loop == nil {
+		return -1
+	}
+	return c.loop.IncIndex(this)
+}
+
+func (c *variableLoop) Total() interface{} {
+	if c.loop == nil {
+		return nil
+	}
+	return c.loop.Total()
+}
+
+func (c *variableLoop) SetTotal(total interface{}) {
+	if c.loop != nil {
+		c.loop.SetTotal(total)
+	}
+}
+
+func (c *variableLoop) Param(name string) (interface{}, bool) {
+	return LoopParam(c.loop, name)
+}
+
+func (c *variableLoop) Variable(name string) (interface{}, bool) {
+	if value, found := c.variables[name]; found {
+		return value, true
+	}
+	return LoopVariable(c.loop, name)
+}
+
+func (c *variableLoop) DefineVariable(name string, value interface{}) bool {
+	c.variables[name] = value
+	return true
+}
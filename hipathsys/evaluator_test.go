@@ -73,6 +73,180 @@ func TestLoopSetTotal(t *testing.T) {
 	assert.Same(t, total, loop.Total())
 }
 
+func TestLoopWithOuter(t *testing.T) {
+	outer := NewLoop(nil)
+	loop := NewLoopWithOuter(newTestEvaluator(), outer)
+	assert.Same(t, outer, LoopOuter(loop))
+	assert.Equal(t, -1, loop.Index())
+	assert.Nil(t, LoopOuter(NewLoop(nil)))
+}
+
+func TestLoopWithIndexAndOuter(t *testing.T) {
+	outer := NewLoop(nil)
+	loop := NewLoopWithIndexAndOuter(newTestEvaluator(), 5, outer)
+	assert.Same(t, outer, LoopOuter(loop))
+	assert.Equal(t, 5, loop.IncIndex(NewString("test")))
+}
+
+func TestLoopParamUndefined(t *testing.T) {
+	v, ok := LoopParam(NewLoopWithOuter(nil, NewLoop(nil)), "x")
+	assert.False(t, ok)
+	assert.Nil(t, v)
+}
+
+func TestLambdaLoop(t *testing.T) {
+	outerThis := NewString("outer")
+	enclosing := NewLoop(nil)
+	enclosing.IncIndex(outerThis)
+
+	evaluator := newTestEvaluator()
+	loop := NewLoopWithOuter(evaluator, enclosing)
+	value := NewString("value")
+	loop.IncIndex(value)
+	loop.IncIndex(value)
+
+	lambda := NewLambdaLoop(loop, enclosing, map[string]interface{}{"x": value})
+	assert.Same(t, evaluator, lambda.Evaluator())
+	assert.Same(t, enclosing, LoopOuter(lambda))
+	assert.Same(t, outerThis, lambda.This())
+	assert.Equal(t, 1, lambda.Index())
+	assert.Equal(t, 2, lambda.IncIndex(value))
+
+	total := NewString("total")
+	lambda.SetTotal(total)
+	assert.Same(t, total, lambda.Total())
+	assert.Same(t, total, loop.Total())
+
+	v, ok := LoopParam(lambda, "x")
+	assert.True(t, ok)
+	assert.Same(t, value, v)
+	v, ok = LoopParam(lambda, "y")
+	assert.False(t, ok)
+	assert.Nil(t, v)
+}
+
+func TestLambdaLoopNested(t *testing.T) {
+	x := NewString("x")
+	y := NewString("y")
+	outerLambda := NewLambdaLoop(nil, nil, map[string]interface{}{"x": x})
+	loop := NewLoopWithOuter(nil, outerLambda)
+	lambda := NewLambdaLoop(loop, LoopOuter(loop), map[string]interface{}{"y": y})
+
+	v, ok := LoopParam(lambda, "x")
+	assert.True(t, ok)
+	assert.Same(t, x, v)
+	v, ok = LoopParam(lambda, "y")
+	assert.True(t, ok)
+	assert.Same(t, y, v)
+
+	shadowing := NewLambdaLoop(loop, LoopOuter(loop), map[string]interface{}{"x": y})
+	v, ok = LoopParam(shadowing, "x")
+	assert.True(t, ok)
+	assert.Same(t, y, v)
+}
+
+func TestLambdaLoopWithoutLoop(t *testing.T) {
+	lambda := NewLambdaLoop(nil, nil, map[string]interface{}{"x": NewString("x")})
+	assert.Nil(t, lambda.Evaluator())
+	assert.Nil(t, LoopOuter(lambda))
+	assert.Nil(t, lambda.This())
+	assert.Equal(t, -1, lambda.Index())
+	assert.Equal(t, -1, lambda.IncIndex(NewString("test")))
+	lambda.SetTotal(NewString("total"))
+	assert.Nil(t, lambda.Total())
+}
+
+func TestHasThis(t *testing.T) {
+	loop := NewLoop(nil)
+	assert.False(t, HasThis(nil))
+	assert.True(t, HasThis(loop))
+	assert.True(t, HasThis(NewLambdaLoop(nil, loop, map[string]interface{}{"x": nil})))
+	assert.False(t, HasThis(NewLambdaLoop(loop, nil, map[string]interface{}{"x": nil})))
+	outer := NewLambdaLoop(nil, nil, map[string]interface{}{"y": nil})
+	assert.False(t, HasThis(NewLambdaLoop(loop, outer, map[string]interface{}{"x": nil})))
+}
+
+func TestVariableLoop(t *testing.T) {
+	evaluator := newTestEvaluator()
+	outer := NewLoop(nil)
+	loop := NewLoopWithOuter(evaluator, outer)
+	this := NewString("test")
+	loop.IncIndex(this)
+
+	v := NewVariableLoop(loop)
+	assert.Same(t, evaluator, v.Evaluator())
+	assert.Same(t, outer, LoopOuter(v))
+	assert.Same(t, this, v.This())
+	assert.Equal(t, 0, v.Index())
+	assert.Equal(t, 1, v.IncIndex(this))
+	total := NewString("total")
+	v.SetTotal(total)
+	assert.Same(t, total, v.Total())
+	assert.True(t, HasThis(v))
+	assert.True(t, HasIndex(v))
+}
+
+func TestVariableLoopWithoutLoop(t *testing.T) {
+	v := NewVariableLoop(nil)
+	assert.Nil(t, v.Evaluator())
+	assert.Nil(t, LoopOuter(v))
+	assert.Nil(t, v.This())
+	assert.Equal(t, -1, v.Index())
+	assert.Equal(t, -1, v.IncIndex(NewString("test")))
+	v.SetTotal(NewString("total"))
+	assert.Nil(t, v.Total())
+	assert.False(t, HasThis(v))
+	assert.False(t, HasIndex(v))
+
+	value, found := LoopParam(v, "x")
+	assert.False(t, found)
+	assert.Nil(t, value)
+	value, found = LoopVariable(v, "x")
+	assert.False(t, found)
+	assert.Nil(t, value)
+}
+
+func TestVariableLoopDefineVariable(t *testing.T) {
+	outer := NewVariableLoop(nil)
+	a, b := NewString("a"), NewString("b")
+	assert.True(t, DefineLoopVariable(outer, "a", a))
+
+	inner := NewVariableLoop(NewLoopWithOuter(nil, NewLambdaLoop(nil, outer, map[string]interface{}{"x": b})))
+	assert.True(t, DefineLoopVariable(inner, "b", b))
+
+	value, found := LoopVariable(inner, "a")
+	assert.True(t, found)
+	assert.Same(t, a, value)
+	value, found = LoopVariable(inner, "b")
+	assert.True(t, found)
+	assert.Same(t, b, value)
+	value, found = LoopParam(inner, "x")
+	assert.True(t, found)
+	assert.Same(t, b, value)
+
+	value, found = LoopVariable(outer, "b")
+	assert.False(t, found, "variable of inner scope must not be visible")
+	assert.Nil(t, value)
+}
+
+func TestLoopDefineVariable(t *testing.T) {
+	v := NewVariableLoop(nil)
+	loop := NewLoopWithOuter(nil, NewLambdaLoop(nil, v, map[string]interface{}{"x": nil}))
+	value := NewString("test")
+	assert.True(t, DefineLoopVariable(loop, "a", value))
+
+	res, found := LoopVariable(v, "a")
+	assert.True(t, found)
+	assert.Same(t, value, res)
+}
+
+func TestLoopDefineVariableNoScope(t *testing.T) {
+	assert.False(t, DefineLoopVariable(NewLoop(nil), "a", nil))
+	assert.False(t, DefineLoopVariable(NewLambdaLoop(nil, nil, map[string]interface{}{"x": nil}), "a", nil))
+	_, found := LoopVariable(NewLambdaLoop(nil, nil, map[string]interface{}{"x": nil}), "a")
+	assert.False(t, found)
+}
+
 func TestNewBaseFunction(t *testing.T) {
 	bf := NewBaseFunction("test", 2, 1, 5)
 	assert.Equal(t, "test", bf.Name())
@@ -91,3 +265,22 @@ func newTestEvaluator() Evaluator {
 func (t testEvaluator) Evaluate(ContextAccessor, interface{}, Looper) (interface{}, error) {
 	return nil, nil
 }
+
+type testLooper struct {
+	Looper
+}
+
+func TestLoopOuterNotNested(t *testing.T) {
+	loop := &testLooper{NewLoop(nil)}
+	assert.Nil(t, LoopOuter(loop))
+	value, found := LoopParam(loop, "x")
+	assert.False(t, found)
+	assert.Nil(t, value)
+	assert.True(t, HasIndex(loop))
+}
+
+func TestHasIndexLambdaLoop(t *testing.T) {
+	assert.False(t, HasIndex(nil))
+	assert.False(t, HasIndex(NewLambdaLoop(nil, NewLoop(nil), nil)))
+	assert.True(t, HasIndex(NewLambdaLoop(NewLoop(nil), nil, nil)))
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathsys
+
+import (
+	"fmt"
+	"sync"
+)
+
+type FunctionRegistry struct {
+	parent    *FunctionRegistry
+	lock      sync.RWMutex
+	functions map[string]FunctionExecutor
+}
+
+func NewFunctionRegistry(parent *FunctionRegistry) *FunctionRegistry {
+	return &FunctionRegistry{
+		parent:    parent,
+		functions: make(map[string]FunctionExecutor),
+	}
+}
+
+func (r *FunctionRegistry) Parent() *FunctionRegistry {
+	return r.parent
+}
+
+func (r *FunctionRegistry) Register(function FunctionExecutor) error {
+	return r.register(function, false)
+}
+
+func (r *FunctionRegistry) Override(function FunctionExecutor) error {
+	return r.register(function, true)
+}
+
+func (r *FunctionRegistry) register(function FunctionExecutor, override bool) error {
+	if function == nil {
+		return fmt.Errorf("function must not be nil")
+	}
+	name := function.Name()
+	if len(name) == 0 {
+		return fmt.Errorf("function name must not be empty")
+	}
+	if function.MinParams() < 0 || function.MaxParams() < function.MinParams() {
+		return fmt.Errorf("function %s has invalid parameter counts", name)
+	}
+	if function.EvaluatorParam() >= function.MaxParams() {
+		return fmt.Errorf("evaluator parameter of function %s exceeds parameters", name)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !override {
+		if _, found := r.functions[name]; found {
+			return fmt.Errorf("function has already been registered: %s", name)
+		}
+		if r.parent != nil && r.parent.Lookup(name) != nil {
+			return fmt.Errorf("function has already been defined: %s", name)
+		}
+	}
+	r.functions[name] = function
+	return nil
+}
+
+func (r *FunctionRegistry) Lookup(name string) FunctionExecutor {
+	r.lock.RLock()
+	f, found := r.functions[name]
+	r.lock.RUnlock()
+
+	if found {
+		return f
+	}
+	if r.parent != nil {
+		return r.parent.Lookup(name)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathsys
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type testFunction struct {
+	BaseFunction
+}
+
+func newTestFunction(name string, minParams int, maxParams int) *testFunction {
+	return &testFunction{BaseFunction: NewBaseFunction(name, -1, minParams, maxParams)}
+}
+
+func (f *testFunction) Execute(ContextAccessor, interface{}, []interface{}, Looper) (interface{}, error) {
+	return nil, nil
+}
+
+func TestFunctionRegistryRegister(t *testing.T) {
+	r := NewFunctionRegistry(nil)
+	f := newTestFunction("test", 0, 1)
+	assert.NoError(t, r.Register(f), "no error expected")
+	assert.Same(t, f, r.Lookup("test"))
+	assert.Nil(t, r.Lookup("other"))
+	assert.Nil(t, r.Parent())
+}
+
+func TestFunctionRegistryRegisterDuplicate(t *testing.T) {
+	r := NewFunctionRegistry(nil)
+	f := newTestFunction("test", 0, 1)
+	assert.NoError(t, r.Register(f), "no error expected")
+	assert.Error(t, r.Register(newTestFunction("test", 0, 0)), "error expected")
+	assert.Same(t, f, r.Lookup("test"))
+}
+
+func TestFunctionRegistryRegisterParentDuplicate(t *testing.T) {
+	p := NewFunctionRegistry(nil)
+	f := newTestFunction("test", 0, 1)
+	assert.NoError(t, p.Register(f), "no error expected")
+
+	r := NewFunctionRegistry(p)
+	assert.Same(t, p, r.Parent())
+	assert.Error(t, r.Register(newTestFunction("test", 0, 0)), "error expected")
+	assert.Same(t, f, r.Lookup("test"))
+}
+
+func TestFunctionRegistryOverride(t *testing.T) {
+	p := NewFunctionRegistry(nil)
+	f1 := newTestFunction("test", 0, 1)
+	assert.NoError(t, p.Register(f1), "no error expected")
+
+	r := NewFunctionRegistry(p)
+	f2 := newTestFunction("test", 0, 0)
+	assert.NoError(t, r.Override(f2), "no error expected")
+	assert.Same(t, f2, r.Lookup("test"))
+	assert.Same(t, f1, p.Lookup("test"))
+}
+
+func TestFunctionRegistryRegisterNil(t *testing.T) {
+	r := NewFunctionRegistry(nil)
+	assert.Error(t, r.Register(nil), "error expected")
+}
+
+func TestFunctionRegistryRegisterEmptyName(t *testing.T) {
+	r := NewFunctionRegistry(nil)
+	assert.Error(t, r.Register(newTestFunction("", 0, 0)), "error expected")
+}
+
+func TestFunctionRegistryRegisterInvalidParams(t *testing.T) {
+	r := NewFunctionRegistry(nil)
+	assert.Error(t, r.Register(newTestFunction("test", 2, 1)), "error expected")
+}
+
+func TestFunctionRegistryRegisterInvalidEvaluatorParam(t *testing.T) {
+	r := NewFunctionRegistry(nil)
+	f := &testFunction{BaseFunction: NewBaseFunction("test", 1, 0, 1)}
+	assert.Error(t, r.Register(f), "error expected")
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathsys
+
+import "context"
+
+type GoContextAccessor interface {
+	GoContext() context.Context
+}
+
+type GoContextBinder interface {
+	BindGoContext(goCtx context.Context) ContextAccessor
+}
+
+type goContextWrapper struct {
+	ContextAccessor
+	goCtx context.Context
+}
+
+// BindGoContext returns a context that makes the evaluation cancelable by
+// the specified Go context.
+func BindGoContext(ctx ContextAccessor, goCtx context.Context) ContextAccessor {
+	if goCtx == nil {
+		return ctx
+	}
+	if binder, ok := ctx.(GoContextBinder); ok {
+		return binder.BindGoContext(goCtx)
+	}
+	if w, ok := ctx.(*goContextWrapper); ok {
+		ctx = w.ContextAccessor
+	}
+	return &goContextWrapper{ctx, goCtx}
+}
+
+func GoContext(ctx ContextAccessor) context.Context {
+	if c, ok := ctx.(GoContextAccessor); ok {
+		return c.GoContext()
+	}
+	return nil
+}
+
+// CheckCanceled returns an error if the Go context that is bound to the
+// specified context has been canceled or its deadline has been exceeded.
+func CheckCanceled(ctx ContextAccessor) error {
+	goCtx := GoContext(ctx)
+	if goCtx == nil {
+		return nil
+	}
+
+	switch err := goCtx.Err(); err {
+	case nil:
+		return nil
+	case context.DeadlineExceeded:
+		return NewCodedError(TimeoutErrorCode, "evaluation deadline has been exceeded: %w", err)
+	default:
+		return NewCodedError(CanceledErrorCode, "evaluation has been canceled: %w", err)
+	}
+}
+
+func (c *goContextWrapper) GoContext() context.Context {
+	return c.goCtx
+}
+
+func (c *goContextWrapper) LazyErrors() bool {
+	return LazyErrors(c.ContextAccessor)
+}
+
+func (c *goContextWrapper) ReferenceResolver() ReferenceResolver {
+	return ContextReferenceResolver(c.ContextAccessor)
+}
+
+func (c *goContextWrapper) TerminologyProvider() TerminologyProvider {
+	return ContextTerminologyProvider(c.ContextAccessor)
+}
+
+func (c *goContextWrapper) ProfileValidator() ProfileValidator {
+	return ContextProfileValidator(c.ContextAccessor)
+}
+
+func (c *goContextWrapper) BindContextNode(node interface{}) ContextAccessor {
+	if binder, ok := c.ContextAccessor.(ContextNodeBinder); ok {
+		return &goContextWrapper{binder.BindContextNode(node), c.goCtx}
+	}
+	return c
+}
+
+func (c *goContextWrapper) Limiter() *Limiter {
+	return ContextLimiter(c.ContextAccessor)
+}
+
+func (c *goContextWrapper) BindLimiter() ContextAccessor {
+	return &goContextWrapper{BindLimiter(c.ContextAccessor), c.goCtx}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathsys
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestBindGoContextNil(t *testing.T) {
+	ctx := newTestContext(t)
+	assert.Same(t, ctx, BindGoContext(ctx, nil))
+}
+
+func TestBindGoContextWrapped(t *testing.T) {
+	goCtx := context.Background()
+	ctx := newTestContext(t)
+
+	bc := BindGoContext(ctx, goCtx)
+	assert.NotSame(t, ctx, bc)
+	assert.Equal(t, goCtx, GoContext(bc))
+	assert.Nil(t, GoContext(ctx))
+	assert.False(t, LazyErrors(bc))
+	assert.Same(t, bc, bc.(ContextNodeBinder).BindContextNode(NewString("test")))
+
+	otherGoCtx, cancel := context.WithCancel(goCtx)
+	defer cancel()
+	bc = BindGoContext(bc, otherGoCtx)
+	assert.Same(t, ctx, bc.(*goContextWrapper).ContextAccessor)
+	assert.Equal(t, otherGoCtx, GoContext(bc))
+}
+
+func TestBindGoContextWrappedServices(t *testing.T) {
+	resolver := &testReferenceResolver{}
+	provider := &testTerminologyProvider{}
+	validator := &testProfileValidator{}
+	ctx := &goContextWrapper{NewContext(newTestModel(t), WithReferenceResolver(resolver),
+		WithTerminologyProvider(provider), WithProfileValidator(validator)), context.Background()}
+
+	assert.Same(t, resolver, ContextReferenceResolver(ctx))
+	assert.Same(t, provider, ContextTerminologyProvider(ctx))
+	assert.Same(t, validator, ContextProfileValidator(ctx))
+}
+
+func TestBindGoContextBinder(t *testing.T) {
+	goCtx := context.Background()
+	ctx := NewContext(newTestModel(t), WithLazyErrors(true))
+
+	bc := BindGoContext(ctx, goCtx)
+	assert.IsType(t, (*defaultContext)(nil), bc)
+	assert.Equal(t, goCtx, GoContext(bc))
+	assert.Nil(t, GoContext(ctx))
+	assert.True(t, LazyErrors(bc))
+}
+
+func TestBindGoContextWrappedContextNode(t *testing.T) {
+	node := NewString("test")
+	ctx := BindGoContext(NewContext(newTestModel(t)), context.Background())
+	ctx = &goContextWrapper{ctx.(*defaultContext), context.Background()}
+
+	bc := ctx.(ContextNodeBinder).BindContextNode(node)
+	assert.Same(t, node, bc.ContextNode())
+	assert.Equal(t, context.Background(), GoContext(bc))
+}
+
+func TestCheckCanceledNoGoContext(t *testing.T) {
+	assert.NoError(t, CheckCanceled(newTestContext(t)), "no error expected")
+}
+
+func TestCheckCanceledActive(t *testing.T) {
+	goCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	assert.NoError(t, CheckCanceled(BindGoContext(newTestContext(t), goCtx)), "no error expected")
+}
+
+func TestCheckCanceled(t *testing.T) {
+	goCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := CheckCanceled(BindGoContext(newTestContext(t), goCtx))
+	if assert.Error(t, err, "error expected") {
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.False(t, errors.Is(err, context.DeadlineExceeded))
+	}
+}
+
+func TestCheckCanceledDeadlineExceeded(t *testing.T) {
+	goCtx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	err := CheckCanceled(BindGoContext(newTestContext(t), goCtx))
+	if assert.Error(t, err, "error expected") {
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.False(t, errors.Is(err, context.Canceled))
+	}
+}
@@ -59,7 +59,7 @@ func NewIntegerWithSource(value int32, source interface{}) IntegerAccessor {
 
 func ParseInteger(value string) (IntegerAccessor, error) {
 	if i, err := strconv.Atoi(value); err != nil {
-		return nil, fmt.Errorf("not an integer: %s", value)
+		return nil, NewCodedError(InvalidValueErrorCode, "not an integer: %s", value)
 	} else {
 		return NewInteger(int32(i)), nil
 	}
@@ -194,17 +194,17 @@ func (t *integerType) Floor() NumberAccessor {
 
 func (t *integerType) Ln() (NumberAccessor, error) {
 	if t.value <= 0 {
-		return nil, fmt.Errorf("logarithmus cannot be applied to non-positive values %d", t.value)
+		return nil, NewCodedError(InvalidValueErrorCode, "logarithmus cannot be applied to non-positive values %d", t.value)
 	}
 	return NewDecimalFloat64(math.Log(t.Float64())), nil
 }
 
 func (t *integerType) Log(base NumberAccessor) (NumberAccessor, error) {
 	if t.value <= 0 {
-		return nil, fmt.Errorf("logarithmus cannot be applied to non-positive values %d", t.value)
+		return nil, NewCodedError(InvalidValueErrorCode, "logarithmus cannot be applied to non-positive values %d", t.value)
 	}
 	if !base.Positive() {
-		return nil, fmt.Errorf("logarithmus cannot be applied to non-positive base %f", base.Float64())
+		return nil, NewCodedError(InvalidValueErrorCode, "logarithmus cannot be applied to non-positive base %f", base.Float64())
 	}
 	return NewDecimalFloat64(math.Log(t.Float64()) / math.Log(base.Float64())), nil
 }
@@ -221,7 +221,7 @@ func (t *integerType) Power(exponent NumberAccessor) (NumberAccessor, bool) {
 
 func (t *integerType) Round(precision int32) (NumberAccessor, error) {
 	if precision < 0 {
-		return nil, fmt.Errorf("precision must not be negative %d", precision)
+		return nil, NewCodedError(InvalidValueErrorCode, "precision must not be negative %d", precision)
 	}
 	return t, nil
 }
@@ -244,7 +244,10 @@ func (t *integerType) Calc(operand DecimalValueAccessor, op ArithmeticOps) (Deci
 	}
 
 	if !t.ArithmeticOpSupported(op) || !operand.ArithmeticOpSupported(op) {
-		return nil, fmt.Errorf("arithmetic operator not supported: %c", op)
+		return nil, NewCodedError(NotSupportedErrorCode, "arithmetic operator not supported: %c", op)
+	}
+	if err := checkSpecialQuantityOperand(operand, op); err != nil {
+		return nil, err
 	}
 
 	if ov, ok := operand.(IntegerAccessor); ok {
@@ -350,6 +350,13 @@ func TestIntegerCalcQuantity(t *testing.T) {
 	assert.True(t, e.Equal(r))
 }
 
+func TestIntegerCalcQuantitySpecialUnit(t *testing.T) {
+	q := NewQuantity(NewDecimalInt(37), NewString("Cel"))
+	r, err := NewInteger(2).Calc(q, MultiplicationOp)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, r)
+}
+
 func TestIntegerCalcNotSupportedOp(t *testing.T) {
 	q := NewQuantity(NewDecimalFloat64(47.2), NewString("m"))
 	r, err := NewInteger(2).Calc(q, ModOp)
@@ -0,0 +1,184 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathsys
+
+import "fmt"
+
+const (
+	CollectionSizeLimitName = "collection size"
+	VisitedNodesLimitName   = "visited nodes"
+	DepthLimitName          = "depth"
+	StepsLimitName          = "steps"
+)
+
+// Limits contains the resource limits of an evaluation. A limit that is not
+// greater than zero is not checked.
+type Limits struct {
+	MaxCollectionSize int
+	MaxVisitedNodes   int
+	MaxDepth          int
+	MaxSteps          int
+}
+
+type LimitError struct {
+	name  string
+	limit int
+}
+
+// Limiter tracks the resource usage of a single evaluation. All methods can
+// be invoked on a nil limiter, which does not limit anything.
+type Limiter struct {
+	limits Limits
+	steps  int
+	depth  int
+}
+
+type LimiterAccessor interface {
+	Limiter() *Limiter
+}
+
+type LimiterBinder interface {
+	BindLimiter() ContextAccessor
+}
+
+// CollectionSizeLimitBinder is implemented by model adapters that create
+// collections on their own. The returned adapter limits the size of all
+// collections it creates.
+type CollectionSizeLimitBinder interface {
+	BindMaxCollectionSize(maxSize int) ModelAdapter
+}
+
+func NewLimitError(name string, limit int) *LimitError {
+	return &LimitError{name, limit}
+}
+
+func (e *LimitError) Name() string {
+	return e.name
+}
+
+func (e *LimitError) Limit() int {
+	return e.limit
+}
+
+func (e *LimitError) ErrorCode() ErrorCode {
+	return LimitExceededErrorCode
+}
+
+func (e *LimitError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("evaluation limit exceeded: maximum %s is %d", e.name, e.limit)
+}
+
+func NewLimiter(limits Limits) *Limiter {
+	return &Limiter{limits: limits}
+}
+
+func ContextLimiter(ctx ContextAccessor) *Limiter {
+	if c, ok := ctx.(LimiterAccessor); ok {
+		return c.Limiter()
+	}
+	return nil
+}
+
+// BindLimiter returns a context with a new limiter, so that the resource
+// usage of a new evaluation is tracked separately.
+func BindLimiter(ctx ContextAccessor) ContextAccessor {
+	if binder, ok := ctx.(LimiterBinder); ok {
+		return binder.BindLimiter()
+	}
+	return ctx
+}
+
+// BindMaxCollectionSize returns a model adapter that limits the size of the
+// collections it creates, if the specified adapter supports this.
+func BindMaxCollectionSize(adapter ModelAdapter, maxSize int) ModelAdapter {
+	if binder, ok := adapter.(CollectionSizeLimitBinder); ok && maxSize > 0 {
+		return binder.BindMaxCollectionSize(maxSize)
+	}
+	return adapter
+}
+
+func (l *Limiter) Limits() Limits {
+	if l == nil {
+		return Limits{}
+	}
+	return l.limits
+}
+
+func (l *Limiter) MaxCollectionSize() int {
+	if l == nil {
+		return 0
+	}
+	return l.limits.MaxCollectionSize
+}
+
+func (l *Limiter) Step() error {
+	if l == nil {
+		return nil
+	}
+
+	l.steps++
+	if l.limits.MaxSteps > 0 && l.steps > l.limits.MaxSteps {
+		return NewLimitError(StepsLimitName, l.limits.MaxSteps)
+	}
+	return nil
+}
+
+func (l *Limiter) Enter() error {
+	if l == nil {
+		return nil
+	}
+
+	l.depth++
+	if l.limits.MaxDepth > 0 && l.depth > l.limits.MaxDepth {
+		l.depth--
+		return NewLimitError(DepthLimitName, l.limits.MaxDepth)
+	}
+	return nil
+}
+
+func (l *Limiter) Leave() {
+	if l != nil {
+		l.depth--
+	}
+}
+
+func (l *Limiter) CheckVisitedNodes(count int) error {
+	if l == nil {
+		return nil
+	}
+
+	if l.limits.MaxVisitedNodes > 0 && count > l.limits.MaxVisitedNodes {
+		return NewLimitError(VisitedNodesLimitName, l.limits.MaxVisitedNodes)
+	}
+	return nil
+}
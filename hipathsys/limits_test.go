@@ -0,0 +1,156 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathsys
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLimitError(t *testing.T) {
+	err := NewLimitError(StepsLimitName, 10)
+	assert.Equal(t, StepsLimitName, err.Name())
+	assert.Equal(t, 10, err.Limit())
+	assert.Equal(t, "evaluation limit exceeded: maximum steps is 10", err.Error())
+}
+
+func TestLimiterNil(t *testing.T) {
+	var l *Limiter
+	assert.Equal(t, Limits{}, l.Limits())
+	assert.Equal(t, 0, l.MaxCollectionSize())
+	assert.NoError(t, l.Step(), "no error expected")
+	assert.NoError(t, l.Enter(), "no error expected")
+	l.Leave()
+	assert.NoError(t, l.CheckVisitedNodes(1000), "no error expected")
+}
+
+func TestLimiterUnlimited(t *testing.T) {
+	l := NewLimiter(Limits{})
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, l.Step(), "no error expected")
+		assert.NoError(t, l.Enter(), "no error expected")
+	}
+	assert.NoError(t, l.CheckVisitedNodes(1000), "no error expected")
+}
+
+func TestLimiterSteps(t *testing.T) {
+	l := NewLimiter(Limits{MaxSteps: 2})
+	assert.NoError(t, l.Step(), "no error expected")
+	assert.NoError(t, l.Step(), "no error expected")
+	err := l.Step()
+	if assert.IsType(t, (*LimitError)(nil), err) {
+		assert.Equal(t, StepsLimitName, err.(*LimitError).Name())
+	}
+}
+
+func TestLimiterDepth(t *testing.T) {
+	l := NewLimiter(Limits{MaxDepth: 2})
+	assert.NoError(t, l.Enter(), "no error expected")
+	assert.NoError(t, l.Enter(), "no error expected")
+	err := l.Enter()
+	if assert.IsType(t, (*LimitError)(nil), err) {
+		assert.Equal(t, DepthLimitName, err.(*LimitError).Name())
+	}
+	l.Leave()
+	assert.NoError(t, l.Enter(), "no error expected")
+}
+
+func TestLimiterVisitedNodes(t *testing.T) {
+	l := NewLimiter(Limits{MaxVisitedNodes: 5})
+	assert.NoError(t, l.CheckVisitedNodes(5), "no error expected")
+	err := l.CheckVisitedNodes(6)
+	if assert.IsType(t, (*LimitError)(nil), err) {
+		assert.Equal(t, VisitedNodesLimitName, err.(*LimitError).Name())
+		assert.Equal(t, 5, err.(*LimitError).Limit())
+	}
+}
+
+func TestContextLimiterNone(t *testing.T) {
+	ctx := newTestContext(t)
+	assert.Nil(t, ContextLimiter(ctx))
+	assert.Same(t, ctx, BindLimiter(ctx))
+}
+
+func TestBindLimiter(t *testing.T) {
+	limits := Limits{MaxSteps: 1, MaxCollectionSize: 2}
+	ctx := NewContext(newTestModel(t), WithLimits(limits))
+
+	limiter := ContextLimiter(ctx)
+	if assert.NotNil(t, limiter, "limiter expected") {
+		assert.Equal(t, limits, limiter.Limits())
+		assert.NoError(t, limiter.Step(), "no error expected")
+		assert.Error(t, limiter.Step(), "error expected")
+	}
+
+	bc := BindLimiter(ctx)
+	assert.NotSame(t, limiter, ContextLimiter(bc))
+	assert.NoError(t, ContextLimiter(bc).Step(), "no error expected")
+}
+
+func TestBindLimiterNoLimits(t *testing.T) {
+	ctx := NewContext(newTestModel(t))
+	assert.Nil(t, ContextLimiter(ctx))
+	assert.Same(t, ctx, BindLimiter(ctx))
+}
+
+func TestBindLimiterGoContext(t *testing.T) {
+	ctx := NewContext(newTestModel(t), WithLimits(Limits{MaxSteps: 1}))
+	wc := BindGoContext(newTestContext(t), context.Background())
+	assert.Nil(t, ContextLimiter(wc))
+	wc = &goContextWrapper{ctx, context.Background()}
+
+	bc := BindLimiter(wc)
+	assert.NotSame(t, ContextLimiter(wc), ContextLimiter(bc))
+	assert.NotNil(t, ContextLimiter(bc))
+	assert.Equal(t, context.Background(), GoContext(bc))
+}
+
+func TestContextCollectionMaxSize(t *testing.T) {
+	ctx := NewContext(newTestModel(t), WithLimits(Limits{MaxCollectionSize: 1}))
+	col := ctx.NewCollection()
+	assert.NoError(t, col.Add(NewString("test1")), "no error expected")
+	assert.Error(t, col.Add(NewString("test2")), "error expected")
+	assert.Equal(t, 1, col.Count())
+}
+
+func TestContextCollectionWithItemMaxSize(t *testing.T) {
+	ctx := NewContext(newTestModel(t), WithLimits(Limits{MaxCollectionSize: 1}))
+	col, err := ctx.NewCollectionWithItem(NewString("test1"))
+	assert.NoError(t, err, "no error expected")
+	if assert.NotNil(t, col, "collection expected") {
+		assert.Error(t, col.Add(NewString("test2")), "error expected")
+		assert.Equal(t, 1, col.Count())
+	}
+}
+
+func TestBindMaxCollectionSizeUnsupported(t *testing.T) {
+	adapter := newTestModel(t)
+	assert.Same(t, adapter, BindMaxCollectionSize(adapter, 1))
+}
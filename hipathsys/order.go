@@ -0,0 +1,124 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathsys
+
+// CompareOrder returns the sort order of the specified values. In contrast to
+// Comparator.Compare the order is also defined for temporal values with
+// different precisions, so that these values can be sorted deterministically.
+// Empty values (nil) are ordered before all other values. An error is returned
+// if the values cannot be ordered (e.g. quantities with incommensurable units).
+func CompareOrder(left interface{}, right interface{}) (int, error) {
+	if left == nil || right == nil {
+		if left != nil {
+			return 1, nil
+		}
+		if right != nil {
+			return -1, nil
+		}
+		return 0, nil
+	}
+
+	leftCmp, ok := left.(Comparator)
+	if !ok {
+		return 0, NewCodedError(TypeMismatchErrorCode, "value cannot be used for ordering: %T", left)
+	}
+	rightCmp, ok := right.(Comparator)
+	if !ok {
+		return 0, NewCodedError(TypeMismatchErrorCode, "value cannot be used for ordering: %T", right)
+	}
+
+	if res, status := leftCmp.Compare(rightCmp); status == Evaluated {
+		return res, nil
+	}
+
+	switch l := left.(type) {
+	case DateTemporalAccessor:
+		if r, ok := right.(DateTemporalAccessor); ok {
+			return compareDateTemporalOrder(l, r), nil
+		}
+	case TimeAccessor:
+		if r, ok := right.(TimeAccessor); ok {
+			return compareTimeOrder(l, r), nil
+		}
+	}
+	return 0, NewCodedError(TypeMismatchErrorCode, "values cannot be ordered: %T <> %T", left, right)
+}
+
+func compareDateTemporalOrder(t1 DateTemporalAccessor, t2 DateTemporalAccessor) int {
+	precision := lowerPrecision(t1, t2)
+	if v := compareDateTimeValue(t1.Year(), t2.Year()); v != 0 {
+		return v
+	}
+	if precision >= MonthDatePrecision {
+		if v := compareDateTimeValue(t1.Month(), t2.Month()); v != 0 {
+			return v
+		}
+	}
+	if precision >= DayDatePrecision {
+		if v := compareDateTimeValue(t1.Day(), t2.Day()); v != 0 {
+			return v
+		}
+	}
+	if precision > DayDatePrecision {
+		if v1, v2 := t1.Time(), t2.Time(); v1.Before(v2) {
+			return -1
+		} else if v1.After(v2) {
+			return 1
+		}
+	}
+	return compareDateTimeValue(int(t1.Precision()), int(t2.Precision()))
+}
+
+func compareTimeOrder(t1 TimeAccessor, t2 TimeAccessor) int {
+	precision := lowerPrecision(t1, t2)
+	if v := compareDateTimeValue(t1.Hour(), t2.Hour()); v != 0 {
+		return v
+	}
+	if precision >= MinuteTimePrecision {
+		if v := compareDateTimeValue(t1.Minute(), t2.Minute()); v != 0 {
+			return v
+		}
+	}
+	if precision >= SecondTimePrecision {
+		if v := compareDateTimeValue(t1.Second(), t2.Second()); v != 0 {
+			return v
+		}
+		if v := compareDateTimeValue(t1.Nanosecond(), t2.Nanosecond()); v != 0 {
+			return v
+		}
+	}
+	return compareDateTimeValue(int(t1.Precision()), int(t2.Precision()))
+}
+
+func lowerPrecision(t1 TemporalAccessor, t2 TemporalAccessor) DateTimePrecisions {
+	if t1.Precision() < t2.Precision() {
+		return t1.Precision()
+	}
+	return t2.Precision()
+}
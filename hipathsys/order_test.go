@@ -0,0 +1,171 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathsys
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCompareOrderEmpty(t *testing.T) {
+	res, err := CompareOrder(nil, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, 0, res)
+	res, err = CompareOrder(nil, NewInteger(1))
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, -1, res)
+	res, err = CompareOrder(NewInteger(1), nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, 1, res)
+}
+
+func TestCompareOrderEvaluated(t *testing.T) {
+	res, err := CompareOrder(NewInteger(2), NewDecimalFloat64(1.5))
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, 1, res)
+	res, err = CompareOrder(NewString("a"), NewString("b"))
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, -1, res)
+}
+
+func TestCompareOrderNoComparator(t *testing.T) {
+	res, err := CompareOrder(newAccessorMock(), NewInteger(1))
+	assert.Error(t, err, "error expected")
+	assert.Equal(t, TypeMismatchErrorCode, ErrorCodeOf(err))
+	assert.Equal(t, 0, res)
+	res, err = CompareOrder(NewInteger(1), newAccessorMock())
+	assert.Error(t, err, "error expected")
+	assert.Equal(t, 0, res)
+}
+
+func TestCompareOrderInconvertible(t *testing.T) {
+	res, err := CompareOrder(NewString("1"), NewInteger(1))
+	assert.Error(t, err, "error expected")
+	assert.Equal(t, TypeMismatchErrorCode, ErrorCodeOf(err))
+	assert.Equal(t, 0, res)
+}
+
+func TestCompareOrderDatePrecision(t *testing.T) {
+	d1, _ := ParseDate("2020-03")
+	d2, _ := ParseDate("2020-03-01")
+	d3, _ := ParseDate("2020-02-28")
+
+	res, err := CompareOrder(d1, d2)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, -1, res)
+	res, err = CompareOrder(d2, d1)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, 1, res)
+	res, err = CompareOrder(d3, d1)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, -1, res)
+}
+
+func TestCompareOrderDateDateTime(t *testing.T) {
+	d, _ := ParseDate("2020-03-01")
+	dt1, _ := ParseDateTime("2020-03-01T10:00:00Z")
+	dt2, _ := ParseDateTime("2020-02-29T10:00:00Z")
+
+	res, err := CompareOrder(d, dt1)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, -1, res)
+	res, err = CompareOrder(dt1, d)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, 1, res)
+	res, err = CompareOrder(d, dt2)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, 1, res)
+}
+
+func TestCompareOrderDateTimePrecision(t *testing.T) {
+	dt1, _ := ParseDateTime("2020-03-01T10:00Z")
+	dt2, _ := ParseDateTime("2020-03-01T10:00:30Z")
+	dt3, _ := ParseDateTime("2020-03-01T09:59:30Z")
+
+	res, err := CompareOrder(dt1, dt2)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, -1, res)
+	res, err = CompareOrder(dt1, dt3)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, 1, res)
+}
+
+func TestCompareOrderTimePrecision(t *testing.T) {
+	t1 := NewTimeHMSNWithPrecision(10, 0, 0, 0, HourTimePrecision)
+	t2 := NewTimeHMSNWithPrecision(10, 0, 0, 0, MinuteTimePrecision)
+	t3 := NewTimeHMSNWithPrecision(9, 59, 0, 0, MinuteTimePrecision)
+	t4 := NewTimeHMSNWithPrecision(10, 0, 1, 0, SecondTimePrecision)
+
+	res, err := CompareOrder(t1, t2)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, -1, res)
+	res, err = CompareOrder(t2, t1)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, 1, res)
+	res, err = CompareOrder(t1, t3)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, 1, res)
+	res, err = CompareOrder(t4, t2)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, 1, res)
+}
+
+func TestCompareOrderQuantityCommensurable(t *testing.T) {
+	res, err := CompareOrder(NewQuantity(NewDecimalInt(1), NewString("m")),
+		NewQuantity(NewDecimalInt(50), NewString("cm")))
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, 1, res)
+}
+
+func TestCompareOrderQuantityIncommensurable(t *testing.T) {
+	res, err := CompareOrder(NewQuantity(NewDecimalInt(1), NewString("m")),
+		NewQuantity(NewDecimalInt(1), NewString("g")))
+	if assert.Error(t, err, "error expected") {
+		assert.Equal(t, TypeMismatchErrorCode, ErrorCodeOf(err))
+	}
+	assert.Equal(t, 0, res)
+}
+
+func TestCompareOrderQuantityUnknownUnit(t *testing.T) {
+	res, err := CompareOrder(NewQuantity(NewDecimalInt(1), NewString("g")),
+		NewQuantity(NewDecimalInt(2), NewString("[xyz")))
+	if assert.Error(t, err, "error expected") {
+		assert.Equal(t, TypeMismatchErrorCode, ErrorCodeOf(err))
+	}
+	assert.Equal(t, 0, res)
+}
+
+func TestCompareOrderQuantityWithoutUnit(t *testing.T) {
+	res, err := CompareOrder(NewQuantity(NewDecimalInt(5), nil),
+		NewQuantity(NewDecimalInt(1), NewString("g")))
+	if assert.Error(t, err, "error expected") {
+		assert.Equal(t, TypeMismatchErrorCode, ErrorCodeOf(err))
+	}
+	assert.Equal(t, 0, res)
+}
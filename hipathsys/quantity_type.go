@@ -28,10 +28,7 @@
 
 package hipathsys
 
-import (
-	"fmt"
-	"strings"
-)
+import "strings"
 
 var UCUMSystemURI = NewString("http://unitsofmeasure.org")
 
@@ -127,12 +124,18 @@ func quantityValueEqual(t QuantityAccessor, node interface{}, equivalent bool) b
 
 		u1, exp1 := QuantityUnitWithNameString(t.Unit())
 		u2, exp2 := QuantityUnitWithNameString(q.Unit())
-		if exp1 != exp2 {
-			return false
+		if exp1 == exp2 {
+			v1, v2, u := ConvertUnitToBase(t.Value(), u1, exp1, q.Value(), u2, exp2, !equivalent)
+			if u != nil {
+				return Equal(v1, v2)
+			}
 		}
-		v1, v2, u := ConvertUnitToBase(t.Value(), u1, exp1, q.Value(), u2, exp2, !equivalent)
-		if u != nil {
-			return Equal(v1, v2)
+
+		if v := convertUCUMQuantity(q, t.Unit()); v != nil {
+			if equivalent {
+				return Equivalent(t.Value(), v)
+			}
+			return Equal(t.Value(), v)
 		}
 	} else if d, ok := node.(DecimalValueAccessor); ok {
 		v1 := t.Value()
@@ -157,6 +160,9 @@ func (t *quantityType) Compare(comparator Comparator) (int, OperatorStatus) {
 				}
 			}
 
+			if v := convertUCUMQuantity(q, t.Unit()); v != nil {
+				return decimalValueCompare(t.Value(), v)
+			}
 			return -1, Empty
 		}
 	}
@@ -171,16 +177,22 @@ func (t *quantityType) ToUnit(unit StringAccessor) QuantityAccessor {
 	}
 
 	u1, exp1 := QuantityUnitWithNameString(t.Unit())
-	if u1 == nil || exp1 != exp2 {
+	if u1 == nil {
 		return nil
 	}
 
-	if u1.Equal(u2) {
+	if exp1 == exp2 && u1.Equal(u2) {
 		return t
 	}
 
-	u := u1.CommonBase(u2, true)
+	var u QuantityUnitAccessor
+	if exp1 == exp2 {
+		u = u1.CommonBase(u2, true)
+	}
 	if u == nil {
+		if v := convertUCUMQuantity(t, unit); v != nil {
+			return NewQuantity(v, unit)
+		}
 		return nil
 	}
 
@@ -192,6 +204,18 @@ func (t *quantityType) ToUnit(unit StringAccessor) QuantityAccessor {
 	return NewQuantity(val, u2.NameWithExp(val, exp2))
 }
 
+func convertUCUMQuantity(q QuantityAccessor, unit StringAccessor) DecimalAccessor {
+	if q.Unit() == nil || unit == nil {
+		return nil
+	}
+
+	v, err := ConvertUCUMUnit(q.Value(), q.Unit().String(), unit.String())
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
 func (t *quantityType) String() string {
 	var b strings.Builder
 	b.Grow(32)
@@ -211,7 +235,19 @@ func (t *quantityType) Calc(operand DecimalValueAccessor, op ArithmeticOps) (Dec
 	}
 
 	if !t.ArithmeticOpSupported(op) || !operand.ArithmeticOpSupported(op) {
-		return nil, fmt.Errorf("arithmetic operator not supported: %c", op)
+		return nil, NewCodedError(NotSupportedErrorCode, "arithmetic operator not supported: %c", op)
+	}
+
+	if err := checkSpecialQuantityUnits(t, operand, op); err != nil {
+		return nil, err
+	}
+	if q, ok := operand.(QuantityAccessor); ok && (op == MultiplicationOp || op == DivisionOp) {
+		if res, ok := ucumQuantityCalc(t, q, op); ok {
+			if res == nil {
+				return nil, nil
+			}
+			return res, nil
+		}
 	}
 
 	var valLeft, varRight DecimalAccessor
@@ -232,6 +268,52 @@ func (t *quantityType) Calc(operand DecimalValueAccessor, op ArithmeticOps) (Dec
 	return NewQuantity(value.Value(), unit.NameWithExp(value.Value(), exp)), nil
 }
 
+func checkSpecialQuantityUnits(l QuantityAccessor, operand DecimalValueAccessor, op ArithmeticOps) error {
+	u1, u2 := ucumUnitName(l.Unit()), "1"
+	if r, ok := operand.(QuantityAccessor); ok {
+		u2 = ucumUnitName(r.Unit())
+	}
+	if !IsUCUMSpecialUnit(u1) && !IsUCUMSpecialUnit(u2) {
+		return nil
+	}
+	if u1 == u2 && (op == SubtractionOp || (op == AdditionOp && !isUCUMAbsoluteUnit(u1))) {
+		return nil
+	}
+	return NewCodedError(NotSupportedErrorCode, "arithmetic operator %c is not defined for quantities with special units: %s, %s", op, u1, u2)
+}
+
+// checkSpecialQuantityOperand checks the quantity operand of an arithmetic
+// operation with a number on the left side.
+func checkSpecialQuantityOperand(operand DecimalValueAccessor, op ArithmeticOps) error {
+	if q, ok := operand.(QuantityAccessor); ok {
+		return checkSpecialQuantityUnits(q, nil, op)
+	}
+	return nil
+}
+
+func ucumQuantityCalc(l QuantityAccessor, r QuantityAccessor, op ArithmeticOps) (QuantityAccessor, bool) {
+	if l.Unit() == nil && r.Unit() == nil {
+		return nil, false
+	}
+
+	value, unit, err := ucumCalc(l.Value().Decimal(), ucumUnitName(l.Unit()),
+		r.Value().Decimal(), ucumUnitName(r.Unit()), op)
+	if err != nil {
+		return nil, false
+	}
+	if value == nil {
+		return nil, true
+	}
+	return NewQuantity(NewDecimal(*value), NewString(unit)), true
+}
+
+func ucumUnitName(unit StringAccessor) string {
+	if unit == nil || len(unit.String()) == 0 {
+		return "1"
+	}
+	return unit.String()
+}
+
 func mergeQuantityUnits(l QuantityAccessor, r QuantityAccessor, op ArithmeticOps) (DecimalAccessor, DecimalAccessor, QuantityUnitAccessor, int, error) {
 	leftVal, rightVal := l.Value(), r.Value()
 	leftUnit, leftExp := QuantityUnitWithNameString(l.Unit())
@@ -247,7 +329,7 @@ func mergeQuantityUnits(l QuantityAccessor, r QuantityAccessor, op ArithmeticOps
 		leftVal, rightVal, unit = ConvertUnitToMostGranular(
 			leftVal, leftUnit, leftExp, rightVal, rightUnit, rightExp, true)
 		if unit == nil {
-			return nil, nil, nil, 1, fmt.Errorf("units are not equal: %s != %s",
+			return nil, nil, nil, 1, NewCodedError(TypeMismatchErrorCode, "units are not equal: %s != %s",
 				leftUnit, rightUnit)
 		}
 	}
@@ -256,7 +338,7 @@ func mergeQuantityUnits(l QuantityAccessor, r QuantityAccessor, op ArithmeticOps
 	switch op {
 	case AdditionOp, SubtractionOp:
 		if leftExp != rightExp {
-			return nil, nil, nil, 1, fmt.Errorf("units exponents are not equal: %d != %d",
+			return nil, nil, nil, 1, NewCodedError(TypeMismatchErrorCode, "units exponents are not equal: %d != %d",
 				leftExp, rightExp)
 		}
 	case MultiplicationOp:
@@ -266,7 +348,7 @@ func mergeQuantityUnits(l QuantityAccessor, r QuantityAccessor, op ArithmeticOps
 	}
 
 	if exp < 1 || exp > 3 {
-		return nil, nil, nil, 1, fmt.Errorf("resulting unit exponent is invalid (must be between 1 and 3): %d", exp)
+		return nil, nil, nil, 1, NewCodedError(InvalidValueErrorCode, "resulting unit exponent is invalid (must be between 1 and 3): %d", exp)
 	}
 
 	return leftVal, rightVal, unit, exp, nil
@@ -153,6 +153,41 @@ func TestQuantityEqualUnitFactorEquivalent(t *testing.T) {
 	assert.Equal(t, true, q1.Equivalent(q2))
 }
 
+func TestQuantityEqualUCUM(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(4), NewString("g"))
+	q2 := NewQuantity(NewDecimalInt(4000), NewString("mg"))
+	assert.Equal(t, true, q1.Equal(q2))
+	assert.Equal(t, true, q1.Equivalent(q2))
+}
+
+func TestQuantityEqualUCUMComposite(t *testing.T) {
+	q1 := NewQuantity(NewDecimalFloat64(5.5), NewString("mmol/L"))
+	q2 := NewQuantity(NewDecimalFloat64(5500), NewString("umol/L"))
+	assert.Equal(t, true, q1.Equal(q2))
+	assert.Equal(t, true, q1.Equivalent(q2))
+}
+
+func TestQuantityEqualUCUMNot(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(4), NewString("g"))
+	q2 := NewQuantity(NewDecimalInt(4), NewString("mg"))
+	assert.Equal(t, false, q1.Equal(q2))
+	assert.Equal(t, false, q1.Equivalent(q2))
+}
+
+func TestQuantityEqualUCUMNotCommensurable(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(4), NewString("g"))
+	q2 := NewQuantity(NewDecimalInt(4), NewString("m"))
+	assert.Equal(t, false, q1.Equal(q2))
+	assert.Equal(t, false, q1.Equivalent(q2))
+}
+
+func TestQuantityEquivalentUCUMPrecision(t *testing.T) {
+	q1 := NewQuantity(NewDecimalFloat64(1.6), NewString("km"))
+	q2 := NewQuantity(NewDecimalInt(1), NewString("[mi_i]"))
+	assert.Equal(t, false, q1.Equal(q2))
+	assert.Equal(t, true, q1.Equivalent(q2))
+}
+
 func TestQuantityEqualInteger(t *testing.T) {
 	q1 := NewQuantity(NewDecimalFloat64(47), NewString("g"))
 	assert.Equal(t, true, q1.Equal(NewInteger(47)))
@@ -345,6 +380,115 @@ func TestQuantityCalcDivision(t *testing.T) {
 	assert.True(t, e.Equal(r))
 }
 
+func assertQuantity(t *testing.T, value string, unit string, actual interface{}) {
+	if assert.Implements(t, (*QuantityAccessor)(nil), actual) {
+		q := actual.(QuantityAccessor)
+		assert.Equal(t, value, q.Value().String())
+		if assert.NotNil(t, q.Unit()) {
+			assert.Equal(t, unit, q.Unit().String())
+		}
+	}
+}
+
+func TestQuantityCalcMultiplicationUCUM(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(2), NewString("m"))
+	q2 := NewQuantity(NewDecimalInt(3), NewString("m"))
+	r, err := q1.Calc(q2, MultiplicationOp)
+	assert.NoError(t, err, "no error expected")
+	assertQuantity(t, "6", "m2", r)
+}
+
+func TestQuantityCalcMultiplicationUCUMHighExp(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(2), NewString("m2"))
+	q2 := NewQuantity(NewDecimalInt(3), NewString("m3"))
+	r, err := q1.Calc(q2, MultiplicationOp)
+	assert.NoError(t, err, "no error expected")
+	assertQuantity(t, "6", "m5", r)
+}
+
+func TestQuantityCalcMultiplicationUCUMConvert(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(2), NewString("cm"))
+	q2 := NewQuantity(NewDecimalInt(2), NewString("m"))
+	r, err := q1.Calc(q2, MultiplicationOp)
+	assert.NoError(t, err, "no error expected")
+	assertQuantity(t, "400", "cm2", r)
+	assert.True(t, NewQuantity(NewDecimalFloat64(0.04), NewString("m2")).Equal(r))
+}
+
+func TestQuantityCalcMultiplicationUCUMCancel(t *testing.T) {
+	q1 := NewQuantity(NewDecimalFloat64(0.5), NewString("mg/kg"))
+	q2 := NewQuantity(NewDecimalInt(70), NewString("kg"))
+	r, err := q1.Calc(q2, MultiplicationOp)
+	assert.NoError(t, err, "no error expected")
+	assertQuantity(t, "35", "mg", r)
+}
+
+func TestQuantityCalcMultiplicationUCUMUnitless(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(2), nil)
+	q2 := NewQuantity(NewDecimalInt(3), NewString("mL"))
+	r, err := q1.Calc(q2, MultiplicationOp)
+	assert.NoError(t, err, "no error expected")
+	assertQuantity(t, "6", "mL", r)
+}
+
+func TestQuantityCalcDivisionUCUM(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(10), NewString("mg"))
+	q2 := NewQuantity(NewDecimalInt(2), NewString("mL"))
+	r, err := q1.Calc(q2, DivisionOp)
+	assert.NoError(t, err, "no error expected")
+	assertQuantity(t, "5", "mg/mL", r)
+}
+
+func TestQuantityCalcDivisionUCUMBMI(t *testing.T) {
+	height := NewQuantity(NewDecimalInt(180), NewString("cm"))
+	heightM := height.ToUnit(NewString("m"))
+	area, err := heightM.Calc(heightM, MultiplicationOp)
+	assert.NoError(t, err, "no error expected")
+	r, err := NewQuantity(NewDecimalFloat64(81), NewString("kg")).Calc(area, DivisionOp)
+	assert.NoError(t, err, "no error expected")
+	assertQuantity(t, "25", "kg/m2", r)
+}
+
+func TestQuantityCalcDivisionUCUMSameUnit(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(10), NewString("mg"))
+	q2 := NewQuantity(NewDecimalInt(4), NewString("g"))
+	r, err := q1.Calc(q2, DivisionOp)
+	assert.NoError(t, err, "no error expected")
+	assertQuantity(t, "0.0025", "1", r)
+}
+
+func TestQuantityCalcDivisionUCUMReciprocal(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(10), NewString("1"))
+	q2 := NewQuantity(NewDecimalInt(2), NewString("min"))
+	r, err := q1.Calc(q2, DivisionOp)
+	assert.NoError(t, err, "no error expected")
+	assertQuantity(t, "5", "/min", r)
+}
+
+func TestQuantityCalcDivisionUCUMAnnotation(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(10), NewString("mg{creat}"))
+	q2 := NewQuantity(NewDecimalInt(2), NewString("dL"))
+	r, err := q1.Calc(q2, DivisionOp)
+	assert.NoError(t, err, "no error expected")
+	assertQuantity(t, "5", "mg{creat}/dL", r)
+}
+
+func TestQuantityCalcDivisionUCUMZero(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(10), NewString("mg"))
+	q2 := NewQuantity(NewDecimalInt(0), NewString("mL"))
+	r, err := q1.Calc(q2, DivisionOp)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, r, "no res expected")
+}
+
+func TestQuantityCalcDivisionCalendarUnits(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(10), NewString("days2"))
+	q2 := NewQuantity(NewDecimalInt(2), NewString("days"))
+	r, err := q1.Calc(q2, DivisionOp)
+	assert.NoError(t, err, "no error expected")
+	assert.True(t, NewQuantity(NewDecimalInt(5), NewString("days")).Equal(r))
+}
+
 func TestQuantityCalcNotSupportedOp(t *testing.T) {
 	q1 := NewQuantity(NewDecimalFloat64(48.75), NewString("m3"))
 	q2 := NewQuantity(NewDecimalFloat64(2.5), NewString("m"))
@@ -398,11 +542,46 @@ func TestQuantityCompareEqualNotOneUnitNil(t *testing.T) {
 
 func TestQuantityCompareEqualNotUnit(t *testing.T) {
 	res, status := NewQuantity(NewDecimalFloat64(10.21), NewString("cm")).
-		Compare(NewQuantity(NewDecimalFloat64(10.21), NewString("m")))
+		Compare(NewQuantity(NewDecimalFloat64(10.21), NewString("g")))
+	assert.Equal(t, Empty, status)
+	assert.Equal(t, -1, res)
+}
+
+func TestQuantityCompareUCUM(t *testing.T) {
+	res, status := NewQuantity(NewDecimalInt(1), NewString("[in_i]")).
+		Compare(NewQuantity(NewDecimalInt(2), NewString("cm")))
+	assert.Equal(t, Evaluated, status)
+	assert.Equal(t, 1, res)
+}
+
+func TestQuantityCompareUCUMEqual(t *testing.T) {
+	res, status := NewQuantity(NewDecimalFloat64(10.21), NewString("cm")).
+		Compare(NewQuantity(NewDecimalFloat64(0.1021), NewString("m")))
+	assert.Equal(t, Evaluated, status)
+	assert.Equal(t, 0, res)
+}
+
+func TestQuantityCompareUCUMInvalid(t *testing.T) {
+	res, status := NewQuantity(NewDecimalInt(1), NewString("cm")).
+		Compare(NewQuantity(NewDecimalInt(2), NewString("x[y")))
 	assert.Equal(t, Empty, status)
 	assert.Equal(t, -1, res)
 }
 
+func TestQuantityCompareSpecialUnit(t *testing.T) {
+	res, status := NewQuantity(NewDecimalInt(37), NewString("Cel")).
+		Compare(NewQuantity(NewDecimalInt(98), NewString("[degF]")))
+	assert.Equal(t, Evaluated, status)
+	assert.Equal(t, 1, res)
+}
+
+func TestQuantityEqualSpecialUnit(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(37), NewString("Cel"))
+	q2 := NewQuantity(NewDecimalFloat64(98.6), NewString("[degF]"))
+	assert.Equal(t, true, q1.Equal(q2))
+	assert.Equal(t, true, q1.Equivalent(q2))
+}
+
 func TestQuantityCompareEqualTypeDiffers(t *testing.T) {
 	res, status := NewQuantity(NewDecimalFloat64(10.21), NewString("cm")).
 		Compare(NewString("test1"))
@@ -524,6 +703,96 @@ func TestQuantityToUnitExp(t *testing.T) {
 	}
 }
 
+func TestQuantityToUnitUCUM(t *testing.T) {
+	q := NewQuantity(NewDecimalFloat64(1.5), NewString("kg.m-2"))
+	q = q.ToUnit(NewString("g/cm2"))
+	if assert.NotNil(t, q) {
+		if assert.NotNil(t, q.Value()) {
+			assert.Equal(t, "0.15", q.Value().String())
+		}
+		if assert.NotNil(t, q.Unit()) {
+			assert.Equal(t, "g/cm2", q.Unit().String())
+		}
+	}
+}
+
+func TestQuantityToUnitUCUMNotCommensurable(t *testing.T) {
+	q := NewQuantity(NewDecimalFloat64(1.5), NewString("kg"))
+	assert.Nil(t, q.ToUnit(NewString("L")))
+}
+
+func TestQuantityToUnitSpecialUnit(t *testing.T) {
+	q := NewQuantity(NewDecimalInt(37), NewString("Cel"))
+	assertQuantity(t, "98.6", "[degF]", q.ToUnit(NewString("[degF]")))
+}
+
+func TestQuantityCalcSpecialUnitAddition(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(37), NewString("Cel"))
+	q2 := NewQuantity(NewDecimalInt(1), NewString("Cel"))
+	r, err := q1.Calc(q2, AdditionOp)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, r, "no res expected")
+}
+
+func TestQuantityCalcSpecialUnitAdditionPrefix(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(37), NewString("mCel"))
+	q2 := NewQuantity(NewDecimalInt(1), NewString("mCel"))
+	r, err := q1.Calc(q2, AdditionOp)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, r, "no res expected")
+}
+
+func TestQuantityCalcSpecialUnitAdditionLogarithmic(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(20), NewString("dB"))
+	q2 := NewQuantity(NewDecimalInt(3), NewString("dB"))
+	r, err := q1.Calc(q2, AdditionOp)
+	assert.NoError(t, err, "no error expected")
+	assertQuantity(t, "23", "dB", r)
+}
+
+func TestQuantityCalcSpecialUnitSubtraction(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(37), NewString("Cel"))
+	q2 := NewQuantity(NewDecimalInt(1), NewString("Cel"))
+	r, err := q1.Calc(q2, SubtractionOp)
+	assert.NoError(t, err, "no error expected")
+	assertQuantity(t, "36", "Cel", r)
+}
+
+func TestQuantityCalcSpecialUnitAdditionDiffers(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(37), NewString("Cel"))
+	q2 := NewQuantity(NewDecimalInt(1), NewString("[degF]"))
+	r, err := q1.Calc(q2, AdditionOp)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, r, "no res expected")
+}
+
+func TestQuantityCalcSpecialUnitMultiplication(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(37), NewString("Cel"))
+	q2 := NewQuantity(NewDecimalInt(2), NewString("Cel"))
+	r, err := q1.Calc(q2, MultiplicationOp)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, r, "no res expected")
+}
+
+func TestQuantityCalcSpecialUnitScalar(t *testing.T) {
+	q := NewQuantity(NewDecimalInt(2), NewString("Cel"))
+	for _, op := range []ArithmeticOps{MultiplicationOp, DivisionOp, AdditionOp} {
+		r, err := q.Calc(NewDecimalInt(2), op)
+		if assert.Error(t, err, "error expected") {
+			assert.Equal(t, NotSupportedErrorCode, ErrorCodeOf(err))
+		}
+		assert.Nil(t, r, "no res expected")
+	}
+}
+
+func TestQuantityCalcSpecialUnitDivision(t *testing.T) {
+	q1 := NewQuantity(NewDecimalInt(20), NewString("dB"))
+	q2 := NewQuantity(NewDecimalInt(2), NewString("s"))
+	r, err := q1.Calc(q2, DivisionOp)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, r, "no res expected")
+}
+
 func TestQuantityAbsPos(t *testing.T) {
 	res := NewQuantity(NewDecimalFloat64(2.1), NewString("mg")).Abs()
 	if assert.Implements(t, (*QuantityAccessor)(nil), res) {
@@ -152,7 +152,7 @@ func addQuantityDateTimeDuration(t time.Time, precision DateTimePrecisions,
 
 	year := t.Year()
 	if year < 0 || year > 9999 {
-		return time.Time{}, fmt.Errorf("date/time arithmetic results in invalid year: %d", year)
+		return time.Time{}, NewCodedError(InvalidValueErrorCode, "date/time arithmetic results in invalid year: %d", year)
 	}
 
 	return t, nil
@@ -184,12 +184,12 @@ func quantityValueNanos(value NumberAccessor, precision DateTimePrecisions) Numb
 func quantityDateTimePrecision(q QuantityAccessor) (NumberAccessor, DateTimePrecisions, error) {
 	value, unit := q.Value(), q.Unit()
 	if unit == nil {
-		return nil, NanoTimePrecision, fmt.Errorf("quantity has no date/time unit")
+		return nil, NanoTimePrecision, NewCodedError(InvalidValueErrorCode, "quantity has no date/time unit")
 	}
 
 	qu := QuantityUnitByNameString(unit)
 	if qu == nil {
-		return nil, NanoTimePrecision, fmt.Errorf(
+		return nil, NanoTimePrecision, NewCodedError(InvalidValueErrorCode,
 			"quantity has no valid date/time unit: %s", unit.String())
 	}
 
@@ -215,7 +215,7 @@ func quantityDateTimePrecision(q QuantityAccessor) (NumberAccessor, DateTimePrec
 	case NanosecondQuantityUnit:
 		return value, NanoTimePrecision, nil
 	default:
-		return nil, NanoTimePrecision, fmt.Errorf(
+		return nil, NanoTimePrecision, NewCodedError(InvalidValueErrorCode,
 			"quantity has no valid date/time unit: %s", unit.String())
 	}
 }
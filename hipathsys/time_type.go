@@ -102,7 +102,7 @@ func ParseTime(value string) (TimeAccessor, error) {
 func ParseTimeWithSource(value string, source interface{}) (TimeAccessor, error) {
 	parts := timeRegexp.FindStringSubmatch(value)
 	if parts == nil {
-		return nil, fmt.Errorf("not a valid fluent time string: %s", value)
+		return nil, NewCodedError(InvalidValueErrorCode, "not a valid fluent time string: %s", value)
 	}
 	return newTimeFromParts(parts, source), nil
 }
@@ -250,7 +250,7 @@ func (t *timeType) Add(quantity QuantityAccessor) (TemporalAccessor, error) {
 	}
 
 	if precision < HourTimePrecision {
-		return nil, fmt.Errorf("quantity precision not allowd for time type: %s", quantity.String())
+		return nil, NewCodedError(InvalidValueErrorCode, "quantity precision not allowd for time type: %s", quantity.String())
 	}
 
 	d := time.Date(1, 1, 1, t.hour, t.minute, t.second, t.nanosecond, time.UTC)
@@ -259,7 +259,7 @@ func (t *timeType) Add(quantity QuantityAccessor) (TemporalAccessor, error) {
 		return nil, err
 	}
 	if res.Day() != 1 || res.Month() != 1 || res.Year() != 1 {
-		return nil, fmt.Errorf("time arithmetic results in an invalid hour")
+		return nil, NewCodedError(InvalidValueErrorCode, "time arithmetic results in an invalid hour")
 	}
 
 	return NewTimeHMSNWithPrecision(res.Hour(), res.Minute(), res.Second(), res.Nanosecond(), t.precision), nil
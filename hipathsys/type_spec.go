@@ -28,10 +28,7 @@
 
 package hipathsys
 
-import (
-	"fmt"
-	"strings"
-)
+import "strings"
 
 const NamespaceName = "System"
 
@@ -70,7 +67,7 @@ type TypeSpecAccessor interface {
 
 func ParseFQTypeName(fqName string) (FQTypeNameAccessor, error) {
 	if len(fqName) == 0 {
-		return nil, fmt.Errorf("type name must not be empty")
+		return nil, NewCodedError(SyntaxErrorCode, "type name must not be empty")
 	}
 
 	fi := strings.IndexRune(fqName, '.')
@@ -78,13 +75,13 @@ func ParseFQTypeName(fqName string) (FQTypeNameAccessor, error) {
 		return NewTypeName(fqName), nil
 	}
 	if fi == 0 || fi+1 == len(fqName) {
-		return nil, fmt.Errorf("invalid type name: %s", fqName)
+		return nil, NewCodedError(SyntaxErrorCode, "invalid type name: %s", fqName)
 	}
 
 	name := fqName[fi+1:]
 	li := strings.IndexRune(name, '.')
 	if li >= 0 {
-		return nil, fmt.Errorf("invalid type name: %s", fqName)
+		return nil, NewCodedError(SyntaxErrorCode, "invalid type name: %s", fqName)
 	}
 
 	return NewFQTypeName(name, fqName[:fi]), nil
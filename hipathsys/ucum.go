@@ -0,0 +1,531 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathsys
+
+import (
+	"fmt"
+	"github.com/shopspring/decimal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type ucumPrefix struct {
+	code  string
+	value string
+}
+
+type ucumUnit struct {
+	code      string
+	metric    bool
+	special   bool
+	arbitrary bool
+	value     string
+	unit      string
+}
+
+type ucumTerm struct {
+	code       string
+	annotation string
+	exp        int
+	factor     bool
+}
+
+type ucumCanonical struct {
+	factor     decimal.Decimal
+	dimensions map[string]int
+	terms      []ucumTerm
+}
+
+type ucumParser struct {
+	unit string
+	pos  int
+}
+
+var ucumUnitsByCode = toUCUMUnitsByCode(ucumUnits)
+var ucumPrefixesByLength = sortUCUMPrefixesByLength(ucumPrefixes)
+
+var ucumCanonicalLock sync.RWMutex
+var ucumCanonicalCache = make(map[string]*ucumCanonical)
+
+var ucumUnity = &ucumCanonical{factor: decimal.New(1, 0), dimensions: map[string]int{}}
+
+func toUCUMUnitsByCode(units []ucumUnit) map[string]*ucumUnit {
+	m := make(map[string]*ucumUnit, len(units)+len(ucumBaseUnits))
+	for _, code := range ucumBaseUnits {
+		m[code] = &ucumUnit{code: code, metric: true}
+	}
+	for i := range units {
+		m[units[i].code] = &units[i]
+	}
+	return m
+}
+
+func sortUCUMPrefixesByLength(prefixes []ucumPrefix) []ucumPrefix {
+	res := make([]ucumPrefix, len(prefixes))
+	copy(res, prefixes)
+	sort.SliceStable(res, func(i, j int) bool {
+		return len(res[i].code) > len(res[j].code)
+	})
+	return res
+}
+
+// ConvertUCUMUnit converts the specified value from the UCUM unit from to
+// the UCUM unit to. An error is returned if one of the units cannot be parsed
+// or if the units are not commensurable.
+func ConvertUCUMUnit(value DecimalAccessor, from string, to string) (DecimalAccessor, error) {
+	v, fromRef, err := ucumSpecialToReference(value.Decimal(), from)
+	if err != nil {
+		return nil, err
+	}
+	toRef := to
+	if u, _ := ucumSpecialUnit(to); u != nil {
+		toRef = u.unit
+	}
+
+	c1, err := ucumCanonicalUnit(fromRef)
+	if err != nil {
+		return nil, err
+	}
+	c2, err := ucumCanonicalUnit(toRef)
+	if err != nil {
+		return nil, err
+	}
+	if !c1.commensurable(c2) {
+		return nil, NewCodedError(TypeMismatchErrorCode, "units are not commensurable: %s, %s", from, to)
+	}
+
+	v, err = ucumSpecialFromReference(v.Mul(c1.factor).Div(c2.factor), to)
+	if err != nil {
+		return nil, err
+	}
+	return NewDecimal(normalizedDecimal(v)), nil
+}
+
+// UCUMUnitsCommensurable returns if both UCUM units are valid and can be
+// converted into each other.
+func UCUMUnitsCommensurable(unit1 string, unit2 string) bool {
+	c1, err := ucumCanonicalUnit(unit1)
+	if err != nil {
+		return false
+	}
+	c2, err := ucumCanonicalUnit(unit2)
+	return err == nil && c1.commensurable(c2)
+}
+
+func ucumCalc(value1 decimal.Decimal, unit1 string, value2 decimal.Decimal, unit2 string, op ArithmeticOps) (*decimal.Decimal, string, error) {
+	c1, err := ucumCanonicalUnit(unit1)
+	if err != nil {
+		return nil, "", err
+	}
+	c2, err := ucumCanonicalUnit(unit2)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var value decimal.Decimal
+	exp := 1
+	switch op {
+	case MultiplicationOp:
+		value = value1.Mul(value2)
+	case DivisionOp:
+		if value2.IsZero() {
+			return nil, "", nil
+		}
+		value = value1.Div(value2)
+		exp = -1
+	default:
+		return nil, "", NewCodedError(NotSupportedErrorCode, "arithmetic operator not supported: %c", op)
+	}
+
+	terms := make([]ucumTerm, len(c1.terms), len(c1.terms)+len(c2.terms))
+	copy(terms, c1.terms)
+	for _, t := range c2.terms {
+		t.exp *= exp
+		if i, factor := commensurableUCUMTerm(terms, t); i >= 0 {
+			for j := 0; j < t.exp; j++ {
+				value = value.Mul(factor)
+			}
+			for j := 0; j > t.exp; j-- {
+				value = value.Div(factor)
+			}
+			t.code, t.annotation = terms[i].code, terms[i].annotation
+		}
+		terms = mergeUCUMTerm(terms, t)
+	}
+
+	value = normalizedDecimal(value)
+	return &value, formatUCUMTerms(terms), nil
+}
+
+// commensurableUCUMTerm returns the index of a different term with the same
+// dimension and the factor that converts the specified term into it.
+func commensurableUCUMTerm(terms []ucumTerm, term ucumTerm) (int, decimal.Decimal) {
+	if term.factor || len(term.code) == 0 {
+		return -1, decimal.Decimal{}
+	}
+	c, err := ucumCanonicalUnit(term.code)
+	if err != nil || len(c.dimensions) == 0 {
+		return -1, decimal.Decimal{}
+	}
+
+	for _, t := range terms {
+		if !t.factor && t.code == term.code {
+			return -1, decimal.Decimal{}
+		}
+	}
+	for i, t := range terms {
+		if t.factor || len(t.code) == 0 {
+			continue
+		}
+		if other, err := ucumCanonicalUnit(t.code); err == nil && c.commensurable(other) {
+			return i, c.factor.Div(other.factor)
+		}
+	}
+	return -1, decimal.Decimal{}
+}
+
+func formatUCUMTerms(terms []ucumTerm) string {
+	var b strings.Builder
+	for _, t := range terms {
+		if t.exp > 0 {
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			writeUCUMTerm(&b, t, t.exp)
+		}
+	}
+	for _, t := range terms {
+		if t.exp < 0 {
+			b.WriteByte('/')
+			writeUCUMTerm(&b, t, -t.exp)
+		}
+	}
+
+	if b.Len() == 0 {
+		return "1"
+	}
+	return b.String()
+}
+
+func writeUCUMTerm(b *strings.Builder, t ucumTerm, exp int) {
+	b.WriteString(t.code)
+	if exp != 1 {
+		b.WriteString(strconv.Itoa(exp))
+	}
+	b.WriteString(t.annotation)
+}
+
+func ucumCanonicalUnit(unit string) (*ucumCanonical, error) {
+	ucumCanonicalLock.RLock()
+	c := ucumCanonicalCache[unit]
+	ucumCanonicalLock.RUnlock()
+	if c != nil {
+		return c, nil
+	}
+
+	p := &ucumParser{unit: unit}
+	c, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	ucumCanonicalLock.Lock()
+	defer ucumCanonicalLock.Unlock()
+	ucumCanonicalCache[unit] = c
+	return c, nil
+}
+
+func normalizedDecimal(value decimal.Decimal) decimal.Decimal {
+	if value.Exponent() >= 0 {
+		return value
+	}
+	return decimal.RequireFromString(value.String())
+}
+
+func (c *ucumCanonical) multiply(other *ucumCanonical, exp int) *ucumCanonical {
+	factor := c.factor
+	for i := 0; i < exp; i++ {
+		factor = factor.Mul(other.factor)
+	}
+	for i := 0; i > exp; i-- {
+		factor = factor.Div(other.factor)
+	}
+
+	dimensions := make(map[string]int, len(c.dimensions)+len(other.dimensions))
+	for d, e := range c.dimensions {
+		dimensions[d] = e
+	}
+	for d, e := range other.dimensions {
+		if r := dimensions[d] + e*exp; r == 0 {
+			delete(dimensions, d)
+		} else {
+			dimensions[d] = r
+		}
+	}
+
+	terms := make([]ucumTerm, len(c.terms), len(c.terms)+len(other.terms))
+	copy(terms, c.terms)
+	for _, t := range other.terms {
+		t.exp *= exp
+		terms = mergeUCUMTerm(terms, t)
+	}
+
+	return &ucumCanonical{factor: factor, dimensions: dimensions, terms: terms}
+}
+
+func mergeUCUMTerm(terms []ucumTerm, term ucumTerm) []ucumTerm {
+	if !term.factor {
+		for i, t := range terms {
+			if !t.factor && t.code == term.code && t.annotation == term.annotation {
+				terms[i].exp += term.exp
+				if terms[i].exp == 0 {
+					return append(terms[:i], terms[i+1:]...)
+				}
+				return terms
+			}
+		}
+	}
+	return append(terms, term)
+}
+
+func (c *ucumCanonical) commensurable(other *ucumCanonical) bool {
+	if len(c.dimensions) != len(other.dimensions) {
+		return false
+	}
+	for d, e := range c.dimensions {
+		if other.dimensions[d] != e {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *ucumParser) parse() (*ucumCanonical, error) {
+	if len(p.unit) == 0 {
+		return nil, NewCodedError(InvalidValueErrorCode, "UCUM unit is empty")
+	}
+
+	c, err := p.parseMainTerm()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.unit) {
+		return nil, p.error("unexpected character %q", p.unit[p.pos])
+	}
+	return c, nil
+}
+
+func (p *ucumParser) error(format string, args ...interface{}) error {
+	return NewCodedError(InvalidValueErrorCode, "invalid UCUM unit %s at position %d: %s",
+		p.unit, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *ucumParser) parseMainTerm() (*ucumCanonical, error) {
+	if p.pos < len(p.unit) && p.unit[p.pos] == '/' {
+		p.pos++
+		c, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		return ucumUnity.multiply(c, -1), nil
+	}
+	return p.parseTerm()
+}
+
+func (p *ucumParser) parseTerm() (*ucumCanonical, error) {
+	c, err := p.parseComponent()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.pos < len(p.unit) {
+		op := p.unit[p.pos]
+		if op != '.' && op != '/' {
+			break
+		}
+		p.pos++
+
+		other, err := p.parseComponent()
+		if err != nil {
+			return nil, err
+		}
+		if op == '.' {
+			c = c.multiply(other, 1)
+		} else {
+			c = c.multiply(other, -1)
+		}
+	}
+	return c, nil
+}
+
+func (p *ucumParser) parseComponent() (*ucumCanonical, error) {
+	if p.pos >= len(p.unit) {
+		return nil, p.error("unit term expected")
+	}
+
+	switch p.unit[p.pos] {
+	case '(':
+		p.pos++
+		c, err := p.parseMainTerm()
+		if err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.unit) || p.unit[p.pos] != ')' {
+			return nil, p.error("closing parenthesis expected")
+		}
+		p.pos++
+		_, err = p.parseAnnotation()
+		return c, err
+	case '{':
+		annotation, err := p.parseAnnotation()
+		if err != nil {
+			return nil, err
+		}
+		return &ucumCanonical{factor: ucumUnity.factor, dimensions: ucumUnity.dimensions,
+			terms: []ucumTerm{{annotation: annotation, exp: 1}}}, nil
+	}
+
+	start := p.pos
+	depth := 0
+	for ; p.pos < len(p.unit); p.pos++ {
+		ch := p.unit[p.pos]
+		if ch == '[' {
+			depth++
+		} else if ch == ']' {
+			depth--
+		} else if depth == 0 && strings.IndexByte("./(){", ch) >= 0 {
+			break
+		}
+	}
+	if p.pos == start {
+		return nil, p.error("unit term expected")
+	}
+
+	c, err := p.parseSimpleUnit(p.unit[start:p.pos])
+	if err != nil {
+		return nil, err
+	}
+	annotation, err := p.parseAnnotation()
+	if err != nil {
+		return nil, err
+	}
+	if len(annotation) > 0 && len(c.terms) == 1 {
+		c.terms[0].annotation = annotation
+	}
+	return c, nil
+}
+
+func (p *ucumParser) parseAnnotation() (string, error) {
+	if p.pos >= len(p.unit) || p.unit[p.pos] != '{' {
+		return "", nil
+	}
+	end := strings.IndexByte(p.unit[p.pos:], '}')
+	if end < 0 {
+		return "", p.error("closing brace expected")
+	}
+	annotation := p.unit[p.pos : p.pos+end+1]
+	p.pos += end + 1
+	return annotation, nil
+}
+
+func (p *ucumParser) parseSimpleUnit(token string) (*ucumCanonical, error) {
+	if factor, err := strconv.ParseUint(token, 10, 32); err == nil {
+		c := &ucumCanonical{factor: decimal.New(int64(factor), 0), dimensions: map[string]int{}}
+		if factor != 1 {
+			c.terms = []ucumTerm{{code: token, exp: 1, factor: true}}
+		}
+		return c, nil
+	}
+
+	code, exp := token, 1
+	i := len(token)
+	for i > 0 && token[i-1] >= '0' && token[i-1] <= '9' {
+		i--
+	}
+	if i < len(token) {
+		if i > 0 && (token[i-1] == '+' || token[i-1] == '-') {
+			i--
+		}
+		if i > 0 {
+			exp, _ = strconv.Atoi(token[i:])
+			code = token[:i]
+		}
+	}
+
+	c, err := ucumAtomCanonical(code)
+	if err != nil {
+		return nil, p.error("%v", err)
+	}
+	c = ucumUnity.multiply(c, exp)
+	c.terms = []ucumTerm{{code: code, exp: exp}}
+	return c, nil
+}
+
+func ucumAtomCanonical(code string) (*ucumCanonical, error) {
+	if u := ucumUnitsByCode[code]; u != nil {
+		return u.canonical()
+	}
+
+	for _, prefix := range ucumPrefixesByLength {
+		if !strings.HasPrefix(code, prefix.code) {
+			continue
+		}
+		if u := ucumUnitsByCode[code[len(prefix.code):]]; u != nil && u.metric {
+			c, err := u.canonical()
+			if err != nil {
+				return nil, err
+			}
+			return &ucumCanonical{
+				factor:     c.factor.Mul(decimal.RequireFromString(prefix.value)),
+				dimensions: c.dimensions,
+			}, nil
+		}
+	}
+	return nil, NewCodedError(InvalidValueErrorCode, "unknown unit: %s", code)
+}
+
+func (u *ucumUnit) canonical() (*ucumCanonical, error) {
+	if u.special {
+		return nil, NewCodedError(InvalidValueErrorCode, "special unit cannot be combined with other units: %s", u.code)
+	}
+	if len(u.unit) == 0 {
+		return &ucumCanonical{factor: decimal.New(1, 0), dimensions: map[string]int{u.code: 1}}, nil
+	}
+
+	c, err := ucumCanonicalUnit(u.unit)
+	if err != nil {
+		return nil, err
+	}
+	return &ucumCanonical{
+		factor:     c.factor.Mul(decimal.RequireFromString(u.value)),
+		dimensions: c.dimensions,
+	}, nil
+}
@@ -0,0 +1,382 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathsys
+
+// UCUM prefixes and units as defined by ucum-essence.xml (version 2.1).
+// Special units refer to the unit of the result of their conversion function.
+
+var ucumPrefixes = []ucumPrefix{
+	{"Y", "1e24"},
+	{"Z", "1e21"},
+	{"E", "1e18"},
+	{"P", "1e15"},
+	{"T", "1e12"},
+	{"G", "1e9"},
+	{"M", "1e6"},
+	{"k", "1e3"},
+	{"h", "1e2"},
+	{"da", "1e1"},
+	{"d", "1e-1"},
+	{"c", "1e-2"},
+	{"m", "1e-3"},
+	{"u", "1e-6"},
+	{"n", "1e-9"},
+	{"p", "1e-12"},
+	{"f", "1e-15"},
+	{"a", "1e-18"},
+	{"z", "1e-21"},
+	{"y", "1e-24"},
+	{"Ki", "1024"},
+	{"Mi", "1048576"},
+	{"Gi", "1073741824"},
+	{"Ti", "1099511627776"},
+}
+
+var ucumBaseUnits = []string{"m", "s", "g", "rad", "K", "C", "cd"}
+
+var ucumUnits = []ucumUnit{
+	// dimensionless
+	{code: "10*", value: "10", unit: "1"},
+	{code: "10^", value: "10", unit: "1"},
+	{code: "[pi]", value: "3.1415926535897932384626433832795028841971693993751058209749445923", unit: "1"},
+	{code: "%", value: "1", unit: "10*-2"},
+	{code: "[ppth]", value: "1", unit: "10*-3"},
+	{code: "[ppm]", value: "1", unit: "10*-6"},
+	{code: "[ppb]", value: "1", unit: "10*-9"},
+	{code: "[pptr]", value: "1", unit: "10*-12"},
+	// SI
+	{code: "mol", metric: true, value: "6.0221367", unit: "10*23"},
+	{code: "sr", metric: true, value: "1", unit: "rad2"},
+	{code: "Hz", metric: true, value: "1", unit: "s-1"},
+	{code: "N", metric: true, value: "1", unit: "kg.m/s2"},
+	{code: "Pa", metric: true, value: "1", unit: "N/m2"},
+	{code: "J", metric: true, value: "1", unit: "N.m"},
+	{code: "W", metric: true, value: "1", unit: "J/s"},
+	{code: "A", metric: true, value: "1", unit: "C/s"},
+	{code: "V", metric: true, value: "1", unit: "J/C"},
+	{code: "F", metric: true, value: "1", unit: "C/V"},
+	{code: "Ohm", metric: true, value: "1", unit: "V/A"},
+	{code: "S", metric: true, value: "1", unit: "Ohm-1"},
+	{code: "Wb", metric: true, value: "1", unit: "V.s"},
+	{code: "Cel", metric: true, special: true, value: "1", unit: "K"},
+	{code: "T", metric: true, value: "1", unit: "Wb/m2"},
+	{code: "H", metric: true, value: "1", unit: "Wb/A"},
+	{code: "lm", metric: true, value: "1", unit: "cd.sr"},
+	{code: "lx", metric: true, value: "1", unit: "lm/m2"},
+	{code: "Bq", metric: true, value: "1", unit: "s-1"},
+	{code: "Gy", metric: true, value: "1", unit: "J/kg"},
+	{code: "Sv", metric: true, value: "1", unit: "J/kg"},
+	// ISO 1000
+	{code: "gon", value: "0.9", unit: "deg"},
+	{code: "deg", value: "2", unit: "[pi].rad/360"},
+	{code: "'", value: "1", unit: "deg/60"},
+	{code: "''", value: "1", unit: "'/60"},
+	{code: "l", metric: true, value: "1", unit: "dm3"},
+	{code: "L", metric: true, value: "1", unit: "l"},
+	{code: "ar", metric: true, value: "100", unit: "m2"},
+	{code: "min", value: "60", unit: "s"},
+	{code: "h", value: "60", unit: "min"},
+	{code: "d", value: "24", unit: "h"},
+	{code: "a_t", value: "365.24219", unit: "d"},
+	{code: "a_j", value: "365.25", unit: "d"},
+	{code: "a_g", value: "365.2425", unit: "d"},
+	{code: "a", value: "1", unit: "a_j"},
+	{code: "wk", value: "7", unit: "d"},
+	{code: "mo_s", value: "29.53059", unit: "d"},
+	{code: "mo_j", value: "1", unit: "a_j/12"},
+	{code: "mo_g", value: "1", unit: "a_g/12"},
+	{code: "mo", value: "1", unit: "mo_j"},
+	{code: "t", metric: true, value: "1e3", unit: "kg"},
+	{code: "bar", metric: true, value: "1e5", unit: "Pa"},
+	{code: "u", metric: true, value: "1.6605402e-24", unit: "g"},
+	{code: "eV", metric: true, value: "1", unit: "[e].V"},
+	{code: "AU", value: "149597.870691", unit: "Mm"},
+	{code: "pc", metric: true, value: "3.085678e16", unit: "m"},
+	// natural constants
+	{code: "[c]", metric: true, value: "299792458", unit: "m/s"},
+	{code: "[h]", metric: true, value: "6.6260755e-34", unit: "J.s"},
+	{code: "[k]", metric: true, value: "1.380658e-23", unit: "J/K"},
+	{code: "[eps_0]", metric: true, value: "8.854187817e-12", unit: "F/m"},
+	{code: "[mu_0]", metric: true, value: "1", unit: "4.[pi].10*-7.N/A2"},
+	{code: "[e]", metric: true, value: "1.60217733e-19", unit: "C"},
+	{code: "[m_e]", metric: true, value: "9.1093897e-28", unit: "g"},
+	{code: "[m_p]", metric: true, value: "1.6726231e-24", unit: "g"},
+	{code: "[G]", metric: true, value: "6.67259e-11", unit: "m3.kg-1.s-2"},
+	{code: "[g]", metric: true, value: "9.80665", unit: "m/s2"},
+	{code: "atm", value: "101325", unit: "Pa"},
+	{code: "[ly]", metric: true, value: "1", unit: "[c].a_j"},
+	{code: "gf", metric: true, value: "1", unit: "g.[g]"},
+	{code: "[lbf_av]", value: "1", unit: "[lb_av].[g]"},
+	// CGS
+	{code: "Ky", metric: true, value: "1", unit: "cm-1"},
+	{code: "Gal", metric: true, value: "1", unit: "cm/s2"},
+	{code: "dyn", metric: true, value: "1", unit: "g.cm/s2"},
+	{code: "erg", metric: true, value: "1", unit: "dyn.cm"},
+	{code: "P", metric: true, value: "1", unit: "dyn.s/cm2"},
+	{code: "Bi", metric: true, value: "10", unit: "A"},
+	{code: "St", metric: true, value: "1", unit: "cm2/s"},
+	{code: "Mx", metric: true, value: "1e-8", unit: "Wb"},
+	{code: "G", metric: true, value: "1e-4", unit: "T"},
+	{code: "Oe", metric: true, value: "250", unit: "/[pi].A/m"},
+	{code: "Gb", metric: true, value: "1", unit: "Oe.cm"},
+	{code: "sb", metric: true, value: "1", unit: "cd/cm2"},
+	{code: "Lmb", metric: true, value: "1", unit: "cd/cm2/[pi]"},
+	{code: "ph", metric: true, value: "1e-4", unit: "lx"},
+	{code: "Ci", metric: true, value: "3.7e10", unit: "Bq"},
+	{code: "R", metric: true, value: "2.58e-4", unit: "C/kg"},
+	{code: "RAD", metric: true, value: "100", unit: "erg/g"},
+	{code: "REM", metric: true, value: "1", unit: "RAD"},
+	// international customary
+	{code: "[in_i]", value: "2.54", unit: "cm"},
+	{code: "[ft_i]", value: "12", unit: "[in_i]"},
+	{code: "[yd_i]", value: "3", unit: "[ft_i]"},
+	{code: "[mi_i]", value: "5280", unit: "[ft_i]"},
+	{code: "[fth_i]", value: "6", unit: "[ft_i]"},
+	{code: "[nmi_i]", value: "1852", unit: "m"},
+	{code: "[kn_i]", value: "1", unit: "[nmi_i]/h"},
+	{code: "[sin_i]", value: "1", unit: "[in_i]2"},
+	{code: "[sft_i]", value: "1", unit: "[ft_i]2"},
+	{code: "[syd_i]", value: "1", unit: "[yd_i]2"},
+	{code: "[cin_i]", value: "1", unit: "[in_i]3"},
+	{code: "[cft_i]", value: "1", unit: "[ft_i]3"},
+	{code: "[cyd_i]", value: "1", unit: "[yd_i]3"},
+	{code: "[bf_i]", value: "144", unit: "[in_i]3"},
+	{code: "[cr_i]", value: "128", unit: "[ft_i]3"},
+	{code: "[mil_i]", value: "1e-3", unit: "[in_i]"},
+	{code: "[cml_i]", value: "1", unit: "[pi]/4.[mil_i]2"},
+	{code: "[hd_i]", value: "4", unit: "[in_i]"},
+	// US survey
+	{code: "[ft_us]", value: "1200", unit: "m/3937"},
+	{code: "[yd_us]", value: "3", unit: "[ft_us]"},
+	{code: "[in_us]", value: "1", unit: "[ft_us]/12"},
+	{code: "[rd_us]", value: "16.5", unit: "[ft_us]"},
+	{code: "[ch_us]", value: "4", unit: "[rd_us]"},
+	{code: "[lk_us]", value: "1", unit: "[ch_us]/100"},
+	{code: "[rch_us]", value: "100", unit: "[ft_us]"},
+	{code: "[rlk_us]", value: "1", unit: "[rch_us]/100"},
+	{code: "[fth_us]", value: "6", unit: "[ft_us]"},
+	{code: "[fur_us]", value: "40", unit: "[rd_us]"},
+	{code: "[mi_us]", value: "8", unit: "[fur_us]"},
+	{code: "[acr_us]", value: "160", unit: "[rd_us]2"},
+	{code: "[srd_us]", value: "1", unit: "[rd_us]2"},
+	{code: "[smi_us]", value: "1", unit: "[mi_us]2"},
+	{code: "[sct]", value: "1", unit: "[mi_us]2"},
+	{code: "[twp]", value: "36", unit: "[sct]"},
+	{code: "[mil_us]", value: "1e-3", unit: "[in_us]"},
+	// British imperial
+	{code: "[in_br]", value: "2.539998", unit: "cm"},
+	{code: "[ft_br]", value: "12", unit: "[in_br]"},
+	{code: "[rd_br]", value: "16.5", unit: "[ft_br]"},
+	{code: "[ch_br]", value: "4", unit: "[rd_br]"},
+	{code: "[lk_br]", value: "1", unit: "[ch_br]/100"},
+	{code: "[fth_br]", value: "6", unit: "[ft_br]"},
+	{code: "[pc_br]", value: "2.5", unit: "[ft_br]"},
+	{code: "[yd_br]", value: "3", unit: "[ft_br]"},
+	{code: "[mi_br]", value: "5280", unit: "[ft_br]"},
+	{code: "[nmi_br]", value: "6080", unit: "[ft_br]"},
+	{code: "[kn_br]", value: "1", unit: "[nmi_br]/h"},
+	{code: "[acr_br]", value: "4840", unit: "[yd_br]2"},
+	// US volumes
+	{code: "[gal_us]", value: "231", unit: "[in_i]3"},
+	{code: "[bbl_us]", value: "42", unit: "[gal_us]"},
+	{code: "[qt_us]", value: "1", unit: "[gal_us]/4"},
+	{code: "[pt_us]", value: "1", unit: "[qt_us]/2"},
+	{code: "[gil_us]", value: "1", unit: "[pt_us]/4"},
+	{code: "[foz_us]", value: "1", unit: "[gil_us]/4"},
+	{code: "[fdr_us]", value: "1", unit: "[foz_us]/8"},
+	{code: "[min_us]", value: "1", unit: "[fdr_us]/60"},
+	{code: "[crd_us]", value: "128", unit: "[ft_i]3"},
+	{code: "[bu_us]", value: "2150.42", unit: "[in_i]3"},
+	{code: "[gal_wi]", value: "1", unit: "[bu_us]/8"},
+	{code: "[pk_us]", value: "1", unit: "[bu_us]/4"},
+	{code: "[dqt_us]", value: "1", unit: "[pk_us]/8"},
+	{code: "[dpt_us]", value: "1", unit: "[dqt_us]/2"},
+	{code: "[tbs_us]", value: "1", unit: "[foz_us]/2"},
+	{code: "[tsp_us]", value: "1", unit: "[tbs_us]/3"},
+	{code: "[cup_us]", value: "16", unit: "[tbs_us]"},
+	{code: "[foz_m]", value: "30", unit: "mL"},
+	{code: "[cup_m]", value: "240", unit: "mL"},
+	{code: "[tsp_m]", value: "5", unit: "mL"},
+	{code: "[tbs_m]", value: "15", unit: "mL"},
+	// British imperial volumes
+	{code: "[gal_br]", value: "4.54609", unit: "l"},
+	{code: "[pk_br]", value: "2", unit: "[gal_br]"},
+	{code: "[bu_br]", value: "4", unit: "[pk_br]"},
+	{code: "[qt_br]", value: "1", unit: "[gal_br]/4"},
+	{code: "[pt_br]", value: "1", unit: "[qt_br]/2"},
+	{code: "[gil_br]", value: "1", unit: "[pt_br]/4"},
+	{code: "[foz_br]", value: "1", unit: "[gil_br]/5"},
+	{code: "[fdr_br]", value: "1", unit: "[foz_br]/8"},
+	{code: "[min_br]", value: "1", unit: "[fdr_br]/60"},
+	// avoirdupois, troy and apothecaries' weights
+	{code: "[gr]", value: "64.79891", unit: "mg"},
+	{code: "[lb_av]", value: "7000", unit: "[gr]"},
+	{code: "[oz_av]", value: "1", unit: "[lb_av]/16"},
+	{code: "[dr_av]", value: "1", unit: "[oz_av]/16"},
+	{code: "[scwt_av]", value: "100", unit: "[lb_av]"},
+	{code: "[lcwt_av]", value: "112", unit: "[lb_av]"},
+	{code: "[ston_av]", value: "20", unit: "[scwt_av]"},
+	{code: "[lton_av]", value: "20", unit: "[lcwt_av]"},
+	{code: "[stone_av]", value: "14", unit: "[lb_av]"},
+	{code: "[pwt_tr]", value: "24", unit: "[gr]"},
+	{code: "[oz_tr]", value: "20", unit: "[pwt_tr]"},
+	{code: "[lb_tr]", value: "12", unit: "[oz_tr]"},
+	{code: "[sc_ap]", value: "20", unit: "[gr]"},
+	{code: "[dr_ap]", value: "3", unit: "[sc_ap]"},
+	{code: "[oz_ap]", value: "8", unit: "[dr_ap]"},
+	{code: "[lb_ap]", value: "12", unit: "[oz_ap]"},
+	{code: "[oz_m]", value: "28", unit: "g"},
+	// typesetter's units
+	{code: "[lne]", value: "1", unit: "[in_i]/12"},
+	{code: "[pnt]", value: "1", unit: "[lne]/6"},
+	{code: "[pca]", value: "12", unit: "[pnt]"},
+	{code: "[pnt_pr]", value: "0.013837", unit: "[in_i]"},
+	{code: "[pca_pr]", value: "12", unit: "[pnt_pr]"},
+	{code: "[pied]", value: "32.48", unit: "cm"},
+	{code: "[pouce]", value: "1", unit: "[pied]/12"},
+	{code: "[ligne]", value: "1", unit: "[pouce]/12"},
+	{code: "[didot]", value: "1", unit: "[ligne]/6"},
+	{code: "[cicero]", value: "12", unit: "[didot]"},
+	// heat
+	{code: "[degF]", special: true, value: "1", unit: "K"},
+	{code: "[degR]", value: "5", unit: "K/9"},
+	{code: "[degRe]", special: true, value: "1", unit: "K"},
+	{code: "cal_[15]", metric: true, value: "4.18580", unit: "J"},
+	{code: "cal_[20]", metric: true, value: "4.18190", unit: "J"},
+	{code: "cal_m", metric: true, value: "4.19002", unit: "J"},
+	{code: "cal_IT", metric: true, value: "4.1868", unit: "J"},
+	{code: "cal_th", metric: true, value: "4.184", unit: "J"},
+	{code: "cal", metric: true, value: "1", unit: "cal_th"},
+	{code: "[Cal]", value: "1", unit: "kcal_th"},
+	{code: "[Btu_39]", value: "1.05967", unit: "kJ"},
+	{code: "[Btu_59]", value: "1.05480", unit: "kJ"},
+	{code: "[Btu_60]", value: "1.05468", unit: "kJ"},
+	{code: "[Btu_m]", value: "1.05587", unit: "kJ"},
+	{code: "[Btu_IT]", value: "1.05505585262", unit: "kJ"},
+	{code: "[Btu_th]", value: "1.054350", unit: "kJ"},
+	{code: "[Btu]", value: "1", unit: "[Btu_th]"},
+	{code: "[HP]", value: "550", unit: "[ft_i].[lbf_av]/s"},
+	{code: "tex", metric: true, value: "1", unit: "g/km"},
+	{code: "[den]", value: "1", unit: "g/9/km"},
+	// clinical
+	{code: "m[H2O]", metric: true, value: "9.80665", unit: "kPa"},
+	{code: "m[Hg]", metric: true, value: "133.3220", unit: "kPa"},
+	{code: "[in_i'H2O]", value: "1", unit: "m[H2O].[in_i]/m"},
+	{code: "[in_i'Hg]", value: "1", unit: "m[Hg].[in_i]/m"},
+	{code: "[PRU]", value: "1", unit: "mm[Hg].s/ml"},
+	{code: "[wood'U]", value: "1", unit: "mm[Hg].min/L"},
+	{code: "[diop]", value: "1", unit: "/m"},
+	{code: "[p'diop]", special: true, value: "1", unit: "rad"},
+	{code: "%[slope]", special: true, value: "1", unit: "rad"},
+	{code: "[mesh_i]", value: "1", unit: "/[in_i]"},
+	{code: "[Ch]", value: "1", unit: "mm/3"},
+	{code: "[drp]", value: "1", unit: "ml/20"},
+	{code: "[hnsf'U]", value: "1", unit: "1"},
+	{code: "[MET]", value: "3.5", unit: "mL/min/kg"},
+	{code: "[hp'_X]", special: true, value: "1", unit: "1"},
+	{code: "[hp'_C]", special: true, value: "1", unit: "1"},
+	{code: "[hp'_M]", special: true, value: "1", unit: "1"},
+	{code: "[hp'_Q]", special: true, value: "1", unit: "1"},
+	{code: "[hp_X]", value: "1", unit: "1"},
+	{code: "[hp_C]", value: "1", unit: "1"},
+	{code: "[hp_M]", value: "1", unit: "1"},
+	{code: "[hp_Q]", value: "1", unit: "1"},
+	{code: "[kp_X]", value: "1", unit: "1"},
+	{code: "[kp_C]", value: "1", unit: "1"},
+	{code: "[kp_M]", value: "1", unit: "1"},
+	{code: "[kp_Q]", value: "1", unit: "1"},
+	{code: "eq", metric: true, value: "1", unit: "mol"},
+	{code: "osm", metric: true, value: "1", unit: "mol"},
+	{code: "[pH]", special: true, value: "1", unit: "mol/l"},
+	{code: "g%", metric: true, value: "1", unit: "g/dl"},
+	{code: "[S]", value: "1", unit: "10*-13.s"},
+	{code: "[HPF]", value: "1", unit: "1"},
+	{code: "[LPF]", value: "100", unit: "1"},
+	{code: "kat", metric: true, value: "1", unit: "mol/s"},
+	{code: "U", metric: true, value: "1", unit: "umol/min"},
+	{code: "[iU]", metric: true, arbitrary: true},
+	{code: "[IU]", metric: true, value: "1", unit: "[iU]"},
+	{code: "[arb'U]", arbitrary: true},
+	{code: "[USP'U]", arbitrary: true},
+	{code: "[GPL'U]", arbitrary: true},
+	{code: "[MPL'U]", arbitrary: true},
+	{code: "[APL'U]", arbitrary: true},
+	{code: "[beth'U]", arbitrary: true},
+	{code: "[anti'Xa'U]", arbitrary: true},
+	{code: "[todd'U]", arbitrary: true},
+	{code: "[dye'U]", arbitrary: true},
+	{code: "[smgy'U]", arbitrary: true},
+	{code: "[bdsk'U]", arbitrary: true},
+	{code: "[ka'U]", arbitrary: true},
+	{code: "[knk'U]", arbitrary: true},
+	{code: "[mclg'U]", arbitrary: true},
+	{code: "[tb'U]", arbitrary: true},
+	{code: "[CCID_50]", arbitrary: true},
+	{code: "[TCID_50]", arbitrary: true},
+	{code: "[EID_50]", arbitrary: true},
+	{code: "[PFU]", arbitrary: true},
+	{code: "[FFU]", arbitrary: true},
+	{code: "[CFU]", arbitrary: true},
+	{code: "[IR]", arbitrary: true},
+	{code: "[BAU]", arbitrary: true},
+	{code: "[AU]", arbitrary: true},
+	{code: "[Amb'a'1'U]", arbitrary: true},
+	{code: "[PNU]", arbitrary: true},
+	{code: "[Lf]", arbitrary: true},
+	{code: "[D'ag'U]", arbitrary: true},
+	{code: "[FEU]", arbitrary: true},
+	{code: "[ELU]", arbitrary: true},
+	{code: "[EU]", arbitrary: true},
+	// levels
+	{code: "Np", metric: true, special: true, value: "1", unit: "1"},
+	{code: "B", metric: true, special: true, value: "1", unit: "1"},
+	{code: "B[SPL]", metric: true, special: true, value: "1", unit: "Pa"},
+	{code: "B[V]", metric: true, special: true, value: "1", unit: "V"},
+	{code: "B[mV]", metric: true, special: true, value: "1", unit: "mV"},
+	{code: "B[uV]", metric: true, special: true, value: "1", unit: "uV"},
+	{code: "B[10.nV]", metric: true, special: true, value: "1", unit: "nV"},
+	{code: "B[W]", metric: true, special: true, value: "1", unit: "W"},
+	{code: "B[kW]", metric: true, special: true, value: "1", unit: "kW"},
+	// miscellaneous
+	{code: "st", metric: true, value: "1", unit: "m3"},
+	{code: "Ao", value: "0.1", unit: "nm"},
+	{code: "b", metric: true, value: "100", unit: "fm2"},
+	{code: "att", value: "1", unit: "kgf/cm2"},
+	{code: "mho", metric: true, value: "1", unit: "S"},
+	{code: "[psi]", value: "1", unit: "[lbf_av]/[in_i]2"},
+	{code: "circ", value: "2", unit: "[pi].rad"},
+	{code: "sph", value: "4", unit: "[pi].sr"},
+	{code: "[car_m]", value: "2e-1", unit: "g"},
+	{code: "[car_Au]", value: "1", unit: "/24"},
+	{code: "[smoot]", value: "67", unit: "[in_i]"},
+	{code: "bit_s", special: true, value: "1", unit: "1"},
+	{code: "bit", metric: true, value: "1", unit: "1"},
+	{code: "By", metric: true, value: "8", unit: "bit"},
+	{code: "Bd", metric: true, value: "1", unit: "/s"},
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathsys
+
+import (
+	"github.com/shopspring/decimal"
+	"math"
+	"strings"
+)
+
+// ucumFunction converts the value of a special unit into the reference unit
+// of the special unit (to) and back (from).
+type ucumFunction struct {
+	to   func(value decimal.Decimal) (decimal.Decimal, bool)
+	from func(value decimal.Decimal) (decimal.Decimal, bool)
+}
+
+var ucumKelvinOffset = decimal.RequireFromString("273.15")
+var ucumFahrenheitOffset = decimal.RequireFromString("459.67")
+
+var ucumFunctions = map[string]ucumFunction{
+	"Cel": {
+		to: func(v decimal.Decimal) (decimal.Decimal, bool) {
+			return v.Add(ucumKelvinOffset), true
+		},
+		from: func(v decimal.Decimal) (decimal.Decimal, bool) {
+			return v.Sub(ucumKelvinOffset), true
+		},
+	},
+	"[degF]": {
+		to: func(v decimal.Decimal) (decimal.Decimal, bool) {
+			return v.Add(ucumFahrenheitOffset).Mul(decimal.New(5, 0)).Div(decimal.New(9, 0)), true
+		},
+		from: func(v decimal.Decimal) (decimal.Decimal, bool) {
+			return v.Mul(decimal.New(9, 0)).Div(decimal.New(5, 0)).Sub(ucumFahrenheitOffset), true
+		},
+	},
+	"[degRe]": {
+		to: func(v decimal.Decimal) (decimal.Decimal, bool) {
+			return v.Mul(decimal.New(5, 0)).Div(decimal.New(4, 0)).Add(ucumKelvinOffset), true
+		},
+		from: func(v decimal.Decimal) (decimal.Decimal, bool) {
+			return v.Sub(ucumKelvinOffset).Mul(decimal.New(4, 0)).Div(decimal.New(5, 0)), true
+		},
+	},
+	"[pH]":     ucumPowerFunction(10, -1, 1),
+	"Np":       ucumPowerFunction(math.E, 1, 1),
+	"B":        ucumPowerFunction(10, 1, 1),
+	"B[SPL]":   ucumPowerFunction(10, .5, 2e-5),
+	"B[V]":     ucumPowerFunction(10, .5, 1),
+	"B[mV]":    ucumPowerFunction(10, .5, 1),
+	"B[uV]":    ucumPowerFunction(10, .5, 1),
+	"B[10.nV]": ucumPowerFunction(10, .5, 10),
+	"B[W]":     ucumPowerFunction(10, 1, 1),
+	"B[kW]":    ucumPowerFunction(10, 1, 1),
+	"[hp'_X]":  ucumPowerFunction(10, -1, 1),
+	"[hp'_C]":  ucumPowerFunction(100, -1, 1),
+	"[hp'_M]":  ucumPowerFunction(1000, -1, 1),
+	"[hp'_Q]":  ucumPowerFunction(50000, -1, 1),
+	"bit_s":    ucumPowerFunction(2, 1, 1),
+	"[p'diop]": ucumTangentFunction(),
+	"%[slope]": ucumTangentFunction(),
+}
+
+// ucumPowerFunction returns a logarithmic unit function that maps value v to
+// factor * base^(scale * v) of the reference unit.
+func ucumPowerFunction(base float64, scale float64, factor float64) ucumFunction {
+	return ucumFunction{
+		to: func(v decimal.Decimal) (decimal.Decimal, bool) {
+			f, _ := v.Float64()
+			return ucumFloat(factor * math.Pow(base, scale*f))
+		},
+		from: func(v decimal.Decimal) (decimal.Decimal, bool) {
+			f, _ := v.Float64()
+			if f <= 0 {
+				return decimal.Decimal{}, false
+			}
+			return ucumFloat(math.Log(f/factor) / math.Log(base) / scale)
+		},
+	}
+}
+
+func ucumTangentFunction() ucumFunction {
+	return ucumFunction{
+		to: func(v decimal.Decimal) (decimal.Decimal, bool) {
+			f, _ := v.Float64()
+			return ucumFloat(math.Atan(f / 100))
+		},
+		from: func(v decimal.Decimal) (decimal.Decimal, bool) {
+			f, _ := v.Float64()
+			return ucumFloat(100 * math.Tan(f))
+		},
+	}
+}
+
+func ucumFloat(f float64) (decimal.Decimal, bool) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return decimal.Decimal{}, false
+	}
+	return decimal.NewFromFloat(f), true
+}
+
+// ucumAbsoluteUnits are the special units of absolute temperature scales.
+// Adding two values of these units is not defined.
+var ucumAbsoluteUnits = map[string]bool{
+	"Cel":     true,
+	"[degF]":  true,
+	"[degRe]": true,
+}
+
+// IsUCUMSpecialUnit returns if the specified unit is a UCUM special unit
+// (e.g. Cel or [degF]) that cannot be converted by a factor only.
+func IsUCUMSpecialUnit(unit string) bool {
+	u, _ := ucumSpecialUnit(unit)
+	return u != nil
+}
+
+func isUCUMAbsoluteUnit(unit string) bool {
+	u, _ := ucumSpecialUnit(unit)
+	return u != nil && ucumAbsoluteUnits[u.code]
+}
+
+func ucumSpecialUnit(unit string) (*ucumUnit, decimal.Decimal) {
+	if u := ucumUnitsByCode[unit]; u != nil {
+		if u.special {
+			return u, decimal.New(1, 0)
+		}
+		return nil, decimal.Decimal{}
+	}
+
+	for _, prefix := range ucumPrefixesByLength {
+		if !strings.HasPrefix(unit, prefix.code) {
+			continue
+		}
+		if u := ucumUnitsByCode[unit[len(prefix.code):]]; u != nil && u.special && u.metric {
+			return u, decimal.RequireFromString(prefix.value)
+		}
+	}
+	return nil, decimal.Decimal{}
+}
+
+func ucumSpecialToReference(value decimal.Decimal, unit string) (decimal.Decimal, string, error) {
+	u, prefix := ucumSpecialUnit(unit)
+	if u == nil {
+		return value, unit, nil
+	}
+
+	res, ok := ucumFunctions[u.code].to(value.Mul(prefix))
+	if !ok {
+		return decimal.Decimal{}, "", NewCodedError(InvalidValueErrorCode, "value %s is out of range for unit %s", value, unit)
+	}
+	return res, u.unit, nil
+}
+
+func ucumSpecialFromReference(value decimal.Decimal, unit string) (decimal.Decimal, error) {
+	u, prefix := ucumSpecialUnit(unit)
+	if u == nil {
+		return value, nil
+	}
+
+	res, ok := ucumFunctions[u.code].from(value)
+	if !ok {
+		return decimal.Decimal{}, NewCodedError(InvalidValueErrorCode, "value %s is out of range for unit %s", value, unit)
+	}
+	return res.Div(prefix), nil
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathsys
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConvertUCUMSpecialUnit(t *testing.T) {
+	for _, test := range []struct {
+		value    string
+		from     string
+		to       string
+		expected string
+	}{
+		{"37", "Cel", "[degF]", "98.6"},
+		{"98.6", "[degF]", "Cel", "37"},
+		{"0", "Cel", "K", "273.15"},
+		{"300", "K", "Cel", "26.85"},
+		{"20", "Cel", "mK", "293150"},
+		{"10", "Cel", "mCel", "10000"},
+		{"80", "[degRe]", "Cel", "100"},
+		{"2", "[pH]", "mmol/L", "10"},
+		{"10", "mmol/L", "[pH]", "2"},
+		{"20", "dB", "1", "100"},
+		{"100", "1", "dB", "20"},
+		{"60", "dB[SPL]", "Pa", "0.02"},
+		{"3", "B[W]", "kW", "1"},
+		{"8", "bit_s", "1", "256"},
+		{"2", "[hp'_C]", "1", "0.0001"},
+	} {
+		t.Run(test.from+"->"+test.to, func(t *testing.T) {
+			value, err := ParseDecimal(test.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := ConvertUCUMUnit(value, test.from, test.to)
+			if assert.NoError(t, err, "no error expected") && assert.NotNil(t, res) {
+				expected, _ := ParseDecimal(test.expected)
+				assert.True(t, expected.Equivalent(res), "expected %s, actual %s", test.expected, res)
+			}
+		})
+	}
+}
+
+func TestConvertUCUMSpecialUnitPrismDiopter(t *testing.T) {
+	res, err := ConvertUCUMUnit(NewDecimalInt(100), "[p'diop]", "deg")
+	if assert.NoError(t, err, "no error expected") {
+		assert.InDelta(t, 45.0, res.Float64(), 1e-9)
+	}
+}
+
+func TestConvertUCUMSpecialUnitNotCommensurable(t *testing.T) {
+	res, err := ConvertUCUMUnit(NewDecimalInt(37), "Cel", "[pH]")
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res)
+}
+
+func TestConvertUCUMSpecialUnitOutOfRange(t *testing.T) {
+	res, err := ConvertUCUMUnit(NewDecimalInt(-1), "1", "B")
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res)
+}
+
+func TestConvertUCUMSpecialUnitInfinite(t *testing.T) {
+	res, err := ConvertUCUMUnit(NewDecimalInt(1000), "B", "1")
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res)
+}
+
+func TestConvertUCUMSpecialUnitCompound(t *testing.T) {
+	res, err := ConvertUCUMUnit(NewDecimalInt(1), "Cel/h", "K/h")
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res)
+}
+
+func TestIsUCUMSpecialUnit(t *testing.T) {
+	assert.True(t, IsUCUMSpecialUnit("Cel"))
+	assert.True(t, IsUCUMSpecialUnit("[degF]"))
+	assert.True(t, IsUCUMSpecialUnit("dB"))
+	assert.False(t, IsUCUMSpecialUnit("K"))
+	assert.False(t, IsUCUMSpecialUnit("[degR]"))
+	assert.False(t, IsUCUMSpecialUnit("k[degF]"))
+	assert.False(t, IsUCUMSpecialUnit("xyz"))
+}
+
+func TestUCUMFunctionsDefined(t *testing.T) {
+	for _, u := range ucumUnits {
+		if u.special {
+			_, found := ucumFunctions[u.code]
+			assert.True(t, found, "function expected for %s", u.code)
+			_, err := ucumCanonicalUnit(u.unit)
+			assert.NoError(t, err, "no error expected for reference unit of %s", u.code)
+		}
+	}
+}
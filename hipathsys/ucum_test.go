@@ -0,0 +1,200 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hipathsys
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var ucumConversionTests = []struct {
+	value    string
+	from     string
+	to       string
+	expected string
+}{
+	{"4", "g", "mg", "4000"},
+	{"1", "[in_i]", "cm", "2.54"},
+	{"1", "[ft_i]", "m", "0.3048"},
+	{"1", "[lb_av]", "kg", "0.45359237"},
+	{"1", "mmol/L", "umol/mL", "1"},
+	{"2", "kg.m-2", "g/cm2", "0.2"},
+	{"1", "10*3/uL", "10*9/L", "1"},
+	{"1", "N", "kg.m/s2", "1"},
+	{"1", "J", "kg.m2.s-2", "1"},
+	{"1", "L", "dm3", "1"},
+	{"1", "[gal_us]", "[qt_us]", "4"},
+	{"1", "a", "d", "365.25"},
+	{"1", "wk", "h", "168"},
+	{"1", "mm[Hg]", "Pa", "133.322"},
+	{"1", "%", "1", "0.01"},
+	{"1", "mg{creat}", "g", "0.001"},
+	{"1", "{cells}/uL", "/mL", "1000"},
+	{"1", "/min", "Hz", "0.0166666666666667"},
+	{"1", "[iU]/L", "[IU]/mL", "0.001"},
+	{"1", "kg/(m.s2)", "Pa", "1"},
+	{"1", "10*-3", "1", "0.001"},
+	{"1", "dag", "g", "10"},
+	{"1", "KiBy", "bit", "8192"},
+	{"1", "cal", "J", "4.184"},
+	{"1", "mol", "10*23", "6.0221367"},
+}
+
+func TestConvertUCUMUnit(t *testing.T) {
+	for _, test := range ucumConversionTests {
+		t.Run(test.from+"->"+test.to, func(t *testing.T) {
+			value, err := ParseDecimal(test.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := ConvertUCUMUnit(value, test.from, test.to)
+			if assert.NoError(t, err, "no error expected") && assert.NotNil(t, res) {
+				assert.Equal(t, test.expected, res.String())
+			}
+		})
+	}
+}
+
+func TestConvertUCUMUnitNotCommensurable(t *testing.T) {
+	res, err := ConvertUCUMUnit(NewDecimalInt(1), "g", "m")
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res)
+}
+
+func TestConvertUCUMUnitArbitrary(t *testing.T) {
+	res, err := ConvertUCUMUnit(NewDecimalInt(1), "[arb'U]", "[USP'U]")
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res)
+}
+
+func TestConvertUCUMUnitInvalidFrom(t *testing.T) {
+	res, err := ConvertUCUMUnit(NewDecimalInt(1), "xyz", "g")
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res)
+}
+
+func TestConvertUCUMUnitInvalidTo(t *testing.T) {
+	res, err := ConvertUCUMUnit(NewDecimalInt(1), "g", "xyz")
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res)
+}
+
+func TestUCUMUnitsCommensurable(t *testing.T) {
+	assert.True(t, UCUMUnitsCommensurable("mmol/L", "mol/m3"))
+	assert.True(t, UCUMUnitsCommensurable("[psi]", "kPa"))
+	assert.False(t, UCUMUnitsCommensurable("mmol/L", "g/L"))
+	assert.False(t, UCUMUnitsCommensurable("g", "xyz"))
+	assert.False(t, UCUMUnitsCommensurable("xyz", "g"))
+}
+
+func TestUCUMInvalidUnits(t *testing.T) {
+	for _, unit := range []string{"", "g.", "/", "(g", "g)", "g{test", "kg..m", "[in_i", "mm-", "cg2x", "km[Hg]x"} {
+		_, err := ucumCanonicalUnit(unit)
+		assert.Error(t, err, "error expected for %s", unit)
+	}
+}
+
+func TestUCUMPrefixNonMetric(t *testing.T) {
+	_, err := ucumCanonicalUnit("k[in_i]")
+	assert.Error(t, err, "error expected")
+}
+
+func TestUCUMSpecialUnit(t *testing.T) {
+	_, err := ucumCanonicalUnit("Cel.m")
+	assert.Error(t, err, "error expected")
+}
+
+func TestUCUMExactAtomBeforePrefix(t *testing.T) {
+	c, err := ucumCanonicalUnit("cd")
+	if assert.NoError(t, err, "no error expected") {
+		assert.Equal(t, map[string]int{"cd": 1}, c.dimensions)
+	}
+}
+
+func TestUCUMCanonicalCached(t *testing.T) {
+	c1, err := ucumCanonicalUnit("mg/dL")
+	assert.NoError(t, err, "no error expected")
+	c2, err := ucumCanonicalUnit("mg/dL")
+	assert.NoError(t, err, "no error expected")
+	assert.Same(t, c1, c2)
+}
+
+func TestUCUMUnitTable(t *testing.T) {
+	for _, u := range ucumUnits {
+		if u.special || u.arbitrary {
+			continue
+		}
+		_, err := ucumCanonicalUnit(u.code)
+		assert.NoError(t, err, "no error expected for %s", u.code)
+	}
+}
+
+func TestUCUMCalc(t *testing.T) {
+	tests := []struct {
+		unit1    string
+		unit2    string
+		op       ArithmeticOps
+		expected string
+	}{
+		{"m", "m", MultiplicationOp, "m2"},
+		{"mg", "mL", DivisionOp, "mg/mL"},
+		{"kg", "m2", DivisionOp, "kg/m2"},
+		{"mg/kg", "d", DivisionOp, "mg/kg/d"},
+		{"mg/kg/d", "kg", MultiplicationOp, "mg/d"},
+		{"m2", "m2", DivisionOp, "1"},
+		{"1", "s", DivisionOp, "/s"},
+		{"10*3/uL", "uL", MultiplicationOp, "10*3"},
+		{"{cells}", "uL", DivisionOp, "{cells}/uL"},
+		{"kg.m/s2", "m", MultiplicationOp, "kg.m2/s2"},
+		{"/(24.h)", "1", MultiplicationOp, "/24/h"},
+	}
+	for _, test := range tests {
+		t.Run(test.unit1+string(test.op)+test.unit2, func(t *testing.T) {
+			value, unit, err := ucumCalc(decimal.New(1, 0), test.unit1, decimal.New(1, 0), test.unit2, test.op)
+			if assert.NoError(t, err, "no error expected") && assert.NotNil(t, value) {
+				assert.Equal(t, test.expected, unit)
+				_, err = ucumCanonicalUnit(unit)
+				assert.NoError(t, err, "resulting unit must be valid")
+			}
+		})
+	}
+}
+
+func TestUCUMCalcInvalidUnit(t *testing.T) {
+	_, _, err := ucumCalc(decimal.New(1, 0), "xyz", decimal.New(1, 0), "m", MultiplicationOp)
+	assert.Error(t, err, "error expected")
+	_, _, err = ucumCalc(decimal.New(1, 0), "m", decimal.New(1, 0), "xyz", MultiplicationOp)
+	assert.Error(t, err, "error expected")
+}
+
+func TestUCUMCalcNotSupportedOp(t *testing.T) {
+	_, _, err := ucumCalc(decimal.New(1, 0), "m", decimal.New(1, 0), "m", AdditionOp)
+	assert.Error(t, err, "error expected")
+}
@@ -32,7 +32,7 @@ expression
         | expression 'and' expression                               #andExpression
         | expression ('or' | 'xor') expression                      #orExpression
         | expression 'implies' expression                           #impliesExpression
-        //| (IDENTIFIER)? '=>' expression                             #lambdaExpression
+        | lambdaParameters '=>' expression                          #lambdaExpression
         ;
 
 term
@@ -110,6 +110,10 @@ identifier
         | 'is'
         ;
 
+lambdaParameters
+        : (identifier | '(' (identifier (',' identifier)*)? ')')?
+        ;
+
 
 /****************************************************************
     Lexical rules
@@ -175,6 +179,10 @@ LINE_COMMENT
         : '//' ~[\r\n]* -> channel(HIDDEN)
         ;
 
+LAMBDA_ARROW
+        : '=>'
+        ;
+
 fragment ESC
         : '\\' ([`'\\/fnrt] | UNICODE)    // allow \`, \', \\, \/, \f, etc. and \uXXX
         ;
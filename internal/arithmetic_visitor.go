@@ -29,7 +29,6 @@
 package internal
 
 import (
-	"fmt"
 	"github.com/antlr/antlr4/runtime/Go/antlr"
 	"github.com/healthiop/hipath/hipathsys"
 	"github.com/healthiop/hipath/internal/expression"
@@ -66,7 +65,7 @@ func visitArithmeticExpression(_ antlr.ParserRuleContext, args []interface{}) (h
 	case "&":
 		return expression.NewStringConcatExpression(leftEvaluator, rightEvaluator), nil
 	default:
-		return nil, fmt.Errorf("unsupported arithmetic oparator: %s", stringOp)
+		return nil, hipathsys.NewCodedError(hipathsys.NotSupportedErrorCode, "unsupported arithmetic oparator: %s", stringOp)
 	}
 
 	return expression.NewArithmeticExpression(leftEvaluator, op, rightEvaluator), nil
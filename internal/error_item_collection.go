@@ -46,6 +46,11 @@ func (c *ErrorItemCollection) AddError(line int, column int, msg string) {
 	c.items = append(c.items, item)
 }
 
+func (c *ErrorItemCollection) AddErrorWithCode(line int, column int, msg string, code hipathsys.ErrorCode) {
+	item := hipathsys.NewErrorItemWithCode(line, column, msg, code)
+	c.items = append(c.items, item)
+}
+
 func (c *ErrorItemCollection) HasErrors() bool {
 	return len(c.items) > 0
 }
@@ -30,8 +30,11 @@ package expression
 
 import (
 	"github.com/healthiop/hipath/hipathsys"
+	"math"
 )
 
+const maxDecimalTrimPrecision = 16
+
 type aggregateFunction struct {
 	hipathsys.BaseFunction
 }
@@ -59,6 +62,9 @@ func (f *aggregateFunction) Execute(ctx hipathsys.ContextAccessor, node interfac
 		}
 		count := col.Count()
 		for i := 0; i < count; i++ {
+			if err := checkEvaluationStep(ctx); err != nil {
+				return nil, err
+			}
 			this := col.Get(i)
 			loop.IncIndex(this)
 
@@ -72,3 +78,198 @@ func (f *aggregateFunction) Execute(ctx hipathsys.ContextAccessor, node interfac
 
 	return loop.Total(), nil
 }
+
+type sumFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newSumFunction() *sumFunction {
+	return &sumFunction{
+		BaseFunction: hipathsys.NewBaseFunction("sum", -1, 0, 0),
+	}
+}
+
+func (f *sumFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}, _ []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	sum, count, err := sumValues(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return hipathsys.NewInteger(0), nil
+	}
+	if sum == nil {
+		return nil, nil
+	}
+	return sum, nil
+}
+
+type avgFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newAvgFunction() *avgFunction {
+	return &avgFunction{
+		BaseFunction: hipathsys.NewBaseFunction("avg", -1, 0, 0),
+	}
+}
+
+func (f *avgFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}, _ []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	sum, count, err := sumValues(ctx, node)
+	if err != nil || sum == nil {
+		return nil, err
+	}
+	// the division by an integer would be performed with float64 values
+	avg, err := sum.(hipathsys.ArithmeticApplier).Calc(hipathsys.NewDecimalInt(int32(count)), hipathsys.DivisionOp)
+	if d, ok := avg.(hipathsys.DecimalAccessor); ok {
+		return trimDecimal(d), err
+	}
+	return avg, err
+}
+
+// trimDecimal returns the specified decimal without the insignificant trailing
+// zeros that result from the fixed precision of the decimal division.
+func trimDecimal(d hipathsys.DecimalAccessor) hipathsys.NumberAccessor {
+	for precision := int32(0); precision < maxDecimalTrimPrecision; precision++ {
+		if t := d.Truncate(precision); t.Decimal().Equal(d.Decimal()) {
+			return t
+		}
+	}
+	return d
+}
+
+func sumValues(ctx hipathsys.ContextAccessor, node interface{}) (hipathsys.DecimalValueAccessor, int, error) {
+	col, err := wrapCollection(ctx, node)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var sum hipathsys.DecimalValueAccessor
+	count := col.Count()
+	for i := 0; i < count; i++ {
+		if err := checkEvaluationStep(ctx); err != nil {
+			return nil, 0, err
+		}
+		value, ok := col.Get(i).(hipathsys.DecimalValueAccessor)
+		if _, applier := value.(hipathsys.ArithmeticApplier); !ok || !applier {
+			return nil, 0, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "sum requires numbers or quantities: %T", col.Get(i))
+		}
+		if sum == nil {
+			sum = value
+			continue
+		}
+
+		if value, err = quantityWithUnitOf(value, sum); err != nil {
+			return nil, 0, err
+		}
+		if err = checkIntegerAddition(sum, value); err != nil {
+			return nil, 0, err
+		}
+		if sum, err = sum.(hipathsys.ArithmeticApplier).Calc(value, hipathsys.AdditionOp); err != nil {
+			return nil, 0, err
+		}
+		if sum == nil {
+			return nil, count, nil
+		}
+	}
+	return sum, count, nil
+}
+
+// checkIntegerAddition returns an error if the addition of the specified
+// values exceeds the range of integer values.
+func checkIntegerAddition(value1 hipathsys.DecimalValueAccessor, value2 hipathsys.DecimalValueAccessor) error {
+	i1, ok1 := value1.(hipathsys.IntegerAccessor)
+	i2, ok2 := value2.(hipathsys.IntegerAccessor)
+	if !ok1 || !ok2 {
+		return nil
+	}
+
+	sum := int64(i1.Primitive()) + int64(i2.Primitive())
+	if sum > math.MaxInt32 || sum < math.MinInt32 {
+		return hipathsys.NewCodedError(hipathsys.InvalidValueErrorCode, "sum exceeds the range of integer values: %d", sum)
+	}
+	return nil
+}
+
+func quantityWithUnitOf(value hipathsys.DecimalValueAccessor, other hipathsys.DecimalValueAccessor) (hipathsys.DecimalValueAccessor, error) {
+	q1, ok1 := value.(hipathsys.QuantityAccessor)
+	q2, ok2 := other.(hipathsys.QuantityAccessor)
+	if !ok1 || !ok2 || q1.Unit() == nil || q2.Unit() == nil || hipathsys.Equal(q1.Unit(), q2.Unit()) {
+		return value, nil
+	}
+
+	q := q1.ToUnit(q2.Unit())
+	if q == nil {
+		return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "quantity units are not compatible: %s, %s", q1.Unit(), q2.Unit())
+	}
+	return q, nil
+}
+
+type minFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newMinFunction() *minFunction {
+	return &minFunction{
+		BaseFunction: hipathsys.NewBaseFunction("min", -1, 0, 0),
+	}
+}
+
+func (f *minFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}, _ []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	return extremeValue(ctx, node, -1)
+}
+
+type maxFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newMaxFunction() *maxFunction {
+	return &maxFunction{
+		BaseFunction: hipathsys.NewBaseFunction("max", -1, 0, 0),
+	}
+}
+
+func (f *maxFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}, _ []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	return extremeValue(ctx, node, 1)
+}
+
+// extremeValue returns the item of the collection whose comparison with all
+// other items results in the specified order. If two items cannot be compared
+// with each other (e.g. dates with different precisions) the result is empty.
+func extremeValue(ctx hipathsys.ContextAccessor, node interface{}, order int) (interface{}, error) {
+	col, err := wrapCollection(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+
+	var extreme hipathsys.Comparator
+	count := col.Count()
+	for i := 0; i < count; i++ {
+		if err := checkEvaluationStep(ctx); err != nil {
+			return nil, err
+		}
+		value, ok := col.Get(i).(hipathsys.Comparator)
+		if !ok {
+			return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "value cannot be compared: %T", col.Get(i))
+		}
+		if extreme == nil {
+			extreme = value
+			continue
+		}
+
+		res, status := value.Compare(extreme)
+		if status == hipathsys.Empty {
+			return nil, nil
+		}
+		if status != hipathsys.Evaluated {
+			return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "values cannot be compared: %T <> %T", value, extreme)
+		}
+		if res == order {
+			extreme = value
+		}
+	}
+
+	if extreme == nil {
+		return nil, nil
+	}
+	return extreme, nil
+}
@@ -32,15 +32,16 @@ import (
 	"github.com/healthiop/hipath/hipathsys"
 	"github.com/healthiop/hipath/internal/test"
 	"github.com/stretchr/testify/assert"
+	"math"
 	"testing"
 )
 
 func TestAggregatePathFuncEvaluatorNil(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewInteger(10))
-	col.MustAdd(hipathsys.NewInteger(11))
-	col.MustAdd(hipathsys.NewInteger(14))
+	col.Add(hipathsys.NewInteger(10))
+	col.Add(hipathsys.NewInteger(11))
+	col.Add(hipathsys.NewInteger(14))
 
 	f := newAggregateFunction()
 	res, err := f.Execute(ctx, col, []interface{}{nil}, hipathsys.NewLoop(nil))
@@ -70,7 +71,7 @@ func TestAggregatePathFuncNodeError(t *testing.T) {
 func TestAggregatePathFuncEvaluatorErr(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewInteger(10))
+	col.Add(hipathsys.NewInteger(10))
 
 	loopEvaluator := newTestErrorExpression()
 
@@ -83,9 +84,9 @@ func TestAggregatePathFuncEvaluatorErr(t *testing.T) {
 func TestAggregatePathFuncTotal(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewInteger(10))
-	col.MustAdd(hipathsys.NewInteger(11))
-	col.MustAdd(hipathsys.NewInteger(14))
+	col.Add(hipathsys.NewInteger(10))
+	col.Add(hipathsys.NewInteger(11))
+	col.Add(hipathsys.NewInteger(14))
 
 	loopEvaluator := NewArithmeticExpression(
 		NewTotalInvocation(), hipathsys.AdditionOp, NewThisInvocation())
@@ -101,9 +102,9 @@ func TestAggregatePathFuncTotal(t *testing.T) {
 func TestAggregatePathFuncIndex(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewInteger(10))
-	col.MustAdd(hipathsys.NewInteger(11))
-	col.MustAdd(hipathsys.NewInteger(14))
+	col.Add(hipathsys.NewInteger(10))
+	col.Add(hipathsys.NewInteger(11))
+	col.Add(hipathsys.NewInteger(14))
 
 	loopEvaluator := NewArithmeticExpression(
 		NewIndexInvocation(), hipathsys.AdditionOp, NewThisInvocation())
@@ -143,3 +144,279 @@ func TestAggregatePathFuncColEmptyTotal(t *testing.T) {
 		assert.Equal(t, hipathsys.NewInteger(17), res)
 	}
 }
+
+func newAggregateTestCollection(ctx hipathsys.ContextAccessor, items ...interface{}) hipathsys.CollectionAccessor {
+	col := ctx.NewCollection()
+	for _, item := range items {
+		col.Add(item)
+	}
+	return col
+}
+
+func TestSumPathFuncEmpty(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newSumFunction()
+	res, err := f.Execute(ctx, nil, nil, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.NewInteger(0), res)
+}
+
+func TestSumPathFuncInteger(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	col := newAggregateTestCollection(ctx, hipathsys.NewInteger(10),
+		hipathsys.NewInteger(11), hipathsys.NewInteger(14))
+
+	f := newSumFunction()
+	res, err := f.Execute(ctx, col, nil, nil)
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.IntegerAccessor)(nil), res) {
+		assert.Equal(t, int32(35), res.(hipathsys.IntegerAccessor).Int())
+	}
+}
+
+func TestSumPathFuncIntegerOverflow(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	col := newAggregateTestCollection(ctx, hipathsys.NewInteger(math.MaxInt32),
+		hipathsys.NewInteger(1))
+
+	f := newSumFunction()
+	res, err := f.Execute(ctx, col, nil, nil)
+	assert.Error(t, err, "error expected")
+	assert.Equal(t, hipathsys.InvalidValueErrorCode, hipathsys.ErrorCodeOf(err))
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestSumPathFuncIntegerUnderflow(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	col := newAggregateTestCollection(ctx, hipathsys.NewInteger(math.MinInt32),
+		hipathsys.NewInteger(-1))
+
+	f := newSumFunction()
+	res, err := f.Execute(ctx, col, nil, nil)
+	assert.Error(t, err, "error expected")
+	assert.Equal(t, hipathsys.InvalidValueErrorCode, hipathsys.ErrorCodeOf(err))
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestSumPathFuncDecimal(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	col := newAggregateTestCollection(ctx, hipathsys.NewInteger(10),
+		hipathsys.NewDecimalFloat64(1.5))
+
+	f := newSumFunction()
+	res, err := f.Execute(ctx, col, nil, nil)
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.DecimalAccessor)(nil), res) {
+		assert.Equal(t, 11.5, res.(hipathsys.DecimalAccessor).Float64())
+	}
+}
+
+func TestSumPathFuncQuantity(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	col := newAggregateTestCollection(ctx,
+		hipathsys.NewQuantity(hipathsys.NewDecimalInt(2), hipathsys.NewString("m")),
+		hipathsys.NewQuantity(hipathsys.NewDecimalInt(50), hipathsys.NewString("cm")))
+
+	f := newSumFunction()
+	res, err := f.Execute(ctx, col, nil, nil)
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.QuantityAccessor)(nil), res) {
+		q := res.(hipathsys.QuantityAccessor)
+		assert.Equal(t, 2.5, q.Value().Float64())
+		assert.Equal(t, hipathsys.NewString("m"), q.Unit())
+	}
+}
+
+func TestSumPathFuncQuantityIncompatible(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	col := newAggregateTestCollection(ctx,
+		hipathsys.NewQuantity(hipathsys.NewDecimalInt(2), hipathsys.NewString("m")),
+		hipathsys.NewQuantity(hipathsys.NewDecimalInt(50), hipathsys.NewString("g")))
+
+	f := newSumFunction()
+	res, err := f.Execute(ctx, col, nil, nil)
+	assert.Error(t, err, "error expected")
+	assert.Equal(t, hipathsys.TypeMismatchErrorCode, hipathsys.ErrorCodeOf(err))
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestSumPathFuncNotNumber(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	col := newAggregateTestCollection(ctx, hipathsys.NewInteger(10),
+		hipathsys.NewString("11"))
+
+	f := newSumFunction()
+	res, err := f.Execute(ctx, col, nil, nil)
+	assert.Error(t, err, "error expected")
+	assert.Equal(t, hipathsys.TypeMismatchErrorCode, hipathsys.ErrorCodeOf(err))
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestSumPathFuncNodeError(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newSumFunction()
+	res, err := f.Execute(ctx, test.NewTestModelErrorNode(), nil, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestAvgPathFuncEmpty(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newAvgFunction()
+	res, err := f.Execute(ctx, nil, nil, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestAvgPathFuncInteger(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	col := newAggregateTestCollection(ctx, hipathsys.NewInteger(10),
+		hipathsys.NewInteger(11))
+
+	f := newAvgFunction()
+	res, err := f.Execute(ctx, col, nil, nil)
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.DecimalAccessor)(nil), res) {
+		assert.Equal(t, 10.5, res.(hipathsys.DecimalAccessor).Float64())
+	}
+}
+
+func TestAvgPathFuncIntegerDecimal(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	col := newAggregateTestCollection(ctx, hipathsys.NewInteger(1),
+		hipathsys.NewInteger(2), hipathsys.NewInteger(4))
+
+	f := newAvgFunction()
+	res, err := f.Execute(ctx, col, nil, nil)
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.DecimalAccessor)(nil), res) {
+		assert.Equal(t, "2.3333333333333333", res.(hipathsys.DecimalAccessor).String())
+	}
+}
+
+func TestAvgPathFuncQuantity(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	col := newAggregateTestCollection(ctx,
+		hipathsys.NewQuantity(hipathsys.NewDecimalInt(1), hipathsys.NewString("g")),
+		hipathsys.NewQuantity(hipathsys.NewDecimalInt(2000), hipathsys.NewString("mg")))
+
+	f := newAvgFunction()
+	res, err := f.Execute(ctx, col, nil, nil)
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.QuantityAccessor)(nil), res) {
+		q := res.(hipathsys.QuantityAccessor)
+		assert.Equal(t, 1.5, q.Value().Float64())
+		assert.Equal(t, hipathsys.NewString("g"), q.Unit())
+	}
+}
+
+func TestAvgPathFuncError(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	col := newAggregateTestCollection(ctx, hipathsys.True)
+
+	f := newAvgFunction()
+	res, err := f.Execute(ctx, col, nil, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestMinPathFuncEmpty(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newMinFunction()
+	res, err := f.Execute(ctx, nil, nil, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestMinPathFuncNumber(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	col := newAggregateTestCollection(ctx, hipathsys.NewInteger(10),
+		hipathsys.NewDecimalFloat64(9.5), hipathsys.NewInteger(11))
+
+	f := newMinFunction()
+	res, err := f.Execute(ctx, col, nil, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Same(t, col.Get(1), res)
+}
+
+func TestMaxPathFuncNumber(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	col := newAggregateTestCollection(ctx, hipathsys.NewInteger(10),
+		hipathsys.NewDecimalFloat64(9.5), hipathsys.NewInteger(11))
+
+	f := newMaxFunction()
+	res, err := f.Execute(ctx, col, nil, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Same(t, col.Get(2), res)
+}
+
+func TestMaxPathFuncString(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	col := newAggregateTestCollection(ctx, hipathsys.NewString("b"),
+		hipathsys.NewString("c"), hipathsys.NewString("a"))
+
+	f := newMaxFunction()
+	res, err := f.Execute(ctx, col, nil, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.NewString("c"), res)
+}
+
+func TestMinPathFuncQuantity(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	col := newAggregateTestCollection(ctx,
+		hipathsys.NewQuantity(hipathsys.NewDecimalInt(1), hipathsys.NewString("g")),
+		hipathsys.NewQuantity(hipathsys.NewDecimalInt(900), hipathsys.NewString("mg")))
+
+	f := newMinFunction()
+	res, err := f.Execute(ctx, col, nil, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Same(t, col.Get(1), res)
+}
+
+func TestMinPathFuncDatePrecision(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	d1, _ := hipathsys.ParseDate("2020-03")
+	d2, _ := hipathsys.ParseDate("2020-03-01")
+	col := newAggregateTestCollection(ctx, d1, d2)
+
+	f := newMinFunction()
+	res, err := f.Execute(ctx, col, nil, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestMinPathFuncNotComparable(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	col := newAggregateTestCollection(ctx, hipathsys.NewInteger(10),
+		hipathsys.NewString("a"))
+
+	f := newMinFunction()
+	res, err := f.Execute(ctx, col, nil, nil)
+	assert.Error(t, err, "error expected")
+	assert.Equal(t, hipathsys.TypeMismatchErrorCode, hipathsys.ErrorCodeOf(err))
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestMaxPathFuncNoComparator(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	col := newAggregateTestCollection(ctx, newTestingType(t))
+
+	f := newMaxFunction()
+	res, err := f.Execute(ctx, col, nil, nil)
+	assert.Error(t, err, "error expected")
+	assert.Equal(t, hipathsys.TypeMismatchErrorCode, hipathsys.ErrorCodeOf(err))
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestMaxPathFuncNodeError(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newMaxFunction()
+	res, err := f.Execute(ctx, test.NewTestModelErrorNode(), nil, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "empty result expected")
+}
@@ -28,22 +28,24 @@
 
 package expression
 
-import (
-	"fmt"
-	"github.com/healthiop/hipath/hipathsys"
-)
+import "github.com/healthiop/hipath/hipathsys"
 
 type ArithmeticExpression struct {
 	evalLeft  hipathsys.Evaluator
 	op        hipathsys.ArithmeticOps
 	evalRight hipathsys.Evaluator
+	sourceNode
 }
 
 func NewArithmeticExpression(evalLeft hipathsys.Evaluator, op hipathsys.ArithmeticOps, evalRight hipathsys.Evaluator) *ArithmeticExpression {
-	return &ArithmeticExpression{evalLeft, op, evalRight}
+	return &ArithmeticExpression{evalLeft, op, evalRight, sourceNode{}}
 }
 
 func (e *ArithmeticExpression) Evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
+	return e.sourceResult(e.evaluate(ctx, node, loop))
+}
+
+func (e *ArithmeticExpression) evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
 	left, err := e.evalLeft.Evaluate(ctx, node, loop)
 	if err != nil {
 		return nil, err
@@ -88,7 +90,7 @@ func applyNonNumberArithmetic(left interface{}, op hipathsys.ArithmeticOps, righ
 		}
 	}
 
-	return nil, fmt.Errorf("operands %T and %T do not support arithmetic operation %c", left, op, right)
+	return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "operands %T and %T do not support arithmetic operation %c", left, op, right)
 }
 
 func applyStringArithmetic(left, right interface{}) hipathsys.StringAccessor {
@@ -112,7 +114,7 @@ func applyTemporalArithmetic(left, right interface{}, negate bool) (hipathsys.Te
 		return nil, nil
 	}
 	if quantity, ok = right.(hipathsys.QuantityAccessor); !ok {
-		return nil, fmt.Errorf("only a quantity may be added to a temporal value: %T", right)
+		return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "only a quantity may be added to a temporal value: %T", right)
 	}
 
 	if negate {
@@ -46,62 +46,107 @@ type BooleanExpression struct {
 	evalLeft  hipathsys.Evaluator
 	op        BooleanOp
 	evalRight hipathsys.Evaluator
+	sourceNode
 }
 
 func NewBooleanExpression(evalLeft hipathsys.Evaluator, op BooleanOp, evalRight hipathsys.Evaluator) *BooleanExpression {
-	return &BooleanExpression{evalLeft, op, evalRight}
+	return &BooleanExpression{evalLeft, op, evalRight, sourceNode{}}
 }
 
 func (e *BooleanExpression) Evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
-	left, err := e.evalLeft.Evaluate(ctx, node, loop)
-	if err != nil {
-		return nil, err
-	}
-	right, err := e.evalRight.Evaluate(ctx, node, loop)
-	if err != nil {
-		return nil, err
+	return e.sourceResult(e.evaluate(ctx, node, loop))
+}
+
+func (e *BooleanExpression) evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
+	leftBool, leftErr := evaluateBooleanOperand(ctx, e.evalLeft, node, loop)
+	if leftErr != nil {
+		if !hipathsys.LazyErrors(ctx) {
+			return nil, leftErr
+		}
+	} else if res, ok := e.leftResult(leftBool); ok {
+		return res, nil
 	}
 
-	leftBool, err := unwrapBooleanCollection(left)
-	if err != nil {
-		return nil, err
+	rightBool, err := evaluateBooleanOperand(ctx, e.evalRight, node, loop)
+	if leftErr != nil {
+		// result can only be determined if the right operand decides it
+		if err == nil {
+			if res, ok := e.rightResult(rightBool); ok {
+				return res, nil
+			}
+		}
+		return nil, leftErr
 	}
-	rightBool, err := unwrapBooleanCollection(right)
 	if err != nil {
+		// an empty or false left operand suppresses a lazy error of the right
+		// operand, since the right operand is a guarded expression then
+		if hipathsys.LazyErrors(ctx) && (leftBool == nil || !leftBool.Bool()) {
+			return nil, nil
+		}
 		return nil, err
 	}
 
-	if leftBool == nil && rightBool == nil {
+	if res, ok := e.rightResult(rightBool); ok {
+		return res, nil
+	}
+	if leftBool == nil || rightBool == nil {
 		return nil, nil
 	}
 
-	if e.op == ImpliesOp {
-		if leftBool == nil {
-			if rightBool.Bool() {
-				return hipathsys.True, nil
-			}
-			return nil, nil
-		}
-		if leftBool.Bool() {
-			return rightBool, nil
-		}
-		return hipathsys.True, nil
-	} else {
-		if leftBool == nil || rightBool == nil {
-			return nil, nil
-		}
+	switch e.op {
+	case AndOp:
+		return hipathsys.BooleanOf(leftBool.Bool() && rightBool.Bool()), nil
+	case OrOp:
+		return hipathsys.BooleanOf(leftBool.Bool() || rightBool.Bool()), nil
+	case XOrOp:
+		return hipathsys.BooleanOf(leftBool.Bool() != rightBool.Bool()), nil
+	case ImpliesOp:
+		return rightBool, nil
+	default:
+		panic(fmt.Sprintf("unhandled boolean operator: %d", e.op))
+	}
+}
 
-		switch e.op {
-		case AndOp:
-			return hipathsys.BooleanOf(leftBool.Bool() && rightBool.Bool()), nil
-		case OrOp:
-			return hipathsys.BooleanOf(leftBool.Bool() || rightBool.Bool()), nil
-		case XOrOp:
-			return hipathsys.BooleanOf(leftBool.Bool() != rightBool.Bool()), nil
-		default:
-			panic(fmt.Sprintf("unhandled boolean operator: %d", e.op))
-		}
+// leftResult returns the result of the operator if it is already determined
+// by the left operand, so that the right operand needs not to be evaluated.
+func (e *BooleanExpression) leftResult(b hipathsys.BooleanAccessor) (interface{}, bool) {
+	if b == nil {
+		return nil, false
+	}
+
+	switch e.op {
+	case AndOp:
+		return hipathsys.False, !b.Bool()
+	case OrOp:
+		return hipathsys.True, b.Bool()
+	case ImpliesOp:
+		return hipathsys.True, !b.Bool()
+	}
+	return nil, false
+}
+
+// rightResult returns the result of the operator if it is determined by the
+// right operand independently of the value of the left operand.
+func (e *BooleanExpression) rightResult(b hipathsys.BooleanAccessor) (interface{}, bool) {
+	if b == nil {
+		return nil, false
+	}
+
+	switch e.op {
+	case AndOp:
+		return hipathsys.False, !b.Bool()
+	case OrOp, ImpliesOp:
+		return hipathsys.True, b.Bool()
+	}
+	return nil, false
+}
+
+func evaluateBooleanOperand(ctx hipathsys.ContextAccessor, evaluator hipathsys.Evaluator, node interface{}, loop hipathsys.Looper) (hipathsys.BooleanAccessor, error) {
+	res, err := evaluator.Evaluate(ctx, node, loop)
+	if err != nil {
+		return nil, err
 	}
+	return unwrapBooleanCollection(res)
 }
 
 func unwrapBooleanCollection(node interface{}) (hipathsys.BooleanAccessor, error) {
@@ -114,7 +159,7 @@ func unwrapBooleanCollection(node interface{}) (hipathsys.BooleanAccessor, error
 			return nil, nil
 		}
 		if col.Count() > 1 {
-			return nil, fmt.Errorf("multi-valued collection cannot be converted to a boolean")
+			return nil, hipathsys.NewCodedError(hipathsys.SingletonErrorCode, "multi-valued collection cannot be converted to a boolean")
 		}
 
 		v := col.Get(0)
@@ -131,5 +176,5 @@ func unwrapBooleanCollection(node interface{}) (hipathsys.BooleanAccessor, error
 		return b, nil
 	}
 
-	return nil, fmt.Errorf("value cannot be converted to a boolean: %T", node)
+	return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "value cannot be converted to a boolean: %T", node)
 }
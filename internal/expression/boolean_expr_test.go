@@ -50,14 +50,18 @@ var booleanTests = []struct {
 	{"andEmptyEmpty", AndOp, NewEmptyLiteral(), NewEmptyLiteral(), nil, false},
 	{"andEmptyTrue", AndOp, NewEmptyLiteral(), NewBooleanLiteral(true), nil, false},
 	{"andTrueEmpty", AndOp, NewBooleanLiteral(true), NewEmptyLiteral(), nil, false},
+	{"andEmptyFalse", AndOp, NewEmptyLiteral(), NewBooleanLiteral(false), hipathsys.False, false},
+	{"andFalseEmpty", AndOp, NewBooleanLiteral(false), NewEmptyLiteral(), hipathsys.False, false},
 
 	{"orFalseFalse", OrOp, NewBooleanLiteral(false), NewBooleanLiteral(false), hipathsys.False, false},
 	{"orFalseTrue", OrOp, NewBooleanLiteral(false), NewBooleanLiteral(true), hipathsys.True, false},
 	{"orTrueFalse", OrOp, NewBooleanLiteral(true), NewBooleanLiteral(false), hipathsys.True, false},
 	{"orTrueTrue", OrOp, NewBooleanLiteral(true), NewBooleanLiteral(true), hipathsys.True, false},
 	{"orEmptyEmpty", OrOp, NewEmptyLiteral(), NewEmptyLiteral(), nil, false},
-	{"orEmptyTrue", OrOp, NewEmptyLiteral(), NewBooleanLiteral(true), nil, false},
-	{"orTrueEmpty", OrOp, NewBooleanLiteral(true), NewEmptyLiteral(), nil, false},
+	{"orEmptyTrue", OrOp, NewEmptyLiteral(), NewBooleanLiteral(true), hipathsys.True, false},
+	{"orTrueEmpty", OrOp, NewBooleanLiteral(true), NewEmptyLiteral(), hipathsys.True, false},
+	{"orEmptyFalse", OrOp, NewEmptyLiteral(), NewBooleanLiteral(false), nil, false},
+	{"orFalseEmpty", OrOp, NewBooleanLiteral(false), NewEmptyLiteral(), nil, false},
 
 	{"xorFalseFalse", XOrOp, NewBooleanLiteral(false), NewBooleanLiteral(false), hipathsys.False, false},
 	{"xorFalseTrue", XOrOp, NewBooleanLiteral(false), NewBooleanLiteral(true), hipathsys.True, false},
@@ -78,7 +82,13 @@ var booleanTests = []struct {
 	{"impliesFalseEmpty", ImpliesOp, NewBooleanLiteral(false), NewEmptyLiteral(), hipathsys.True, false},
 
 	{"leftError", AndOp, newTestErrorExpression(), NewBooleanLiteral(false), nil, true},
-	{"rightError", AndOp, NewBooleanLiteral(false), newTestErrorExpression(), nil, true},
+	{"rightError", AndOp, NewBooleanLiteral(true), newTestErrorExpression(), nil, true},
+	{"xorRightError", XOrOp, NewBooleanLiteral(false), newTestErrorExpression(), nil, true},
+	{"andShortCircuit", AndOp, NewBooleanLiteral(false), newTestErrorExpression(), hipathsys.False, false},
+	{"orShortCircuit", OrOp, NewBooleanLiteral(true), newTestErrorExpression(), hipathsys.True, false},
+	{"impliesShortCircuit", ImpliesOp, NewBooleanLiteral(false), newTestErrorExpression(), hipathsys.True, false},
+	{"andEmptyRightError", AndOp, NewEmptyLiteral(), newTestErrorExpression(), nil, true},
+	{"orLeftError", OrOp, newTestErrorExpression(), NewBooleanLiteral(true), nil, true},
 }
 
 func TestBooleanExpression(t *testing.T) {
@@ -104,6 +114,56 @@ func TestBooleanExpression(t *testing.T) {
 	}
 }
 
+var booleanLazyErrorTests = []struct {
+	name      string
+	op        BooleanOp
+	leftEval  hipathsys.Evaluator
+	rightEval hipathsys.Evaluator
+	result    hipathsys.AnyAccessor
+	error     bool
+}{
+	{"andLeftErrorFalse", AndOp, newTestErrorExpression(), NewBooleanLiteral(false), hipathsys.False, false},
+	{"andLeftErrorTrue", AndOp, newTestErrorExpression(), NewBooleanLiteral(true), nil, true},
+	{"orLeftErrorTrue", OrOp, newTestErrorExpression(), NewBooleanLiteral(true), hipathsys.True, false},
+	{"orLeftErrorFalse", OrOp, newTestErrorExpression(), NewBooleanLiteral(false), nil, true},
+	{"impliesLeftErrorTrue", ImpliesOp, newTestErrorExpression(), NewBooleanLiteral(true), hipathsys.True, false},
+	{"impliesLeftErrorEmpty", ImpliesOp, newTestErrorExpression(), NewEmptyLiteral(), nil, true},
+	{"xorLeftError", XOrOp, newTestErrorExpression(), NewBooleanLiteral(true), nil, true},
+	{"bothError", AndOp, newTestErrorExpression(), newTestErrorExpression(), nil, true},
+	{"rightError", AndOp, NewBooleanLiteral(true), newTestErrorExpression(), nil, true},
+	{"orShortCircuit", OrOp, NewBooleanLiteral(true), newTestErrorExpression(), hipathsys.True, false},
+	{"andEmptyRightError", AndOp, NewEmptyLiteral(), newTestErrorExpression(), nil, false},
+	{"orEmptyRightError", OrOp, NewEmptyLiteral(), newTestErrorExpression(), nil, false},
+	{"orFalseRightError", OrOp, NewBooleanLiteral(false), newTestErrorExpression(), nil, false},
+	{"impliesEmptyRightError", ImpliesOp, NewEmptyLiteral(), newTestErrorExpression(), nil, false},
+	{"impliesTrueRightError", ImpliesOp, NewBooleanLiteral(true), newTestErrorExpression(), nil, true},
+	{"xorFalseRightError", XOrOp, NewBooleanLiteral(false), newTestErrorExpression(), nil, false},
+	{"xorTrueRightError", XOrOp, NewBooleanLiteral(true), newTestErrorExpression(), nil, true},
+}
+
+func TestBooleanExpressionLazyErrors(t *testing.T) {
+	for _, tt := range booleanLazyErrorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := test.NewTestContextWithLazyErrors(t)
+
+			eval := NewBooleanExpression(tt.leftEval, tt.op, tt.rightEval)
+			res, err := eval.Evaluate(ctx, nil, nil)
+
+			if tt.error {
+				assert.Error(t, err, "error expected")
+			} else {
+				assert.NoError(t, err, "no error expected")
+			}
+
+			if tt.result == nil {
+				assert.Nil(t, res, "empty result expected")
+			} else {
+				assert.Equal(t, tt.result, res)
+			}
+		})
+	}
+}
+
 func TestBooleanExpressionInvalidOp(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	evaluator := NewBooleanExpression(NewBooleanLiteral(true), 0, NewBooleanLiteral(true))
@@ -116,8 +176,8 @@ func TestBooleanExpressionInvalidOp(t *testing.T) {
 func TestBooleanExpressionMultiColLeft(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	operand := ctx.NewCollection()
-	operand.MustAdd(hipathsys.True)
-	operand.MustAdd(hipathsys.True)
+	operand.Add(hipathsys.True)
+	operand.Add(hipathsys.True)
 
 	evaluator := NewBooleanExpression(newTestExpression(operand), AndOp, NewBooleanLiteral(true))
 	if assert.NotNil(t, evaluator, "evaluator expected") {
@@ -130,8 +190,8 @@ func TestBooleanExpressionMultiColLeft(t *testing.T) {
 func TestBooleanExpressionMultiColRight(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	operand := ctx.NewCollection()
-	operand.MustAdd(hipathsys.True)
-	operand.MustAdd(hipathsys.True)
+	operand.Add(hipathsys.True)
+	operand.Add(hipathsys.True)
 
 	evaluator := NewBooleanExpression(NewBooleanLiteral(true), AndOp, newTestExpression(operand))
 	if assert.NotNil(t, evaluator, "evaluator expected") {
@@ -155,7 +215,7 @@ func TestBooleanExpressionNoBoolean(t *testing.T) {
 func TestBooleanExpressionSingleColString(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	operand := ctx.NewCollection()
-	operand.MustAdd(hipathsys.NewString("test"))
+	operand.Add(hipathsys.NewString("test"))
 
 	evaluator := NewBooleanExpression(newTestExpression(operand), AndOp, NewBooleanLiteral(true))
 	if assert.NotNil(t, evaluator, "evaluator expected") {
@@ -168,7 +228,7 @@ func TestBooleanExpressionSingleColString(t *testing.T) {
 func TestBooleanExpressionSingleColBoolean(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	operand := ctx.NewCollection()
-	operand.MustAdd(hipathsys.False)
+	operand.Add(hipathsys.False)
 
 	evaluator := NewBooleanExpression(newTestExpression(operand), AndOp, NewBooleanLiteral(true))
 	if assert.NotNil(t, evaluator, "evaluator expected") {
@@ -193,7 +253,7 @@ func TestBooleanExpressionEmptyCol(t *testing.T) {
 func TestBooleanExpressionColSingleNil(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	operand := ctx.NewCollection()
-	operand.MustAdd(nil)
+	operand.Add(nil)
 
 	evaluator := NewBooleanExpression(newTestExpression(operand), AndOp, NewBooleanLiteral(true))
 	if assert.NotNil(t, evaluator, "evaluator expected") {
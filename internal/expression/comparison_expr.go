@@ -46,13 +46,18 @@ type ComparisonExpression struct {
 	evalLeft  hipathsys.Evaluator
 	op        ComparisonOp
 	evalRight hipathsys.Evaluator
+	sourceNode
 }
 
 func NewComparisonExpression(evalLeft hipathsys.Evaluator, op ComparisonOp, evalRight hipathsys.Evaluator) *ComparisonExpression {
-	return &ComparisonExpression{evalLeft, op, evalRight}
+	return &ComparisonExpression{evalLeft, op, evalRight, sourceNode{}}
 }
 
 func (e *ComparisonExpression) Evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
+	return e.sourceResult(e.evaluate(ctx, node, loop))
+}
+
+func (e *ComparisonExpression) evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
 	left, err := e.evalLeft.Evaluate(ctx, node, loop)
 	if err != nil {
 		return nil, err
@@ -70,10 +75,10 @@ func (e *ComparisonExpression) Evaluate(ctx hipathsys.ContextAccessor, node inte
 	var ok bool
 	var leftCmp, rightCmp hipathsys.Comparator
 	if leftCmp, ok = left.(hipathsys.Comparator); !ok {
-		return nil, fmt.Errorf("operand cannot be used for comparison: %T", left)
+		return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "operand cannot be used for comparison: %T", left)
 	}
 	if rightCmp, ok = right.(hipathsys.Comparator); !ok {
-		return nil, fmt.Errorf("operand cannot be used for comparison: %T", right)
+		return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "operand cannot be used for comparison: %T", right)
 	}
 
 	res, status := leftCmp.Compare(rightCmp)
@@ -81,7 +86,7 @@ func (e *ComparisonExpression) Evaluate(ctx hipathsys.ContextAccessor, node inte
 		return nil, nil
 	}
 	if status != hipathsys.Evaluated {
-		return nil, fmt.Errorf("operands cannot be compared: %T <> %T", leftCmp, rightCmp)
+		return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "operands cannot be compared: %T <> %T", leftCmp, rightCmp)
 	}
 
 	var b bool
@@ -29,7 +29,6 @@
 package expression
 
 import (
-	"fmt"
 	"github.com/healthiop/hipath/hipathsys"
 	"regexp"
 	"strings"
@@ -50,7 +49,7 @@ func (f *iifFunction) Execute(_ hipathsys.ContextAccessor, _ interface{}, args [
 	var criterionValue bool
 	if criterion != nil {
 		if b, ok := criterion.(hipathsys.BooleanAccessor); !ok {
-			return nil, fmt.Errorf("criterion must be a boolean: %T", criterion)
+			return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "criterion must be a boolean: %T", criterion)
 		} else {
 			criterionValue = b.Bool()
 		}
@@ -337,7 +336,7 @@ func (f *toQuantityFunction) Execute(_ hipathsys.ContextAccessor, node interface
 
 	if q != nil && len(args) > 0 {
 		if s, ok := args[0].(hipathsys.StringAccessor); !ok {
-			return nil, fmt.Errorf("conversion unit is no string: %T", args[0])
+			return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "conversion unit is no string: %T", args[0])
 		} else {
 			q = q.ToUnit(s)
 		}
@@ -461,7 +460,7 @@ func convertibleAny(node interface{}) (hipathsys.AnyAccessor, error) {
 		return nil, nil
 	} else {
 		if _, ok := any.(hipathsys.CollectionAccessor); ok {
-			return nil, fmt.Errorf("collection with multiple items cannot be converted")
+			return nil, hipathsys.NewCodedError(hipathsys.SingletonErrorCode, "collection with multiple items cannot be converted")
 		}
 		return any, nil
 	}
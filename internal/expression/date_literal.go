@@ -28,10 +28,7 @@
 
 package expression
 
-import (
-	"fmt"
-	"github.com/healthiop/hipath/hipathsys"
-)
+import "github.com/healthiop/hipath/hipathsys"
 
 type DateLiteral struct {
 	node hipathsys.DateAccessor
@@ -39,7 +36,7 @@ type DateLiteral struct {
 
 func ParseDateLiteral(value string) (hipathsys.Evaluator, error) {
 	if len(value) < 2 || value[0] != '@' {
-		return nil, fmt.Errorf("invalid date literal: %s", value)
+		return nil, hipathsys.NewCodedError(hipathsys.SyntaxErrorCode, "invalid date literal: %s", value)
 	}
 	if node, err := hipathsys.ParseDate(value[1:]); err != nil {
 		return nil, err
@@ -28,10 +28,7 @@
 
 package expression
 
-import (
-	"fmt"
-	"github.com/healthiop/hipath/hipathsys"
-)
+import "github.com/healthiop/hipath/hipathsys"
 
 type DateTimeLiteral struct {
 	node hipathsys.DateTimeAccessor
@@ -39,7 +36,7 @@ type DateTimeLiteral struct {
 
 func ParseDateTimeLiteral(value string) (hipathsys.Evaluator, error) {
 	if len(value) < 2 || value[0] != '@' {
-		return nil, fmt.Errorf("invalid date/time literal: %s", value)
+		return nil, hipathsys.NewCodedError(hipathsys.SyntaxErrorCode, "invalid date/time literal: %s", value)
 	}
 	if node, err := hipathsys.ParseDateTime(value[1:]); err != nil {
 		return nil, err
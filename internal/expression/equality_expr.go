@@ -37,13 +37,18 @@ type EqualityExpression struct {
 	equivalent bool
 	evalLeft   hipathsys.Evaluator
 	evalRight  hipathsys.Evaluator
+	sourceNode
 }
 
 func NewEqualityExpression(not bool, equivalent bool, evalLeft hipathsys.Evaluator, evalRight hipathsys.Evaluator) *EqualityExpression {
-	return &EqualityExpression{not, equivalent, evalLeft, evalRight}
+	return &EqualityExpression{not, equivalent, evalLeft, evalRight, sourceNode{}}
 }
 
 func (e *EqualityExpression) Evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
+	return e.sourceResult(e.evaluate(ctx, node, loop))
+}
+
+func (e *EqualityExpression) evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
 	res, err := e.evaluateInternally(ctx, node, loop)
 	if err != nil {
 		return nil, err
@@ -28,10 +28,7 @@
 
 package expression
 
-import (
-	"fmt"
-	"github.com/healthiop/hipath/hipathsys"
-)
+import "github.com/healthiop/hipath/hipathsys"
 
 type emptyFunction struct {
 	hipathsys.BaseFunction
@@ -89,6 +86,9 @@ func (f *existsFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}
 		found = count > 0
 	} else {
 		for i := 0; i < count; i++ {
+			if err := checkEvaluationStep(ctx); err != nil {
+				return nil, err
+			}
 			this := col.Get(i)
 			loop.IncIndex(this)
 
@@ -98,7 +98,7 @@ func (f *existsFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}
 			}
 			if res != nil {
 				if b, ok := res.(hipathsys.BooleanAccessor); !ok {
-					return nil, fmt.Errorf("filter expression must return boolean, but returned %T", res)
+					return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "filter expression must return boolean, but returned %T", res)
 				} else if b.Bool() {
 					return hipathsys.True, nil
 				}
@@ -127,6 +127,9 @@ func (f *allFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}, _
 	}
 	count := col.Count()
 	for i := 0; i < count; i++ {
+		if err := checkEvaluationStep(ctx); err != nil {
+			return nil, err
+		}
 		this := col.Get(i)
 		loop.IncIndex(this)
 
@@ -135,7 +138,7 @@ func (f *allFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}, _
 			return nil, err
 		}
 		if b, ok := res.(hipathsys.BooleanAccessor); !ok {
-			return nil, fmt.Errorf("parameter expression must return boolean, but returned %T", res)
+			return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "parameter expression must return boolean, but returned %T", res)
 		} else if !b.Bool() {
 			return hipathsys.False, nil
 		}
@@ -183,7 +186,7 @@ func (f *allAnyTrueFalseFunction) Execute(ctx hipathsys.ContextAccessor, node in
 	for i := 0; i < count; i++ {
 		this := col.Get(i)
 		if b, ok := this.(hipathsys.BooleanAccessor); !ok {
-			return nil, fmt.Errorf("collection must contain only boolean values, but contains %T", this)
+			return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "collection must contain only boolean values, but contains %T", this)
 		} else if f.all && f.t != b.Bool() {
 			return hipathsys.False, nil
 		} else if !f.all && f.t == b.Bool() {
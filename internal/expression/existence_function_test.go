@@ -60,7 +60,7 @@ func TestEmptyPathFuncEmptyCollection(t *testing.T) {
 func TestEmptyPathFuncCollection(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	c := ctx.NewCollection()
-	c.MustAdd(hipathsys.NewString(""))
+	c.Add(hipathsys.NewString(""))
 	f := newEmptyFunction()
 	res, err := f.Execute(nil, c, nil, nil)
 	assert.NoError(t, err, "no error expected")
@@ -84,7 +84,7 @@ func TestExistsPathFuncValueNoFilter(t *testing.T) {
 func TestExistsPathFuncColNoFilter(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	c := ctx.NewCollection()
-	c.MustAdd(hipathsys.NewString("test"))
+	c.Add(hipathsys.NewString("test"))
 	f := newExistsFunction()
 	res, err := f.Execute(nil, c, nil, hipathsys.NewLoop(nil))
 	assert.NoError(t, err, "no error expected")
@@ -133,8 +133,8 @@ func TestExistsPathFuncValueMatchingFilter(t *testing.T) {
 func TestExistsPathFuncColNonMatchingFilter(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	c := ctx.NewCollection()
-	c.MustAdd(hipathsys.NewString("test"))
-	c.MustAdd(hipathsys.NewString("tesT"))
+	c.Add(hipathsys.NewString("test"))
+	c.Add(hipathsys.NewString("tesT"))
 	loopEvaluator := NewEqualityExpression(false, false, NewThisInvocation(), NewRawStringLiteral("Test"))
 
 	f := newExistsFunction()
@@ -146,8 +146,8 @@ func TestExistsPathFuncColNonMatchingFilter(t *testing.T) {
 func TestExistsPathFuncColMatchingFilter(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	c := ctx.NewCollection()
-	c.MustAdd(hipathsys.NewString("test"))
-	c.MustAdd(hipathsys.NewString("Test"))
+	c.Add(hipathsys.NewString("test"))
+	c.Add(hipathsys.NewString("Test"))
 	loopEvaluator := NewEqualityExpression(false, false, NewThisInvocation(), NewRawStringLiteral("Test"))
 
 	f := newExistsFunction()
@@ -238,9 +238,9 @@ func TestAllPathFuncTrue(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	node := ctx.NewCollection()
-	node.MustAdd(hipathsys.NewInteger(0))
-	node.MustAdd(hipathsys.NewInteger(1))
-	node.MustAdd(hipathsys.NewInteger(2))
+	node.Add(hipathsys.NewInteger(0))
+	node.Add(hipathsys.NewInteger(1))
+	node.Add(hipathsys.NewInteger(2))
 
 	loopEval := NewEqualityExpression(false, false,
 		NewThisInvocation(), NewIndexInvocation())
@@ -255,9 +255,9 @@ func TestAllPathFuncFalse(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	node := ctx.NewCollection()
-	node.MustAdd(hipathsys.NewInteger(0))
-	node.MustAdd(hipathsys.NewInteger(2))
-	node.MustAdd(hipathsys.NewInteger(1))
+	node.Add(hipathsys.NewInteger(0))
+	node.Add(hipathsys.NewInteger(2))
+	node.Add(hipathsys.NewInteger(1))
 
 	loopEval := NewEqualityExpression(false, false,
 		NewThisInvocation(), NewIndexInvocation())
@@ -287,8 +287,8 @@ func TestAllPathFuncInvalidType(t *testing.T) {
 func TestAllTruePathFuncTypeError(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.True)
-	col.MustAdd(hipathsys.NewString("test"))
+	col.Add(hipathsys.True)
+	col.Add(hipathsys.NewString("test"))
 
 	f := newAllTrueFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
@@ -327,9 +327,9 @@ func TestAllTruePathFuncNodeError(t *testing.T) {
 func TestAllTruePathFuncTrue(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.True)
-	col.MustAdd(hipathsys.True)
-	col.MustAdd(hipathsys.True)
+	col.Add(hipathsys.True)
+	col.Add(hipathsys.True)
+	col.Add(hipathsys.True)
 
 	f := newAllTrueFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
@@ -340,9 +340,9 @@ func TestAllTruePathFuncTrue(t *testing.T) {
 func TestAllTruePathFuncFalse(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.True)
-	col.MustAdd(hipathsys.False)
-	col.MustAdd(hipathsys.True)
+	col.Add(hipathsys.True)
+	col.Add(hipathsys.False)
+	col.Add(hipathsys.True)
 
 	f := newAllTrueFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
@@ -372,9 +372,9 @@ func TestAnyTruePathFuncEmpty(t *testing.T) {
 func TestAnyTruePathFuncTrue(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.False)
-	col.MustAdd(hipathsys.True)
-	col.MustAdd(hipathsys.False)
+	col.Add(hipathsys.False)
+	col.Add(hipathsys.True)
+	col.Add(hipathsys.False)
 
 	f := newAnyTrueFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
@@ -385,9 +385,9 @@ func TestAnyTruePathFuncTrue(t *testing.T) {
 func TestAnyTruePathFuncFalse(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.False)
-	col.MustAdd(hipathsys.False)
-	col.MustAdd(hipathsys.False)
+	col.Add(hipathsys.False)
+	col.Add(hipathsys.False)
+	col.Add(hipathsys.False)
 
 	f := newAnyTrueFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
@@ -417,9 +417,9 @@ func TestAllFalsePathFuncEmpty(t *testing.T) {
 func TestAllFalsePathFuncTrue(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.False)
-	col.MustAdd(hipathsys.False)
-	col.MustAdd(hipathsys.False)
+	col.Add(hipathsys.False)
+	col.Add(hipathsys.False)
+	col.Add(hipathsys.False)
 
 	f := newAllFalseFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
@@ -430,9 +430,9 @@ func TestAllFalsePathFuncTrue(t *testing.T) {
 func TestAllFalsePathFuncFalse(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.False)
-	col.MustAdd(hipathsys.True)
-	col.MustAdd(hipathsys.False)
+	col.Add(hipathsys.False)
+	col.Add(hipathsys.True)
+	col.Add(hipathsys.False)
 
 	f := newAllFalseFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
@@ -462,9 +462,9 @@ func TestAnyFalsePathFuncEmpty(t *testing.T) {
 func TestAnyFalsePathFuncTrue(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.True)
-	col.MustAdd(hipathsys.False)
-	col.MustAdd(hipathsys.True)
+	col.Add(hipathsys.True)
+	col.Add(hipathsys.False)
+	col.Add(hipathsys.True)
 
 	f := newAnyFalseFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
@@ -475,9 +475,9 @@ func TestAnyFalsePathFuncTrue(t *testing.T) {
 func TestAnyFalsePathFuncFalse(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.True)
-	col.MustAdd(hipathsys.True)
-	col.MustAdd(hipathsys.True)
+	col.Add(hipathsys.True)
+	col.Add(hipathsys.True)
+	col.Add(hipathsys.True)
 
 	f := newAnyFalseFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
@@ -491,7 +491,7 @@ func TestSubsetOfPathFuncNodeError(t *testing.T) {
 	col := test.NewTestModelErrorNode()
 
 	other := ctx.NewCollection()
-	other.MustAdd(hipathsys.NewString("test4"))
+	other.Add(hipathsys.NewString("test4"))
 
 	f := newSubsetOfFunction()
 	res, err := f.Execute(ctx, col, []interface{}{other}, nil)
@@ -505,7 +505,7 @@ func TestSubsetOfPathFuncArgNodeError(t *testing.T) {
 	other := test.NewTestModelErrorNode()
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test4"))
+	col.Add(hipathsys.NewString("test4"))
 
 	f := newSubsetOfFunction()
 	res, err := f.Execute(ctx, col, []interface{}{other}, nil)
@@ -517,15 +517,15 @@ func TestSubsetOfPathFuncTrue(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test3"))
-	col.MustAdd(hipathsys.NewString("test1"))
-	col.MustAdd(hipathsys.NewString("test4"))
+	col.Add(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test4"))
 
 	other := ctx.NewCollection()
-	other.MustAdd(hipathsys.NewString("test4"))
-	other.MustAdd(hipathsys.NewString("test1"))
-	other.MustAdd(hipathsys.NewString("test2"))
-	other.MustAdd(hipathsys.NewString("test3"))
+	other.Add(hipathsys.NewString("test4"))
+	other.Add(hipathsys.NewString("test1"))
+	other.Add(hipathsys.NewString("test2"))
+	other.Add(hipathsys.NewString("test3"))
 
 	f := newSubsetOfFunction()
 	res, err := f.Execute(ctx, col, []interface{}{other}, nil)
@@ -537,15 +537,15 @@ func TestSubsetOfPathFuncFalse(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test3"))
-	col.MustAdd(hipathsys.NewString("test1"))
-	col.MustAdd(hipathsys.NewString("test4"))
+	col.Add(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test4"))
 
 	other := ctx.NewCollection()
-	other.MustAdd(hipathsys.NewString("test4"))
-	other.MustAdd(hipathsys.NewString("test5"))
-	other.MustAdd(hipathsys.NewString("test2"))
-	other.MustAdd(hipathsys.NewString("test3"))
+	other.Add(hipathsys.NewString("test4"))
+	other.Add(hipathsys.NewString("test5"))
+	other.Add(hipathsys.NewString("test2"))
+	other.Add(hipathsys.NewString("test3"))
 
 	f := newSubsetOfFunction()
 	res, err := f.Execute(ctx, col, []interface{}{other}, nil)
@@ -557,13 +557,13 @@ func TestSubsetOfPathFuncFalseLessItems(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test3"))
-	col.MustAdd(hipathsys.NewString("test1"))
-	col.MustAdd(hipathsys.NewString("test4"))
+	col.Add(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test4"))
 
 	other := ctx.NewCollection()
-	other.MustAdd(hipathsys.NewString("test3"))
-	other.MustAdd(hipathsys.NewString("test1"))
+	other.Add(hipathsys.NewString("test3"))
+	other.Add(hipathsys.NewString("test1"))
 
 	f := newSubsetOfFunction()
 	res, err := f.Execute(ctx, col, []interface{}{other}, nil)
@@ -577,7 +577,7 @@ func TestSupersetOfPathFuncNodeError(t *testing.T) {
 	col := test.NewTestModelErrorNode()
 
 	other := ctx.NewCollection()
-	other.MustAdd(hipathsys.NewString("test4"))
+	other.Add(hipathsys.NewString("test4"))
 
 	f := newSupersetOfFunction()
 	res, err := f.Execute(ctx, col, []interface{}{other}, nil)
@@ -591,7 +591,7 @@ func TestSupersetOfPathFuncArgNodeError(t *testing.T) {
 	other := test.NewTestModelErrorNode()
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test4"))
+	col.Add(hipathsys.NewString("test4"))
 
 	f := newSupersetOfFunction()
 	res, err := f.Execute(ctx, col, []interface{}{other}, nil)
@@ -603,15 +603,15 @@ func TestSupersetOfPathFuncTrue(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	other := ctx.NewCollection()
-	other.MustAdd(hipathsys.NewString("test3"))
-	other.MustAdd(hipathsys.NewString("test1"))
-	other.MustAdd(hipathsys.NewString("test4"))
+	other.Add(hipathsys.NewString("test3"))
+	other.Add(hipathsys.NewString("test1"))
+	other.Add(hipathsys.NewString("test4"))
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test4"))
-	col.MustAdd(hipathsys.NewString("test1"))
-	col.MustAdd(hipathsys.NewString("test2"))
-	col.MustAdd(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test4"))
+	col.Add(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test2"))
+	col.Add(hipathsys.NewString("test3"))
 
 	f := newSupersetOfFunction()
 	res, err := f.Execute(ctx, col, []interface{}{other}, nil)
@@ -623,15 +623,15 @@ func TestSupersetOfPathFuncFalse(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	other := ctx.NewCollection()
-	other.MustAdd(hipathsys.NewString("test3"))
-	other.MustAdd(hipathsys.NewString("test1"))
-	other.MustAdd(hipathsys.NewString("test4"))
+	other.Add(hipathsys.NewString("test3"))
+	other.Add(hipathsys.NewString("test1"))
+	other.Add(hipathsys.NewString("test4"))
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test4"))
-	col.MustAdd(hipathsys.NewString("test5"))
-	col.MustAdd(hipathsys.NewString("test2"))
-	col.MustAdd(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test4"))
+	col.Add(hipathsys.NewString("test5"))
+	col.Add(hipathsys.NewString("test2"))
+	col.Add(hipathsys.NewString("test3"))
 
 	f := newSupersetOfFunction()
 	res, err := f.Execute(ctx, col, []interface{}{other}, nil)
@@ -643,8 +643,8 @@ func TestCountPathFunc(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test3"))
-	col.MustAdd(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test1"))
 
 	f := newCountFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
@@ -685,7 +685,7 @@ func TestDistinctPathFuncOneItem(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test3"))
 
 	f := newDistinctFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
@@ -702,10 +702,10 @@ func TestDistinctPathFunc(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test3"))
-	col.MustAdd(hipathsys.NewString("test4"))
-	col.MustAdd(hipathsys.NewString("test3"))
-	col.MustAdd(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test4"))
+	col.Add(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test1"))
 
 	f := newDistinctFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
@@ -735,7 +735,7 @@ func TestIsDistinctPathFuncOneItem(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test3"))
 
 	f := newIsDistinctFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
@@ -765,8 +765,8 @@ func TestIsDistinctPathFunc(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test3"))
-	col.MustAdd(hipathsys.NewString("test5"))
+	col.Add(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test5"))
 
 	f := newIsDistinctFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
@@ -778,9 +778,9 @@ func TestIsDistinctPathFuncNot(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test3"))
-	col.MustAdd(hipathsys.NewString("test5"))
-	col.MustAdd(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test5"))
+	col.Add(hipathsys.NewString("test3"))
 
 	f := newIsDistinctFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
@@ -28,23 +28,34 @@
 
 package expression
 
-import (
-	"fmt"
-	"github.com/healthiop/hipath/hipathsys"
-)
+import "github.com/healthiop/hipath/hipathsys"
 
 type ExtConstantTerm struct {
 	name string
+	sourceNode
 }
 
 func ParseExtConstantTerm(value string) *ExtConstantTerm {
-	return &ExtConstantTerm{value}
+	return &ExtConstantTerm{value, sourceNode{}}
 }
 
-func (e *ExtConstantTerm) Evaluate(ctx hipathsys.ContextAccessor, _ interface{}, _ hipathsys.Looper) (interface{}, error) {
+func (e *ExtConstantTerm) Name() string {
+	return e.name
+}
+
+func (e *ExtConstantTerm) Evaluate(ctx hipathsys.ContextAccessor, _ interface{}, loop hipathsys.Looper) (interface{}, error) {
+	return e.sourceResult(e.evaluate(ctx, loop))
+}
+
+func (e *ExtConstantTerm) evaluate(ctx hipathsys.ContextAccessor, loop hipathsys.Looper) (interface{}, error) {
+	// variables that have been defined by the path overlay the environment
+	if res, found := hipathsys.LoopVariable(loop, e.name); found {
+		return res, nil
+	}
+
 	res, found := ctx.EnvVar(e.name)
 	if !found {
-		return nil, fmt.Errorf("Environment variable has not been defined: %s", e.name)
+		return nil, hipathsys.NewCodedError(hipathsys.UndefinedVariableErrorCode, "Environment variable has not been defined: %s", e.name)
 	}
 	return res, nil
 }
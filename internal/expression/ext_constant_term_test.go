@@ -50,3 +50,24 @@ func TestExtConstantTermNotDefined(t *testing.T) {
 	assert.Error(t, err, "error expected")
 	assert.Nil(t, res, "no res expected due to error")
 }
+
+func TestExtConstantTermVariable(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	loop := hipathsys.NewVariableLoop(nil)
+	value := hipathsys.NewString("test")
+	hipathsys.DefineLoopVariable(loop, "xxx", value)
+
+	evaluator := ParseExtConstantTerm("xxx")
+	assert.Equal(t, "xxx", evaluator.Name())
+	res, err := evaluator.Evaluate(ctx, nil, loop)
+	assert.NoError(t, err, "no error expected")
+	assert.Same(t, value, res)
+}
+
+func TestExtConstantTermVariableEnv(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	evaluator := ParseExtConstantTerm("ucum")
+	res, err := evaluator.Evaluate(ctx, nil, hipathsys.NewVariableLoop(nil))
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.UCUMSystemURI, res)
+}
@@ -0,0 +1,407 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package expression
+
+import "github.com/healthiop/hipath/hipathsys"
+
+type extensionFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newExtensionFunction() *extensionFunction {
+	return &extensionFunction{
+		BaseFunction: hipathsys.NewBaseFunction("extension", -1, 1, 1),
+	}
+}
+
+func (f *extensionFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}, args []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	if emptyCollection(node) {
+		return nil, nil
+	}
+
+	url, err := stringNode(args[0])
+	if url == nil || err != nil {
+		return nil, err
+	}
+
+	adapter := ctx.ModelAdapter()
+	extensions, err := adapter.Navigate(node, "extension")
+	if err != nil {
+		return nil, hipathsys.NewAdapterError(err)
+	}
+	col, err := wrapCollection(ctx, extensions)
+	if err != nil {
+		return nil, err
+	}
+
+	res := ctx.NewCollection()
+	count := col.Count()
+	for i := 0; i < count; i++ {
+		extension := col.Get(i)
+		if extension == nil {
+			continue
+		}
+		u, err := adapter.Navigate(extension, "url")
+		if err != nil {
+			return nil, hipathsys.NewAdapterError(err)
+		}
+		if s, ok := unwrapCollection(u).(hipathsys.StringAccessor); ok && s.String() == url.String() {
+			if err := res.Add(extension); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return res, nil
+}
+
+type hasValueFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newHasValueFunction() *hasValueFunction {
+	return &hasValueFunction{
+		BaseFunction: hipathsys.NewBaseFunction("hasValue", -1, 0, 0),
+	}
+}
+
+func (f *hasValueFunction) Execute(_ hipathsys.ContextAccessor, node interface{}, _ []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	return hipathsys.BooleanOf(primitiveValue(node) != nil), nil
+}
+
+type getValueFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newGetValueFunction() *getValueFunction {
+	return &getValueFunction{
+		BaseFunction: hipathsys.NewBaseFunction("getValue", -1, 0, 0),
+	}
+}
+
+func (f *getValueFunction) Execute(_ hipathsys.ContextAccessor, node interface{}, _ []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	if value := primitiveValue(node); value != nil {
+		return value, nil
+	}
+	return nil, nil
+}
+
+type resolveFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newResolveFunction() *resolveFunction {
+	return &resolveFunction{
+		BaseFunction: hipathsys.NewBaseFunction("resolve", -1, 0, 0),
+	}
+}
+
+func (f *resolveFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}, _ []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	resolver := hipathsys.ContextReferenceResolver(ctx)
+	if resolver == nil {
+		return nil, hipathsys.NewCodedError(hipathsys.NotSupportedErrorCode, "no reference resolver has been specified")
+	}
+	if emptyCollection(node) {
+		return nil, nil
+	}
+
+	col, err := wrapCollection(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+
+	res := ctx.NewCollection()
+	count := col.Count()
+	for i := 0; i < count; i++ {
+		n := col.Get(i)
+		reference, err := referenceOf(ctx, n)
+		if err != nil {
+			return nil, err
+		}
+		if len(reference) == 0 {
+			continue
+		}
+
+		resource, err := resolver.Resolve(ctx, n, reference)
+		if err != nil {
+			return nil, err
+		}
+		if resource != nil {
+			if err := res.Add(resource); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return res, nil
+}
+
+func referenceOf(ctx hipathsys.ContextAccessor, node interface{}) (string, error) {
+	if node == nil {
+		return "", nil
+	}
+	if s, ok := node.(hipathsys.StringAccessor); ok {
+		return s.String(), nil
+	}
+
+	reference, err := ctx.ModelAdapter().Navigate(node, "reference")
+	if err != nil {
+		return "", hipathsys.NewAdapterError(err)
+	}
+	if s, ok := unwrapCollection(reference).(hipathsys.StringAccessor); ok {
+		return s.String(), nil
+	}
+	return "", nil
+}
+
+type memberOfFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newMemberOfFunction() *memberOfFunction {
+	return &memberOfFunction{
+		BaseFunction: hipathsys.NewBaseFunction("memberOf", -1, 1, 1),
+	}
+}
+
+func (f *memberOfFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}, args []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	provider, err := terminologyProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := stringNode(args[0])
+	if url == nil || err != nil {
+		return nil, err
+	}
+
+	codings, err := codingsOf(ctx, node)
+	if len(codings) == 0 || err != nil {
+		return nil, err
+	}
+
+	// a single member coding decides the result, whereas the result is false
+	// only if all codings have been determined not to be members
+	determined := true
+	for _, c := range codings {
+		member, err := provider.MemberOf(url.String(), c.system, c.code)
+		if err != nil {
+			return nil, err
+		}
+		if member == nil {
+			determined = false
+		} else if member.Bool() {
+			return hipathsys.True, nil
+		}
+	}
+	if !determined {
+		return nil, nil
+	}
+	return hipathsys.False, nil
+}
+
+type subsumesFunction struct {
+	hipathsys.BaseFunction
+	inverse bool
+}
+
+func newSubsumesFunction() *subsumesFunction {
+	return &subsumesFunction{
+		BaseFunction: hipathsys.NewBaseFunction("subsumes", -1, 1, 1),
+	}
+}
+
+func newSubsumedByFunction() *subsumesFunction {
+	return &subsumesFunction{
+		BaseFunction: hipathsys.NewBaseFunction("subsumedBy", -1, 1, 1),
+		inverse:      true,
+	}
+}
+
+func (f *subsumesFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}, args []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	provider, err := terminologyProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	codings, err := codingsOf(ctx, node)
+	if len(codings) == 0 || err != nil {
+		return nil, err
+	}
+	otherCodings, err := codingsOf(ctx, args[0])
+	if len(otherCodings) == 0 || err != nil {
+		return nil, err
+	}
+
+	var res hipathsys.BooleanAccessor
+	for _, c1 := range codings {
+		for _, c2 := range otherCodings {
+			if len(c1.system) == 0 || c1.system != c2.system {
+				continue
+			}
+
+			var subsumes hipathsys.BooleanAccessor
+			if f.inverse {
+				subsumes, err = provider.Subsumes(c1.system, c2.code, c1.code)
+			} else {
+				subsumes, err = provider.Subsumes(c1.system, c1.code, c2.code)
+			}
+			if err != nil {
+				return nil, err
+			}
+			if subsumes != nil {
+				if subsumes.Bool() {
+					return hipathsys.True, nil
+				}
+				res = subsumes
+			}
+		}
+	}
+	return res, nil
+}
+
+type conformsToFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newConformsToFunction() *conformsToFunction {
+	return &conformsToFunction{
+		BaseFunction: hipathsys.NewBaseFunction("conformsTo", -1, 1, 1),
+	}
+}
+
+func (f *conformsToFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}, args []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	validator := hipathsys.ContextProfileValidator(ctx)
+	if validator == nil {
+		return nil, hipathsys.NewCodedError(hipathsys.NotSupportedErrorCode, "no profile validator has been specified")
+	}
+
+	profile, err := stringNode(args[0])
+	if profile == nil || err != nil {
+		return nil, err
+	}
+
+	node = unwrapCollection(node)
+	if node == nil {
+		return nil, nil
+	}
+	if _, ok := node.(hipathsys.CollectionAccessor); ok {
+		return nil, hipathsys.NewCodedError(hipathsys.SingletonErrorCode, "conformsTo can only be invoked on a single item")
+	}
+
+	res, err := validator.ConformsTo(ctx, node, profile.String())
+	if res == nil || err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type coding struct {
+	system string
+	code   string
+}
+
+func terminologyProvider(ctx hipathsys.ContextAccessor) (hipathsys.TerminologyProvider, error) {
+	provider := hipathsys.ContextTerminologyProvider(ctx)
+	if provider == nil {
+		return nil, hipathsys.NewCodedError(hipathsys.NotSupportedErrorCode, "no terminology provider has been specified")
+	}
+	return provider, nil
+}
+
+func codingsOf(ctx hipathsys.ContextAccessor, node interface{}) ([]coding, error) {
+	if emptyCollection(node) {
+		return nil, nil
+	}
+	col, err := wrapCollection(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+
+	adapter := ctx.ModelAdapter()
+	codings := make([]coding, 0)
+	count := col.Count()
+	for i := 0; i < count; i++ {
+		n := col.Get(i)
+		if s, ok := n.(hipathsys.StringAccessor); ok {
+			codings = append(codings, coding{code: s.String()})
+			continue
+		}
+
+		// codeable concepts provide their codings, otherwise the node is a coding
+		nested, err := adapter.Navigate(n, "coding")
+		if err != nil {
+			return nil, hipathsys.NewAdapterError(err)
+		}
+		if !emptyCollection(nested) {
+			nestedCodings, err := codingsOf(ctx, nested)
+			if err != nil {
+				return nil, err
+			}
+			codings = append(codings, nestedCodings...)
+			continue
+		}
+
+		system, err := navigateString(adapter, n, "system")
+		if err != nil {
+			return nil, err
+		}
+		code, err := navigateString(adapter, n, "code")
+		if err != nil {
+			return nil, err
+		}
+		if len(code) > 0 {
+			codings = append(codings, coding{system, code})
+		}
+	}
+	return codings, nil
+}
+
+func navigateString(adapter hipathsys.ModelAdapter, node interface{}, name string) (string, error) {
+	n, err := adapter.Navigate(node, name)
+	if err != nil {
+		return "", hipathsys.NewAdapterError(err)
+	}
+	s, err := stringNode(n)
+	if s == nil || err != nil {
+		return "", err
+	}
+	return s.String(), nil
+}
+
+func primitiveValue(node interface{}) hipathsys.AnyAccessor {
+	value := unwrapCollection(node)
+	if hipathsys.IsCollection(value) {
+		return nil
+	}
+
+	// primitive elements without value are not converted to system types
+	if a, ok := value.(hipathsys.AnyAccessor); ok {
+		return a
+	}
+	return nil
+}
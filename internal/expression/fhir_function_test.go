@@ -0,0 +1,607 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package expression
+
+import (
+	"fmt"
+	"github.com/healthiop/hipath/hipathsys"
+	"github.com/healthiop/hipath/internal/test"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newTestExtensionNode(ctx hipathsys.ContextAccessor, urls ...string) map[string]interface{} {
+	extensions := ctx.NewCollection()
+	for i, url := range urls {
+		extensions.Add(map[string]interface{}{
+			"url":          hipathsys.NewString(url),
+			"valueInteger": hipathsys.NewInteger(int32(i)),
+		})
+	}
+	return map[string]interface{}{"extension": extensions}
+}
+
+func TestExtensionFunc(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	node := newTestExtensionNode(ctx, "http://test/a", "http://test/b", "http://test/a")
+
+	f := newExtensionFunction()
+	res, err := f.Execute(ctx, node, []interface{}{hipathsys.NewString("http://test/a")}, nil)
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.CollectionAccessor)(nil), res) {
+		c := res.(hipathsys.CollectionAccessor)
+		if assert.Equal(t, 2, c.Count()) {
+			assert.Equal(t, hipathsys.NewInteger(0), c.Get(0).(map[string]interface{})["valueInteger"])
+			assert.Equal(t, hipathsys.NewInteger(2), c.Get(1).(map[string]interface{})["valueInteger"])
+		}
+	}
+}
+
+func TestExtensionFuncNoMatch(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	node := newTestExtensionNode(ctx, "http://test/a")
+
+	f := newExtensionFunction()
+	res, err := f.Execute(ctx, node, []interface{}{hipathsys.NewString("http://test/b")}, nil)
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.CollectionAccessor)(nil), res) {
+		assert.Equal(t, 0, res.(hipathsys.CollectionAccessor).Count())
+	}
+}
+
+func TestExtensionFuncEmpty(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newExtensionFunction()
+	res, err := f.Execute(ctx, nil, []interface{}{hipathsys.NewString("http://test/a")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestExtensionFuncEmptyURL(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	node := newTestExtensionNode(ctx, "http://test/a")
+
+	f := newExtensionFunction()
+	res, err := f.Execute(ctx, node, []interface{}{nil}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestExtensionFuncInvalidURL(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	node := newTestExtensionNode(ctx, "http://test/a")
+
+	f := newExtensionFunction()
+	res, err := f.Execute(ctx, node, []interface{}{hipathsys.NewInteger(10)}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestExtensionFuncNavigationError(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newExtensionFunction()
+	res, err := f.Execute(ctx, map[string]interface{}{}, []interface{}{hipathsys.NewString("http://test/a")}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestHasValueFunc(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newHasValueFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("test"), []interface{}{}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.True, res)
+}
+
+func TestHasValueFuncCollection(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	c := ctx.NewCollection()
+	c.Add(hipathsys.NewString("test"))
+
+	f := newHasValueFunction()
+	res, err := f.Execute(ctx, c, []interface{}{}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.True, res)
+
+	c.Add(hipathsys.NewString("test"))
+	res, err = f.Execute(ctx, c, []interface{}{}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.False, res)
+}
+
+func TestHasValueFuncEmpty(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newHasValueFunction()
+	res, err := f.Execute(ctx, nil, []interface{}{}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.False, res)
+}
+
+func TestHasValueFuncElement(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newHasValueFunction()
+	res, err := f.Execute(ctx, newTestExtensionNode(ctx), []interface{}{}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.False, res)
+}
+
+func TestGetValueFunc(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newGetValueFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("test"), []interface{}{}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.NewString("test"), res)
+}
+
+func TestGetValueFuncElement(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newGetValueFunction()
+	res, err := f.Execute(ctx, newTestExtensionNode(ctx), []interface{}{}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+type testReferenceResolver struct {
+	references []string
+	err        error
+}
+
+func (r *testReferenceResolver) Resolve(_ hipathsys.ContextAccessor, _ interface{}, reference string) (interface{}, error) {
+	r.references = append(r.references, reference)
+	if r.err != nil {
+		return nil, r.err
+	}
+	if reference == "Patient/1" {
+		return hipathsys.NewString("resolved"), nil
+	}
+	return nil, nil
+}
+
+func TestResolveFunc(t *testing.T) {
+	resolver := &testReferenceResolver{}
+	ctx := test.NewTestContextWithReferenceResolver(t, resolver)
+
+	c := ctx.NewCollection()
+	c.Add(map[string]interface{}{"reference": hipathsys.NewString("Patient/1")})
+	c.Add(hipathsys.NewString("Patient/2"))
+	c.Add(hipathsys.NewString("Patient/1"))
+	c.Add(map[string]interface{}{"reference": nil})
+
+	f := newResolveFunction()
+	res, err := f.Execute(ctx, c, []interface{}{}, nil)
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.CollectionAccessor)(nil), res) {
+		c := res.(hipathsys.CollectionAccessor)
+		if assert.Equal(t, 2, c.Count()) {
+			assert.Equal(t, hipathsys.NewString("resolved"), c.Get(0))
+			assert.Equal(t, hipathsys.NewString("resolved"), c.Get(1))
+		}
+	}
+	assert.Equal(t, []string{"Patient/1", "Patient/2", "Patient/1"}, resolver.references)
+}
+
+func TestResolveFuncNoResolver(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newResolveFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("Patient/1"), []interface{}{}, nil)
+	if assert.Error(t, err, "error expected") {
+		assert.Equal(t, hipathsys.NotSupportedErrorCode, hipathsys.ErrorCodeOf(err))
+	}
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestResolveFuncEmpty(t *testing.T) {
+	ctx := test.NewTestContextWithReferenceResolver(t, &testReferenceResolver{})
+
+	f := newResolveFunction()
+	res, err := f.Execute(ctx, nil, []interface{}{}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestResolveFuncError(t *testing.T) {
+	ctx := test.NewTestContextWithReferenceResolver(t,
+		&testReferenceResolver{err: fmt.Errorf("resolution failed")})
+
+	f := newResolveFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("Patient/1"), []interface{}{}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestResolveFuncNavigationError(t *testing.T) {
+	ctx := test.NewTestContextWithReferenceResolver(t, &testReferenceResolver{})
+
+	f := newResolveFunction()
+	res, err := f.Execute(ctx, map[string]interface{}{}, []interface{}{}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+type testTerminologyProvider struct {
+	calls []string
+	name  string
+	args  []interface{}
+	err   error
+}
+
+func (p *testTerminologyProvider) MemberOf(valueSetURL string, system string, code string) (hipathsys.BooleanAccessor, error) {
+	p.calls = append(p.calls, valueSetURL+"|"+system+"|"+code)
+	if p.err != nil {
+		return nil, p.err
+	}
+	if valueSetURL != "http://test/vs" || code == "u" {
+		return nil, nil
+	}
+	return hipathsys.BooleanOf(code == "a"), nil
+}
+
+func (p *testTerminologyProvider) Subsumes(system string, code1 string, code2 string) (hipathsys.BooleanAccessor, error) {
+	p.calls = append(p.calls, system+"|"+code1+"|"+code2)
+	if p.err != nil {
+		return nil, p.err
+	}
+	if system != "http://test/cs" {
+		return nil, nil
+	}
+	return hipathsys.BooleanOf(code1 == "a" || code1 == code2), nil
+}
+
+func (p *testTerminologyProvider) invoke(name string, args ...interface{}) (interface{}, error) {
+	p.name = name
+	p.args = args
+	if p.err != nil {
+		return nil, p.err
+	}
+	return hipathsys.NewString(name), nil
+}
+
+func (p *testTerminologyProvider) Expand(_ hipathsys.ContextAccessor, valueSet interface{}, params interface{}) (interface{}, error) {
+	return p.invoke("expand", valueSet, params)
+}
+
+func (p *testTerminologyProvider) Lookup(_ hipathsys.ContextAccessor, coded interface{}, params interface{}) (interface{}, error) {
+	return p.invoke("lookup", coded, params)
+}
+
+func (p *testTerminologyProvider) ValidateVS(_ hipathsys.ContextAccessor, valueSet interface{}, coded interface{}, params interface{}) (interface{}, error) {
+	return p.invoke("validateVS", valueSet, coded, params)
+}
+
+func (p *testTerminologyProvider) ValidateCS(_ hipathsys.ContextAccessor, codeSystem interface{}, coded interface{}, params interface{}) (interface{}, error) {
+	return p.invoke("validateCS", codeSystem, coded, params)
+}
+
+func (p *testTerminologyProvider) SubsumesOutcome(_ hipathsys.ContextAccessor, system interface{}, coding1 interface{}, coding2 interface{}, params interface{}) (interface{}, error) {
+	return p.invoke("subsumes", system, coding1, coding2, params)
+}
+
+func (p *testTerminologyProvider) Translate(_ hipathsys.ContextAccessor, conceptMap interface{}, coded interface{}, params interface{}) (interface{}, error) {
+	return p.invoke("translate", conceptMap, coded, params)
+}
+
+func newTestCoding(system string, code string) map[string]interface{} {
+	return map[string]interface{}{
+		"coding": nil,
+		"system": hipathsys.NewString(system),
+		"code":   hipathsys.NewString(code),
+	}
+}
+
+func newTestCodeableConcept(ctx hipathsys.ContextAccessor, codings ...map[string]interface{}) map[string]interface{} {
+	c := ctx.NewCollection()
+	for _, coding := range codings {
+		c.Add(coding)
+	}
+	return map[string]interface{}{"coding": c}
+}
+
+func TestMemberOfFuncCode(t *testing.T) {
+	provider := &testTerminologyProvider{}
+	ctx := test.NewTestContextWithTerminologyProvider(t, provider)
+
+	f := newMemberOfFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("a"), []interface{}{hipathsys.NewString("http://test/vs")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.True, res)
+	assert.Equal(t, []string{"http://test/vs||a"}, provider.calls)
+}
+
+func TestMemberOfFuncCodeableConcept(t *testing.T) {
+	provider := &testTerminologyProvider{}
+	ctx := test.NewTestContextWithTerminologyProvider(t, provider)
+	node := newTestCodeableConcept(ctx, newTestCoding("http://test/cs", "b"),
+		newTestCoding("http://test/cs", "a"))
+
+	f := newMemberOfFunction()
+	res, err := f.Execute(ctx, node, []interface{}{hipathsys.NewString("http://test/vs")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.True, res)
+	assert.Equal(t, []string{"http://test/vs|http://test/cs|b", "http://test/vs|http://test/cs|a"}, provider.calls)
+}
+
+func TestMemberOfFuncNotMember(t *testing.T) {
+	ctx := test.NewTestContextWithTerminologyProvider(t, &testTerminologyProvider{})
+
+	f := newMemberOfFunction()
+	res, err := f.Execute(ctx, newTestCoding("http://test/cs", "b"), []interface{}{hipathsys.NewString("http://test/vs")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.False, res)
+}
+
+func TestMemberOfFuncUndeterminedCodingMember(t *testing.T) {
+	provider := &testTerminologyProvider{}
+	ctx := test.NewTestContextWithTerminologyProvider(t, provider)
+	node := newTestCodeableConcept(ctx, newTestCoding("http://test/cs", "u"),
+		newTestCoding("http://test/cs", "a"))
+
+	f := newMemberOfFunction()
+	res, err := f.Execute(ctx, node, []interface{}{hipathsys.NewString("http://test/vs")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.True, res)
+	assert.Equal(t, []string{"http://test/vs|http://test/cs|u", "http://test/vs|http://test/cs|a"}, provider.calls)
+}
+
+func TestMemberOfFuncUndeterminedCodingNotMember(t *testing.T) {
+	ctx := test.NewTestContextWithTerminologyProvider(t, &testTerminologyProvider{})
+	node := newTestCodeableConcept(ctx, newTestCoding("http://test/cs", "b"),
+		newTestCoding("http://test/cs", "u"))
+
+	f := newMemberOfFunction()
+	res, err := f.Execute(ctx, node, []interface{}{hipathsys.NewString("http://test/vs")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestMemberOfFuncAllNotMember(t *testing.T) {
+	ctx := test.NewTestContextWithTerminologyProvider(t, &testTerminologyProvider{})
+	node := newTestCodeableConcept(ctx, newTestCoding("http://test/cs", "b"),
+		newTestCoding("http://test/cs", "c"))
+
+	f := newMemberOfFunction()
+	res, err := f.Execute(ctx, node, []interface{}{hipathsys.NewString("http://test/vs")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.False, res)
+}
+
+func TestMemberOfFuncUnknownValueSet(t *testing.T) {
+	ctx := test.NewTestContextWithTerminologyProvider(t, &testTerminologyProvider{})
+
+	f := newMemberOfFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("a"), []interface{}{hipathsys.NewString("http://test/other")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestMemberOfFuncEmpty(t *testing.T) {
+	ctx := test.NewTestContextWithTerminologyProvider(t, &testTerminologyProvider{})
+
+	f := newMemberOfFunction()
+	res, err := f.Execute(ctx, nil, []interface{}{hipathsys.NewString("http://test/vs")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestMemberOfFuncNoProvider(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newMemberOfFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("a"), []interface{}{hipathsys.NewString("http://test/vs")}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestMemberOfFuncError(t *testing.T) {
+	ctx := test.NewTestContextWithTerminologyProvider(t, &testTerminologyProvider{err: fmt.Errorf("failed")})
+
+	f := newMemberOfFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("a"), []interface{}{hipathsys.NewString("http://test/vs")}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestSubsumesFunc(t *testing.T) {
+	provider := &testTerminologyProvider{}
+	ctx := test.NewTestContextWithTerminologyProvider(t, provider)
+
+	f := newSubsumesFunction()
+	res, err := f.Execute(ctx, newTestCoding("http://test/cs", "a"),
+		[]interface{}{newTestCoding("http://test/cs", "b")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.True, res)
+	assert.Equal(t, []string{"http://test/cs|a|b"}, provider.calls)
+}
+
+func TestSubsumedByFunc(t *testing.T) {
+	provider := &testTerminologyProvider{}
+	ctx := test.NewTestContextWithTerminologyProvider(t, provider)
+
+	f := newSubsumedByFunction()
+	res, err := f.Execute(ctx, newTestCoding("http://test/cs", "a"),
+		[]interface{}{newTestCoding("http://test/cs", "b")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.False, res)
+	assert.Equal(t, []string{"http://test/cs|b|a"}, provider.calls)
+}
+
+func TestSubsumesFuncCodeableConcept(t *testing.T) {
+	ctx := test.NewTestContextWithTerminologyProvider(t, &testTerminologyProvider{})
+	node := newTestCodeableConcept(ctx, newTestCoding("http://test/other", "a"),
+		newTestCoding("http://test/cs", "b"))
+
+	f := newSubsumesFunction()
+	res, err := f.Execute(ctx, node, []interface{}{newTestCoding("http://test/cs", "b")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.True, res)
+}
+
+func TestSubsumesFuncDifferentSystems(t *testing.T) {
+	provider := &testTerminologyProvider{}
+	ctx := test.NewTestContextWithTerminologyProvider(t, provider)
+
+	f := newSubsumesFunction()
+	res, err := f.Execute(ctx, newTestCoding("http://test/cs", "a"),
+		[]interface{}{newTestCoding("http://test/other", "b")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty result expected")
+	assert.Empty(t, provider.calls)
+}
+
+func TestSubsumesFuncEmptyArg(t *testing.T) {
+	ctx := test.NewTestContextWithTerminologyProvider(t, &testTerminologyProvider{})
+
+	f := newSubsumesFunction()
+	res, err := f.Execute(ctx, newTestCoding("http://test/cs", "a"), []interface{}{nil}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestSubsumesFuncError(t *testing.T) {
+	ctx := test.NewTestContextWithTerminologyProvider(t, &testTerminologyProvider{err: fmt.Errorf("failed")})
+
+	f := newSubsumesFunction()
+	res, err := f.Execute(ctx, newTestCoding("http://test/cs", "a"),
+		[]interface{}{newTestCoding("http://test/cs", "b")}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestSubsumesFuncNoProvider(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newSubsumesFunction()
+	res, err := f.Execute(ctx, newTestCoding("http://test/cs", "a"),
+		[]interface{}{newTestCoding("http://test/cs", "b")}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+type testProfileValidator struct {
+	calls []string
+}
+
+func (v *testProfileValidator) ConformsTo(_ hipathsys.ContextAccessor, node interface{}, profile string) (hipathsys.BooleanAccessor, error) {
+	v.calls = append(v.calls, profile)
+	switch profile {
+	case "http://test/valid":
+		return hipathsys.True, nil
+	case "http://test/invalid":
+		return hipathsys.False, nil
+	case "http://test/error":
+		return nil, fmt.Errorf("validation failed")
+	}
+	return nil, nil
+}
+
+func TestConformsToFunc(t *testing.T) {
+	validator := &testProfileValidator{}
+	ctx := test.NewTestContextWithProfileValidator(t, validator)
+
+	f := newConformsToFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("a"), []interface{}{hipathsys.NewString("http://test/valid")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.True, res)
+	assert.Equal(t, []string{"http://test/valid"}, validator.calls)
+}
+
+func TestConformsToFuncNotConforming(t *testing.T) {
+	ctx := test.NewTestContextWithProfileValidator(t, &testProfileValidator{})
+
+	f := newConformsToFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("a"), []interface{}{hipathsys.NewString("http://test/invalid")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.False, res)
+}
+
+func TestConformsToFuncUnknownProfile(t *testing.T) {
+	ctx := test.NewTestContextWithProfileValidator(t, &testProfileValidator{})
+
+	f := newConformsToFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("a"), []interface{}{hipathsys.NewString("http://test/other")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestConformsToFuncEmpty(t *testing.T) {
+	validator := &testProfileValidator{}
+	ctx := test.NewTestContextWithProfileValidator(t, validator)
+
+	f := newConformsToFunction()
+	res, err := f.Execute(ctx, ctx.NewCollection(), []interface{}{hipathsys.NewString("http://test/valid")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty result expected")
+	assert.Empty(t, validator.calls)
+}
+
+func TestConformsToFuncEmptyArg(t *testing.T) {
+	ctx := test.NewTestContextWithProfileValidator(t, &testProfileValidator{})
+
+	f := newConformsToFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("a"), []interface{}{nil}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestConformsToFuncMultipleItems(t *testing.T) {
+	ctx := test.NewTestContextWithProfileValidator(t, &testProfileValidator{})
+	col := ctx.NewCollection()
+	col.Add(hipathsys.NewString("a"))
+	col.Add(hipathsys.NewString("b"))
+
+	f := newConformsToFunction()
+	res, err := f.Execute(ctx, col, []interface{}{hipathsys.NewString("http://test/valid")}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestConformsToFuncError(t *testing.T) {
+	ctx := test.NewTestContextWithProfileValidator(t, &testProfileValidator{})
+
+	f := newConformsToFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("a"), []interface{}{hipathsys.NewString("http://test/error")}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestConformsToFuncNoValidator(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newConformsToFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("a"), []interface{}{hipathsys.NewString("http://test/valid")}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "empty result expected")
+}
@@ -29,8 +29,9 @@
 package expression
 
 import (
-	"fmt"
 	"github.com/healthiop/hipath/hipathsys"
+	"math"
+	"sort"
 )
 
 type whereFunction struct {
@@ -56,6 +57,9 @@ func (f *whereFunction) Execute(ctx hipathsys.ContextAccessor, node interface{},
 	var filtered hipathsys.CollectionModifier
 	loopEvaluator := loop.Evaluator()
 	for i := 0; i < count; i++ {
+		if err := checkEvaluationStep(ctx); err != nil {
+			return nil, err
+		}
 		this := col.Get(i)
 		loop.IncIndex(this)
 
@@ -65,7 +69,7 @@ func (f *whereFunction) Execute(ctx hipathsys.ContextAccessor, node interface{},
 		}
 		if res != nil {
 			if b, ok := res.(hipathsys.BooleanAccessor); !ok {
-				return nil, fmt.Errorf("filter expression must return boolean, but returned %T", res)
+				return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "filter expression must return boolean, but returned %T", res)
 			} else if b.Bool() {
 				if filtered == nil {
 					filtered = ctx.NewCollection()
@@ -104,6 +108,9 @@ func (f *selectFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}
 	var projected hipathsys.CollectionModifier
 	loopEvaluator := loop.Evaluator()
 	for i := 0; i < count; i++ {
+		if err := checkEvaluationStep(ctx); err != nil {
+			return nil, err
+		}
 		this := col.Get(i)
 		loop.IncIndex(this)
 
@@ -151,6 +158,12 @@ func (f *repeatFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}
 }
 
 func repeat(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper, projected hipathsys.CollectionModifier) error {
+	limiter := hipathsys.ContextLimiter(ctx)
+	if err := limiter.Enter(); err != nil {
+		return err
+	}
+	defer limiter.Leave()
+
 	col, err := wrapCollection(ctx, node)
 	if err != nil {
 		return err
@@ -162,6 +175,9 @@ func repeat(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Loop
 
 	loopEvaluator := loop.Evaluator()
 	for i := 0; i < count; i++ {
+		if err := checkEvaluationStep(ctx); err != nil {
+			return err
+		}
 		this := col.Get(i)
 		loop.IncIndex(this)
 
@@ -181,6 +197,7 @@ func repeat(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Loop
 }
 
 func repeatRecursively(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper, projected hipathsys.CollectionModifier) error {
+	limiter := hipathsys.ContextLimiter(ctx)
 	if col, ok := node.(hipathsys.CollectionAccessor); ok {
 		count := col.Count()
 		for i := 0; i < count; i++ {
@@ -191,8 +208,11 @@ func repeatRecursively(ctx hipathsys.ContextAccessor, node interface{}, loop hip
 					return err
 				}
 				if added {
-					err = repeat(ctx, n, hipathsys.NewLoopWithIndex(
-						loop.Evaluator(), i), projected)
+					if err := limiter.CheckVisitedNodes(projected.Count()); err != nil {
+						return err
+					}
+					err = repeat(ctx, n, hipathsys.NewLoopWithIndexAndOuter(
+						loop.Evaluator(), i, hipathsys.LoopOuter(loop)), projected)
 					if err != nil {
 						return err
 					}
@@ -205,8 +225,11 @@ func repeatRecursively(ctx hipathsys.ContextAccessor, node interface{}, loop hip
 			return err
 		}
 		if added {
-			err := repeat(ctx, node, hipathsys.NewLoop(
-				loop.Evaluator()), projected)
+			if err := limiter.CheckVisitedNodes(projected.Count()); err != nil {
+				return err
+			}
+			err := repeat(ctx, node, hipathsys.NewLoopWithOuter(
+				loop.Evaluator(), hipathsys.LoopOuter(loop)), projected)
 			if err != nil {
 				return err
 			}
@@ -229,13 +252,13 @@ func (f *ofTypeFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}
 	var typeSpec hipathsys.StringAccessor
 	var ok bool
 	if typeSpec, ok = unwrapCollection(args[0]).(hipathsys.StringAccessor); !ok {
-		return nil, fmt.Errorf("not a valid type specifier: %T", args[0])
+		return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "not a valid type specifier: %T", args[0])
 	}
 
 	var typeName hipathsys.FQTypeNameAccessor
 	var err error
 	if typeName, err = hipathsys.ParseFQTypeName(typeSpec.String()); err != nil {
-		return nil, fmt.Errorf("not a valid type specifier: %s", typeSpec)
+		return nil, hipathsys.NewCodedError(hipathsys.InvalidValueErrorCode, "not a valid type specifier: %s", typeSpec)
 	}
 
 	col, err := wrapCollection(ctx, node)
@@ -264,3 +287,135 @@ func (f *ofTypeFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}
 
 	return filtered, nil
 }
+
+type sortFunction struct {
+	hipathsys.BaseFunction
+}
+
+type sortCriterion struct {
+	evaluator  hipathsys.Evaluator
+	descending bool
+}
+
+type sortEntry struct {
+	node interface{}
+	keys []interface{}
+}
+
+func newSortFunction() *sortFunction {
+	return &sortFunction{
+		BaseFunction: hipathsys.NewBaseFunction("sort", hipathsys.AllEvaluatorParams, 0, math.MaxInt32),
+	}
+}
+
+func (f *sortFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}, args []interface{}, loop hipathsys.Looper) (interface{}, error) {
+	col, err := wrapCollection(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	count := col.Count()
+	if count == 0 {
+		return nil, nil
+	}
+
+	criteria := sortCriteria(args)
+	loops := make([]hipathsys.Looper, len(criteria))
+	for i, c := range criteria {
+		loops[i] = hipathsys.NewLoopWithOuter(c.evaluator, hipathsys.LoopOuter(loop))
+	}
+
+	entries := make([]sortEntry, count)
+	for i := 0; i < count; i++ {
+		if err := checkEvaluationStep(ctx); err != nil {
+			return nil, err
+		}
+		this := col.Get(i)
+		entries[i].node = this
+		if len(criteria) == 0 {
+			entries[i].keys = []interface{}{this}
+			continue
+		}
+
+		entries[i].keys = make([]interface{}, len(criteria))
+		for k, c := range criteria {
+			loops[k].IncIndex(this)
+			res, err := c.evaluator.Evaluate(ctx, this, loops[k])
+			if err != nil {
+				return nil, err
+			}
+			key := unwrapCollection(res)
+			if _, ok := key.(hipathsys.CollectionAccessor); ok {
+				return nil, hipathsys.NewCodedError(hipathsys.SingletonErrorCode, "sort criterion %d must not return multiple items", k)
+			}
+			entries[i].keys[k] = key
+		}
+	}
+
+	var sortErr error
+	sort.SliceStable(entries, func(i, j int) bool {
+		if sortErr != nil {
+			return false
+		}
+		for k, key := range entries[i].keys {
+			res, err := hipathsys.CompareOrder(key, entries[j].keys[k])
+			if err != nil {
+				sortErr = err
+				return false
+			}
+			if res != 0 {
+				if len(criteria) > 0 && criteria[k].descending {
+					return res > 0
+				}
+				return res < 0
+			}
+		}
+		return false
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
+	sorted := ctx.NewCollection()
+	for _, e := range entries {
+		if err := sorted.Add(e.node); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+// sortCriteria returns the sort criteria of the specified arguments. Empty
+// keys are ordered first when sorting ascending and last when sorting
+// descending.
+func sortCriteria(args []interface{}) []sortCriterion {
+	criteria := make([]sortCriterion, 0, len(args))
+	for _, arg := range args {
+		evaluator, ok := arg.(hipathsys.Evaluator)
+		if !ok || evaluator == nil {
+			continue
+		}
+		if key, ok := descendingSortKey(evaluator); ok {
+			criteria = append(criteria, sortCriterion{key, true})
+		} else {
+			criteria = append(criteria, sortCriterion{evaluator, false})
+		}
+	}
+	return criteria
+}
+
+// descendingSortKey returns the key without its leading minus if the
+// specified sort key (or the body of the specified lambda expression) is a
+// sort key negator.
+func descendingSortKey(evaluator hipathsys.Evaluator) (hipathsys.Evaluator, bool) {
+	switch e := evaluator.(type) {
+	case *NegatorExpression:
+		if e.sortKey {
+			return e.evaluator, true
+		}
+	case *LambdaExpression:
+		if key, ok := descendingSortKey(e.body); ok {
+			return &LambdaExpression{e.sourceNode, e.paramNames, key}, true
+		}
+	}
+	return nil, false
+}
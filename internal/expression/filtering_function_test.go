@@ -29,6 +29,8 @@
 package expression
 
 import (
+	"context"
+	"errors"
 	"github.com/healthiop/hipath/hipathsys"
 	"github.com/healthiop/hipath/internal/test"
 	"github.com/stretchr/testify/assert"
@@ -47,9 +49,9 @@ func TestWherePathFunc(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewInteger(0))
-	col.MustAdd(hipathsys.NewInteger(7))
-	col.MustAdd(hipathsys.NewInteger(2))
+	col.Add(hipathsys.NewInteger(0))
+	col.Add(hipathsys.NewInteger(7))
+	col.Add(hipathsys.NewInteger(2))
 
 	loopEval := NewEqualityExpression(false, false,
 		NewThisInvocation(), NewIndexInvocation())
@@ -66,6 +68,25 @@ func TestWherePathFunc(t *testing.T) {
 	}
 }
 
+func TestWherePathFuncCanceled(t *testing.T) {
+	goCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx := hipathsys.BindGoContext(test.NewTestContext(t), goCtx)
+
+	col := ctx.NewCollection()
+	col.Add(hipathsys.NewInteger(0))
+	col.Add(hipathsys.NewInteger(7))
+
+	loopEval := newTestCancelExpression(hipathsys.True, cancel)
+
+	f := newWhereFunction()
+	res, err := f.Execute(ctx, col, nil, hipathsys.NewLoop(loopEval))
+	if assert.Error(t, err, "error expected") {
+		assert.True(t, errors.Is(err, context.Canceled))
+	}
+	assert.Nil(t, res, "empty result expected")
+}
+
 func TestWherePathFuncNodeError(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
@@ -93,9 +114,9 @@ func TestWherePathFuncNoMatch(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewInteger(2))
-	col.MustAdd(hipathsys.NewInteger(7))
-	col.MustAdd(hipathsys.NewInteger(0))
+	col.Add(hipathsys.NewInteger(2))
+	col.Add(hipathsys.NewInteger(7))
+	col.Add(hipathsys.NewInteger(0))
 
 	loopEval := NewEqualityExpression(false, false,
 		NewThisInvocation(), NewIndexInvocation())
@@ -110,9 +131,9 @@ func TestWherePathFuncEvalNil(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewInteger(2))
-	col.MustAdd(hipathsys.NewInteger(7))
-	col.MustAdd(hipathsys.NewInteger(0))
+	col.Add(hipathsys.NewInteger(2))
+	col.Add(hipathsys.NewInteger(7))
+	col.Add(hipathsys.NewInteger(0))
 
 	f := newWhereFunction()
 	res, err := f.Execute(ctx, col, nil, hipathsys.NewLoop(newTestExpression(nil)))
@@ -124,7 +145,7 @@ func TestWherePathFuncError(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewInteger(0))
+	col.Add(hipathsys.NewInteger(0))
 
 	f := newWhereFunction()
 	res, err := f.Execute(ctx, col, nil, hipathsys.NewLoop(newTestErrorExpression()))
@@ -136,7 +157,7 @@ func TestWherePathFuncTypeError(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewInteger(0))
+	col.Add(hipathsys.NewInteger(0))
 
 	f := newWhereFunction()
 	res, err := f.Execute(ctx, col, nil, hipathsys.NewLoop(NewRawStringLiteral("")))
@@ -156,8 +177,8 @@ func TestSelectPathFunc(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewInteger(5))
-	col.MustAdd(hipathsys.NewInteger(7))
+	col.Add(hipathsys.NewInteger(5))
+	col.Add(hipathsys.NewInteger(7))
 
 	loopEval := NewUnionExpression(NewThisInvocation(), NewIndexInvocation())
 
@@ -190,7 +211,7 @@ func TestSelectPathFuncColError(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewInteger(5))
+	col.Add(hipathsys.NewInteger(5))
 
 	loopEval := newTestExpression(test.NewErrorCollection())
 
@@ -204,7 +225,7 @@ func TestSelectPathFuncItemError(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewInteger(5))
+	col.Add(hipathsys.NewInteger(5))
 
 	loopEval := newTestExpression(test.NewTestModelErrorNode())
 
@@ -218,8 +239,8 @@ func TestSelectPathFuncSingleItems(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewInteger(5))
-	col.MustAdd(hipathsys.NewInteger(7))
+	col.Add(hipathsys.NewInteger(5))
+	col.Add(hipathsys.NewInteger(7))
 
 	f := newSelectFunction()
 	res, err := f.Execute(ctx, col, nil, hipathsys.NewLoop(NewIndexInvocation()))
@@ -237,7 +258,7 @@ func TestSelectPathFuncError(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewInteger(5))
+	col.Add(hipathsys.NewInteger(5))
 
 	f := newSelectFunction()
 	res, err := f.Execute(ctx, col, nil, hipathsys.NewLoop(newTestErrorExpression()))
@@ -249,7 +270,7 @@ func TestSelectPathFuncEvalNil(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewInteger(5))
+	col.Add(hipathsys.NewInteger(5))
 
 	f := newSelectFunction()
 	res, err := f.Execute(ctx, col, nil, hipathsys.NewLoop(newTestExpression(nil)))
@@ -280,7 +301,7 @@ func TestRepeatPathFuncItemError(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewInteger(5))
+	col.Add(hipathsys.NewInteger(5))
 
 	loopEval := newTestExpression(test.NewTestModelErrorNode())
 
@@ -294,7 +315,7 @@ func TestRepeatPathFuncColError(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewInteger(5))
+	col.Add(hipathsys.NewInteger(5))
 
 	loopEval := newTestExpression(test.NewErrorCollection())
 
@@ -333,6 +354,87 @@ func TestRepeatPathFunc(t *testing.T) {
 	}
 }
 
+func TestRepeatPathFuncCanceled(t *testing.T) {
+	goCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx := hipathsys.BindGoContext(test.NewTestContext(t), goCtx)
+
+	node11 := make(map[string]interface{})
+	node11["id"] = "11"
+	node1 := make(map[string]interface{})
+	node1["id"] = "1"
+
+	loopEval := newTestCancelExpression(node11, cancel)
+
+	f := repeatFunc
+	res, err := f.Execute(ctx, node1, nil, hipathsys.NewLoop(loopEval))
+	if assert.Error(t, err, "error expected") {
+		assert.True(t, errors.Is(err, context.Canceled))
+	}
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestRepeatPathFuncMaxVisitedNodes(t *testing.T) {
+	ctx := test.NewTestContextWithLimits(t, hipathsys.Limits{MaxVisitedNodes: 1})
+
+	node111 := make(map[string]interface{})
+	node111["id"] = "111"
+	node111["item"] = nil
+	node11 := make(map[string]interface{})
+	node11["id"] = "11"
+	node11["item"] = node111
+	node1 := make(map[string]interface{})
+	node1["id"] = "1"
+	node1["item"] = node11
+
+	f := repeatFunc
+	res, err := f.Execute(ctx, node1, nil, hipathsys.NewLoop(NewMemberInvocation("item")))
+	var limitErr *hipathsys.LimitError
+	if assert.True(t, errors.As(err, &limitErr), "limit error expected") {
+		assert.Equal(t, hipathsys.VisitedNodesLimitName, limitErr.Name())
+	}
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestRepeatPathFuncMaxDepth(t *testing.T) {
+	ctx := test.NewTestContextWithLimits(t, hipathsys.Limits{MaxDepth: 2})
+
+	node111 := make(map[string]interface{})
+	node111["id"] = "111"
+	node111["item"] = nil
+	node11 := make(map[string]interface{})
+	node11["id"] = "11"
+	node11["item"] = node111
+	node1 := make(map[string]interface{})
+	node1["id"] = "1"
+	node1["item"] = node11
+
+	f := repeatFunc
+	res, err := f.Execute(ctx, node1, nil, hipathsys.NewLoop(NewMemberInvocation("item")))
+	var limitErr *hipathsys.LimitError
+	if assert.True(t, errors.As(err, &limitErr), "limit error expected") {
+		assert.Equal(t, hipathsys.DepthLimitName, limitErr.Name())
+	}
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestWherePathFuncMaxSteps(t *testing.T) {
+	ctx := test.NewTestContextWithLimits(t, hipathsys.Limits{MaxSteps: 2})
+
+	col := ctx.NewCollection()
+	col.Add(hipathsys.NewInteger(0))
+	col.Add(hipathsys.NewInteger(7))
+	col.Add(hipathsys.NewInteger(2))
+
+	f := newWhereFunction()
+	res, err := f.Execute(ctx, col, nil, hipathsys.NewLoop(NewBooleanLiteral(true)))
+	var limitErr *hipathsys.LimitError
+	if assert.True(t, errors.As(err, &limitErr), "limit error expected") {
+		assert.Equal(t, hipathsys.StepsLimitName, limitErr.Name())
+	}
+	assert.Nil(t, res, "empty result expected")
+}
+
 func TestRepeatPathFuncErr(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
@@ -357,25 +459,25 @@ func TestRepeatPathFuncColInCol(t *testing.T) {
 	node11 := createRepeatTestColData("11")
 	col := ctx.NewCollection()
 	node111 := createRepeatTestColData("111")
-	col.MustAdd(node111)
+	col.Add(node111)
 	node112 := createRepeatTestColData("112")
-	col.MustAdd(node112)
+	col.Add(node112)
 	node11["items"] = col
 
 	node12 := createRepeatTestColData("12")
 	col = ctx.NewCollection()
 	node121 := createRepeatTestColData("121")
-	col.MustAdd(node121)
+	col.Add(node121)
 	node122 := createRepeatTestColData("122")
-	col.MustAdd(node122)
-	col.MustAdd(nil)
+	col.Add(node122)
+	col.Add(nil)
 	node12["items"] = col
 
 	node1 := createRepeatTestColData("1")
 	col = ctx.NewCollection()
-	col.MustAdd(node11)
-	col.MustAdd(node12)
-	col.MustAdd(node11)
+	col.Add(node11)
+	col.Add(node12)
+	col.Add(node11)
 	node1["items"] = col
 
 	loopEval := NewMemberInvocation("items")
@@ -404,7 +506,7 @@ func TestRepeatPathFuncColInColError(t *testing.T) {
 
 	node1 := createRepeatTestColData("1")
 	col := ctx.NewCollection()
-	col.MustAdd(node11)
+	col.Add(node11)
 	node1["items"] = col
 
 	loopEval := NewMemberInvocation("items")
@@ -467,9 +569,9 @@ func TestOfTypePathFunc(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
-	col.MustAdd(hipathsys.NewInteger(10))
-	col.MustAdd(hipathsys.NewString("test2"))
+	col.Add(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewInteger(10))
+	col.Add(hipathsys.NewString("test2"))
 
 	f := newOfTypeFunction()
 	res, err := f.Execute(ctx, col, []interface{}{hipathsys.NewString("System.String")}, nil)
@@ -487,9 +589,9 @@ func TestOfTypePathFuncBaseType(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
-	col.MustAdd(hipathsys.NewInteger(10))
-	col.MustAdd(hipathsys.NewString("test2"))
+	col.Add(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewInteger(10))
+	col.Add(hipathsys.NewString("test2"))
 
 	f := newOfTypeFunction()
 	res, err := f.Execute(ctx, col, []interface{}{hipathsys.NewString("System.Any")}, nil)
@@ -503,3 +605,174 @@ func TestOfTypePathFuncBaseType(t *testing.T) {
 		}
 	}
 }
+
+func TestSortPathFuncNil(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	f := newSortFunction()
+	res, err := f.Execute(ctx, nil, emptyFunctionArgs, hipathsys.NewLoop(nil))
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestSortPathFuncNoCriteria(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	col := ctx.NewCollection()
+	col.Add(hipathsys.NewInteger(7))
+	col.Add(hipathsys.NewInteger(0))
+	col.Add(hipathsys.NewDecimalFloat64(2.5))
+
+	f := newSortFunction()
+	res, err := f.Execute(ctx, col, emptyFunctionArgs, hipathsys.NewLoop(nil))
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.CollectionAccessor)(nil), res) {
+		col := res.(hipathsys.CollectionAccessor)
+		if assert.Equal(t, 3, col.Count()) {
+			assert.True(t, hipathsys.NewInteger(0).Equal(col.Get(0)))
+			assert.True(t, hipathsys.NewDecimalFloat64(2.5).Equal(col.Get(1)))
+			assert.True(t, hipathsys.NewInteger(7).Equal(col.Get(2)))
+		}
+	}
+}
+
+func TestSortPathFuncAscending(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	col := ctx.NewCollection()
+	col.Add(hipathsys.NewString("b"))
+	col.Add(hipathsys.NewString("c"))
+	col.Add(hipathsys.NewString("a"))
+
+	f := newSortFunction()
+	res, err := f.Execute(ctx, col, []interface{}{NewThisInvocation()},
+		hipathsys.NewLoop(nil))
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.CollectionAccessor)(nil), res) {
+		col := res.(hipathsys.CollectionAccessor)
+		if assert.Equal(t, 3, col.Count()) {
+			assert.Equal(t, hipathsys.NewString("a"), col.Get(0))
+			assert.Equal(t, hipathsys.NewString("b"), col.Get(1))
+			assert.Equal(t, hipathsys.NewString("c"), col.Get(2))
+		}
+	}
+}
+
+func TestSortPathFuncDescending(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	col := ctx.NewCollection()
+	col.Add(hipathsys.NewString("b"))
+	col.Add(hipathsys.NewString("c"))
+	col.Add(hipathsys.NewString("a"))
+
+	f := newSortFunction()
+	res, err := f.Execute(ctx, col, []interface{}{NewSortKeyNegatorExpression(NewThisInvocation())},
+		hipathsys.NewLoop(nil))
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.CollectionAccessor)(nil), res) {
+		col := res.(hipathsys.CollectionAccessor)
+		if assert.Equal(t, 3, col.Count()) {
+			assert.Equal(t, hipathsys.NewString("c"), col.Get(0))
+			assert.Equal(t, hipathsys.NewString("b"), col.Get(1))
+			assert.Equal(t, hipathsys.NewString("a"), col.Get(2))
+		}
+	}
+}
+
+func TestSortPathFuncStable(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	col := ctx.NewCollection()
+	col.Add(hipathsys.NewString("b"))
+	col.Add(hipathsys.NewString("c"))
+	col.Add(hipathsys.NewString("a"))
+
+	f := newSortFunction()
+	res, err := f.Execute(ctx, col, []interface{}{newTestExpression(nil)},
+		hipathsys.NewLoop(nil))
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.CollectionAccessor)(nil), res) {
+		col := res.(hipathsys.CollectionAccessor)
+		if assert.Equal(t, 3, col.Count()) {
+			assert.Equal(t, hipathsys.NewString("b"), col.Get(0))
+			assert.Equal(t, hipathsys.NewString("c"), col.Get(1))
+			assert.Equal(t, hipathsys.NewString("a"), col.Get(2))
+		}
+	}
+}
+
+func TestSortPathFuncNilCriterion(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	col := ctx.NewCollection()
+	col.Add(hipathsys.NewInteger(2))
+	col.Add(hipathsys.NewInteger(1))
+
+	f := newSortFunction()
+	res, err := f.Execute(ctx, col, []interface{}{nil}, hipathsys.NewLoop(nil))
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.CollectionAccessor)(nil), res) {
+		col := res.(hipathsys.CollectionAccessor)
+		if assert.Equal(t, 2, col.Count()) {
+			assert.True(t, hipathsys.NewInteger(1).Equal(col.Get(0)))
+			assert.True(t, hipathsys.NewInteger(2).Equal(col.Get(1)))
+		}
+	}
+}
+
+func TestSortPathFuncMultipleItems(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	col := ctx.NewCollection()
+	col.Add(hipathsys.NewInteger(2))
+	col.Add(hipathsys.NewInteger(1))
+
+	f := newSortFunction()
+	res, err := f.Execute(ctx, col, []interface{}{newTestExpression(col)},
+		hipathsys.NewLoop(nil))
+	assert.Error(t, err, "error expected")
+	assert.True(t, errors.Is(err, hipathsys.ErrSingleton))
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestSortPathFuncCriterionError(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	col := ctx.NewCollection()
+	col.Add(hipathsys.NewInteger(2))
+
+	f := newSortFunction()
+	res, err := f.Execute(ctx, col, []interface{}{newTestErrorExpression()},
+		hipathsys.NewLoop(nil))
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestSortPathFuncOrderError(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	col := ctx.NewCollection()
+	col.Add(hipathsys.NewInteger(2))
+	col.Add(hipathsys.NewString("1"))
+
+	f := newSortFunction()
+	res, err := f.Execute(ctx, col, emptyFunctionArgs, hipathsys.NewLoop(nil))
+	assert.Error(t, err, "error expected")
+	assert.True(t, errors.Is(err, hipathsys.ErrTypeMismatch))
+	assert.Nil(t, res, "empty result expected")
+}
+
+func TestSortPathFuncCanceled(t *testing.T) {
+	goCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ctx := hipathsys.BindGoContext(test.NewTestContext(t), goCtx)
+
+	col := ctx.NewCollection()
+	col.Add(hipathsys.NewInteger(2))
+
+	f := newSortFunction()
+	res, err := f.Execute(ctx, col, emptyFunctionArgs, hipathsys.NewLoop(nil))
+	assert.Error(t, err, "error expected")
+	assert.True(t, errors.Is(err, hipathsys.ErrCanceled))
+	assert.Nil(t, res, "empty result expected")
+}
@@ -52,6 +52,7 @@ var functions = []hipathsys.FunctionExecutor{
 	newSelectFunction(),
 	repeatFunc,
 	newOfTypeFunction(),
+	newSortFunction(),
 	// sub-setting
 	newSingleFunction(),
 	newFirstFunction(),
@@ -84,6 +85,7 @@ var functions = []hipathsys.FunctionExecutor{
 	newConvertsToTimeFunction(),
 	// string manipulation
 	newIndexOfFunction(),
+	newLastIndexOfFunction(),
 	newSubstringFunction(),
 	newStartsWithFunction(),
 	newEndsWithFunction(),
@@ -92,9 +94,13 @@ var functions = []hipathsys.FunctionExecutor{
 	newLowerFunction(),
 	newReplaceFunction(),
 	newMatchesFunction(),
+	newMatchesFullFunction(),
 	newReplaceMatchesFunction(),
 	newLengthFunction(),
 	newToCharsFunction(),
+	newTrimFunction(),
+	newSplitFunction(),
+	newJoinFunction(),
 	// math
 	newAbsFunction(),
 	newCeilingFunction(),
@@ -110,6 +116,7 @@ var functions = []hipathsys.FunctionExecutor{
 	childrenFunc,
 	newDescendantsFunction(),
 	// utility
+	newDefineVariableFunction(),
 	newTraceFunction(),
 	newNowFunction(),
 	newTimeOfDayFunction(),
@@ -119,54 +126,101 @@ var functions = []hipathsys.FunctionExecutor{
 	newIsFunction(),
 	// aggregate
 	newAggregateFunction(),
+	newSumFunction(),
+	newMinFunction(),
+	newMaxFunction(),
+	newAvgFunction(),
+	// FHIR
+	newExtensionFunction(),
+	newHasValueFunction(),
+	newGetValueFunction(),
+	newResolveFunction(),
+	newMemberOfFunction(),
+	newSubsumesFunction(),
+	newSubsumedByFunction(),
+	newConformsToFunction(),
 }
 
-var functionsByName = createFunctionsByName(functions)
+var BuiltInFunctions = newBuiltInFunctionRegistry(functions)
 
 var emptyFunctionArgs = []interface{}{}
 
 type FunctionInvocation struct {
 	executor        hipathsys.FunctionExecutor
 	paramEvaluators []hipathsys.Evaluator
+	sourceNode
 }
 
 func LookupFunctionInvocation(name string, paramEvaluators []hipathsys.Evaluator) (*FunctionInvocation, error) {
-	executor, found := functionsByName[name]
-	if !found {
-		return nil, fmt.Errorf("executor has not been defined: %s", name)
+	return LookupFunctionInvocationWithRegistry(nil, name, paramEvaluators)
+}
+
+func LookupFunctionInvocationWithRegistry(registry *hipathsys.FunctionRegistry, name string, paramEvaluators []hipathsys.Evaluator) (*FunctionInvocation, error) {
+	if registry == nil {
+		registry = BuiltInFunctions
+	}
+
+	executor := registry.Lookup(name)
+	if executor == nil {
+		return nil, hipathsys.NewCodedError(hipathsys.UnknownFunctionErrorCode, "executor has not been defined: %s", name)
 	}
 
 	if len(paramEvaluators) < executor.MinParams() {
-		return nil, fmt.Errorf("executor %s requires at least %d parameters", name, executor.MinParams())
+		return nil, hipathsys.NewCodedError(hipathsys.InvalidArgumentsErrorCode, "executor %s requires at least %d parameters", name, executor.MinParams())
 	}
 	if len(paramEvaluators) > executor.MaxParams() {
-		return nil, fmt.Errorf("executor %s accepts at most %d parameters", name, executor.MaxParams())
+		return nil, hipathsys.NewCodedError(hipathsys.InvalidArgumentsErrorCode, "executor %s accepts at most %d parameters", name, executor.MaxParams())
+	}
+
+	evaluatorParam := executor.EvaluatorParam()
+	for pos, paramEvaluator := range paramEvaluators {
+		if _, ok := paramEvaluator.(*LambdaExpression); ok && evaluatorParam != pos && evaluatorParam != hipathsys.AllEvaluatorParams {
+			return nil, hipathsys.NewCodedError(hipathsys.InvalidArgumentsErrorCode, "executor %s does not accept a lambda expression as parameter %d", name, pos)
+		}
 	}
 
 	return newFunctionInvocation(executor, paramEvaluators), nil
 }
 
 func newFunctionInvocation(executor hipathsys.FunctionExecutor, argEvaluators []hipathsys.Evaluator) *FunctionInvocation {
-	return &FunctionInvocation{executor, argEvaluators}
+	return &FunctionInvocation{executor, argEvaluators, sourceNode{}}
 }
 
 func (f *FunctionInvocation) Evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
+	return f.sourceResult(f.evaluate(ctx, node, loop))
+}
+
+func (f *FunctionInvocation) evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
+	if err := checkEvaluationStep(ctx); err != nil {
+		return nil, err
+	}
+	limiter := hipathsys.ContextLimiter(ctx)
+	if err := limiter.Enter(); err != nil {
+		return nil, err
+	}
+	defer limiter.Leave()
+
 	var args []interface{}
 	ac := len(f.paramEvaluators)
 	if ac == 0 {
 		args = emptyFunctionArgs
+		if f.executor.EvaluatorParam() >= 0 || f.executor.EvaluatorParam() == hipathsys.AllEvaluatorParams {
+			loop = hipathsys.NewLoopWithOuter(nil, loop)
+		}
 	} else {
 		evaluatorParam := f.executor.EvaluatorParam()
 		args = make([]interface{}, len(f.paramEvaluators))
 
 		var loopEvaluator hipathsys.Evaluator
 		for pos, argEvaluator := range f.paramEvaluators {
-			if evaluatorParam == pos {
+			if evaluatorParam == hipathsys.AllEvaluatorParams {
+				args[pos] = argEvaluator
+			} else if evaluatorParam == pos {
 				loopEvaluator = argEvaluator
 			} else {
 				if argEvaluator != nil {
 					if arg, err := argEvaluator.Evaluate(ctx, node, loop); err != nil {
-						return nil, fmt.Errorf("error in argument %d of executor invocation %s: %v",
+						return nil, fmt.Errorf("error in argument %d of executor invocation %s: %w",
 							pos, f.executor.Name(), err)
 					} else {
 						args[pos] = arg
@@ -175,18 +229,20 @@ func (f *FunctionInvocation) Evaluate(ctx hipathsys.ContextAccessor, node interf
 			}
 		}
 
-		if evaluatorParam >= 0 {
-			loop = hipathsys.NewLoop(loopEvaluator)
+		if evaluatorParam >= 0 || evaluatorParam == hipathsys.AllEvaluatorParams {
+			loop = hipathsys.NewLoopWithOuter(loopEvaluator, loop)
 		}
 	}
 
 	return f.executor.Execute(ctx, node, args, loop)
 }
 
-func createFunctionsByName(functions []hipathsys.FunctionExecutor) map[string]hipathsys.FunctionExecutor {
-	functionsByName := make(map[string]hipathsys.FunctionExecutor)
+func newBuiltInFunctionRegistry(functions []hipathsys.FunctionExecutor) *hipathsys.FunctionRegistry {
+	registry := hipathsys.NewFunctionRegistry(nil)
 	for _, f := range functions {
-		functionsByName[f.Name()] = f
+		if err := registry.Register(f); err != nil {
+			panic(err)
+		}
 	}
-	return functionsByName
+	return registry
 }
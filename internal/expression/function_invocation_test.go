@@ -29,6 +29,8 @@
 package expression
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/healthiop/hipath/hipathsys"
 	"github.com/healthiop/hipath/internal/test"
@@ -105,6 +107,44 @@ func TestFunctionInvocationLoop(t *testing.T) {
 	assert.NotSame(t, testLoop, loopExpression.loop)
 }
 
+func TestFunctionInvocationAllEvaluators(t *testing.T) {
+	var args []interface{}
+	var argsLoop hipathsys.Looper
+	function := &testInvocationEvaluatorsFunction{
+		BaseFunction: hipathsys.NewBaseFunction("test", hipathsys.AllEvaluatorParams, 0, 100),
+		execute: func(a []interface{}, loop hipathsys.Looper) {
+			args, argsLoop = a, loop
+		},
+	}
+
+	testExpression1 := newTestExpression(hipathsys.NewString("test1"))
+	testExpression2 := newTestExpression(hipathsys.NewString("test2"))
+	ctx := test.NewTestContext(t)
+	e := newFunctionInvocation(function, []hipathsys.Evaluator{testExpression1, testExpression2})
+
+	_, err := e.Evaluate(ctx, newTestingType(t), testLoop)
+	assert.NoError(t, err, "no error expected")
+	if assert.Len(t, args, 2) {
+		assert.Same(t, testExpression1, args[0])
+		assert.Same(t, testExpression2, args[1])
+	}
+	assert.Equal(t, 0, testExpression1.invocationCount)
+	assert.Equal(t, 0, testExpression2.invocationCount)
+	if assert.NotNil(t, argsLoop) {
+		assert.Nil(t, argsLoop.Evaluator())
+		assert.Same(t, testLoop, hipathsys.LoopOuter(argsLoop))
+	}
+}
+
+func TestFunctionInvocationLoopNoArgs(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	e := newFunctionInvocation(newExistsFunction(), []hipathsys.Evaluator{})
+
+	res, err := e.Evaluate(ctx, hipathsys.NewString("test"), nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.True, res)
+}
+
 func TestFunctionInvocationArgsError(t *testing.T) {
 	function := &testInvocationArgsFunction{
 		t:            t,
@@ -133,6 +173,80 @@ func TestFunctionInvocationError(t *testing.T) {
 	assert.Nil(t, res, "no res expected")
 }
 
+func TestFunctionInvocationCanceled(t *testing.T) {
+	function := &testInvocationArgsFunction{
+		t:            t,
+		BaseFunction: hipathsys.NewBaseFunction("test", -1, 0, 0),
+	}
+
+	goCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ctx := hipathsys.BindGoContext(test.NewTestContext(t), goCtx)
+	e := newFunctionInvocation(function, []hipathsys.Evaluator{})
+
+	res, err := e.Evaluate(ctx, newTestingType(t), testLoop)
+	if assert.Error(t, err, "error expected") {
+		assert.True(t, errors.Is(err, context.Canceled))
+	}
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestFunctionInvocationArgsCanceled(t *testing.T) {
+	function := &testInvocationArgsFunction{
+		t:            t,
+		BaseFunction: hipathsys.NewBaseFunction("test", -1, 0, 2),
+	}
+
+	goCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx := hipathsys.BindGoContext(test.NewTestContext(t), goCtx)
+	e := newFunctionInvocation(function, []hipathsys.Evaluator{
+		newTestCancelExpression(nil, cancel), newFunctionInvocation(function, []hipathsys.Evaluator{})})
+
+	res, err := e.Evaluate(ctx, newTestingType(t), testLoop)
+	if assert.Error(t, err, "error expected") {
+		assert.True(t, errors.Is(err, context.Canceled))
+	}
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestFunctionInvocationMaxDepth(t *testing.T) {
+	function := &testInvocationArgsFunction{
+		t:            t,
+		BaseFunction: hipathsys.NewBaseFunction("test", -1, 0, 1),
+	}
+
+	ctx := test.NewTestContextWithLimits(t, hipathsys.Limits{MaxDepth: 1})
+	e := newFunctionInvocation(function, []hipathsys.Evaluator{
+		newFunctionInvocation(function, []hipathsys.Evaluator{})})
+
+	res, err := e.Evaluate(ctx, newTestingType(t), testLoop)
+	var limitErr *hipathsys.LimitError
+	if assert.True(t, errors.As(err, &limitErr), "limit error expected") {
+		assert.Equal(t, hipathsys.DepthLimitName, limitErr.Name())
+	}
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestFunctionInvocationMaxSteps(t *testing.T) {
+	function := &testInvocationArgsFunction{
+		t:            t,
+		BaseFunction: hipathsys.NewBaseFunction("test", -1, 0, 0),
+	}
+
+	ctx := test.NewTestContextWithLimits(t, hipathsys.Limits{MaxSteps: 1})
+	e := newFunctionInvocation(function, []hipathsys.Evaluator{})
+
+	_, err := e.Evaluate(ctx, newTestingType(t), testLoop)
+	assert.NoError(t, err, "no error expected")
+	res, err := e.Evaluate(ctx, newTestingType(t), testLoop)
+	var limitErr *hipathsys.LimitError
+	if assert.True(t, errors.As(err, &limitErr), "limit error expected") {
+		assert.Equal(t, hipathsys.StepsLimitName, limitErr.Name())
+	}
+	assert.Nil(t, res, "no result expected")
+}
+
 func TestLookupFunctionInvocationNotFound(t *testing.T) {
 	fi, err := LookupFunctionInvocation("test", make([]hipathsys.Evaluator, 0))
 	assert.EqualError(t, err, "executor has not been defined: test", "error expected")
@@ -151,6 +265,47 @@ func TestLookupFunctionInvocationTooManyArgs(t *testing.T) {
 	assert.Nil(t, fi, "no executor invocation expected")
 }
 
+func TestLookupFunctionInvocationLambdaArg(t *testing.T) {
+	lambda := NewLambdaExpression([]string{"x"}, NewLambdaParamInvocation("x"))
+	fi, err := LookupFunctionInvocation("where", []hipathsys.Evaluator{lambda})
+	assert.NoError(t, err, "no error expected")
+	assert.NotNil(t, fi, "executor invocation expected")
+}
+
+func TestLookupFunctionInvocationLambdaNoEvaluatorArg(t *testing.T) {
+	lambda := NewLambdaExpression([]string{"x"}, NewLambdaParamInvocation("x"))
+	fi, err := LookupFunctionInvocation("iif", []hipathsys.Evaluator{lambda, NewEmptyLiteral()})
+	assert.EqualError(t, err, "executor iif does not accept a lambda expression as parameter 0", "error expected")
+	assert.True(t, errors.Is(err, hipathsys.ErrInvalidArguments))
+	assert.Nil(t, fi, "no executor invocation expected")
+}
+
+func TestLookupFunctionInvocationWithRegistry(t *testing.T) {
+	function := &testInvocationArgsFunction{
+		t:            t,
+		BaseFunction: hipathsys.NewBaseFunction("test", -1, 0, 0),
+	}
+	registry := hipathsys.NewFunctionRegistry(BuiltInFunctions)
+	assert.NoError(t, registry.Register(function), "no error expected")
+
+	fi, err := LookupFunctionInvocationWithRegistry(registry, "test", make([]hipathsys.Evaluator, 0))
+	assert.NoError(t, err, "no error expected")
+	if assert.NotNil(t, fi, "executor invocation expected") {
+		assert.Same(t, function, fi.executor)
+	}
+
+	fi, err = LookupFunctionInvocationWithRegistry(registry, "empty", make([]hipathsys.Evaluator, 0))
+	assert.NoError(t, err, "no error expected")
+	assert.NotNil(t, fi, "executor invocation expected")
+}
+
+func TestLookupFunctionInvocationWithRegistryNotFound(t *testing.T) {
+	registry := hipathsys.NewFunctionRegistry(nil)
+	fi, err := LookupFunctionInvocationWithRegistry(registry, "empty", make([]hipathsys.Evaluator, 0))
+	assert.EqualError(t, err, "executor has not been defined: empty", "error expected")
+	assert.Nil(t, fi, "no executor invocation expected")
+}
+
 type testInvocationArgsFunction struct {
 	hipathsys.BaseFunction
 	t *testing.T
@@ -161,9 +316,9 @@ func (f *testInvocationArgsFunction) Execute(ctx hipathsys.ContextAccessor, _ in
 	assert.Same(t, testLoop, loop)
 
 	c := ctx.NewCollection()
-	c.MustAdd(hipathsys.NewInteger(int32(len(args))))
+	c.Add(hipathsys.NewInteger(int32(len(args))))
 	for _, a := range args {
-		c.MustAdd(a)
+		c.Add(a)
 	}
 	return c, nil
 }
@@ -182,6 +337,16 @@ func (f *testInvocationLoopFunction) Execute(_ hipathsys.ContextAccessor, _ inte
 	return nil, nil
 }
 
+type testInvocationEvaluatorsFunction struct {
+	hipathsys.BaseFunction
+	execute func(args []interface{}, loop hipathsys.Looper)
+}
+
+func (f *testInvocationEvaluatorsFunction) Execute(_ hipathsys.ContextAccessor, _ interface{}, args []interface{}, loop hipathsys.Looper) (interface{}, error) {
+	f.execute(args, loop)
+	return nil, nil
+}
+
 type testInvocationErrFunction struct {
 	hipathsys.BaseFunction
 }
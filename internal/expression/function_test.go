@@ -31,6 +31,7 @@ package expression
 import (
 	"github.com/healthiop/hipath/hipathsys"
 	"github.com/stretchr/testify/assert"
+	"math"
 	"testing"
 )
 
@@ -57,6 +58,7 @@ var functionTests = []struct {
 	{"select", newSelectFunction(), 0, 1, 1},
 	{"repeat", repeatFunc, 0, 1, 1},
 	{"ofType", newOfTypeFunction(), -1, 1, 1},
+	{"sort", newSortFunction(), hipathsys.AllEvaluatorParams, 0, math.MaxInt32},
 	{"single", newSingleFunction(), -1, 0, 0},
 	{"first", newFirstFunction(), -1, 0, 0},
 	{"last", newLastFunction(), -1, 0, 0},
@@ -85,6 +87,7 @@ var functionTests = []struct {
 	{"toTime", toTimeFunc, -1, 0, 0},
 	{"convertsToTime", newConvertsToTimeFunction(), -1, 0, 0},
 	{"indexOf", newIndexOfFunction(), -1, 1, 1},
+	{"lastIndexOf", newLastIndexOfFunction(), -1, 1, 1},
 	{"substring", newSubstringFunction(), -1, 1, 2},
 	{"startsWith", newStartsWithFunction(), -1, 1, 1},
 	{"endsWith", newEndsWithFunction(), -1, 1, 1},
@@ -93,9 +96,13 @@ var functionTests = []struct {
 	{"lower", newLowerFunction(), -1, 0, 0},
 	{"replace", newReplaceFunction(), -1, 2, 2},
 	{"matches", newMatchesFunction(), -1, 1, 1},
+	{"matchesFull", newMatchesFullFunction(), -1, 1, 1},
 	{"replaceMatches", newReplaceMatchesFunction(), -1, 2, 2},
 	{"length", newLengthFunction(), -1, 0, 0},
 	{"toChars", newToCharsFunction(), -1, 0, 0},
+	{"trim", newTrimFunction(), -1, 0, 0},
+	{"split", newSplitFunction(), -1, 1, 1},
+	{"join", newJoinFunction(), -1, 0, 1},
 	{"abs", newAbsFunction(), -1, 0, 0},
 	{"ceiling", newCeilingFunction(), -1, 0, 0},
 	{"exp", newExpFunction(), -1, 0, 0},
@@ -106,6 +113,7 @@ var functionTests = []struct {
 	{"round", newRoundFunction(), -1, 0, 1},
 	{"sqrt", newSqrtFunction(), -1, 0, 0},
 	{"truncate", newTruncateFunction(), -1, 0, 0},
+	{"defineVariable", newDefineVariableFunction(), -1, 1, 2},
 	{"trace", newTraceFunction(), 1, 1, 2},
 	{"now", newNowFunction(), -1, 0, 0},
 	{"timeOfDay", newTimeOfDayFunction(), -1, 0, 0},
@@ -115,12 +123,24 @@ var functionTests = []struct {
 	{"as", newAsFunction(), -1, 1, 1},
 	{"is", newIsFunction(), -1, 1, 1},
 	{"aggregate", newAggregateFunction(), 0, 1, 2},
+	{"sum", newSumFunction(), -1, 0, 0},
+	{"min", newMinFunction(), -1, 0, 0},
+	{"max", newMaxFunction(), -1, 0, 0},
+	{"avg", newAvgFunction(), -1, 0, 0},
+	{"extension", newExtensionFunction(), -1, 1, 1},
+	{"hasValue", newHasValueFunction(), -1, 0, 0},
+	{"getValue", newGetValueFunction(), -1, 0, 0},
+	{"resolve", newResolveFunction(), -1, 0, 0},
+	{"memberOf", newMemberOfFunction(), -1, 1, 1},
+	{"subsumes", newSubsumesFunction(), -1, 1, 1},
+	{"subsumedBy", newSubsumedByFunction(), -1, 1, 1},
+	{"conformsTo", newConformsToFunction(), -1, 1, 1},
 }
 
 func TestFunctions(t *testing.T) {
 	for _, tt := range functionTests {
 		t.Run(tt.name, func(t *testing.T) {
-			if fe, found := functionsByName[tt.name]; !found {
+			if fe := BuiltInFunctions.Lookup(tt.name); fe == nil {
 				t.Errorf("executor %s has not been defined", tt.name)
 			} else {
 				assert.Equal(t, tt.executor, fe)
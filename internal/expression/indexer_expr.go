@@ -28,21 +28,23 @@
 
 package expression
 
-import (
-	"fmt"
-	"github.com/healthiop/hipath/hipathsys"
-)
+import "github.com/healthiop/hipath/hipathsys"
 
 type IndexerExpression struct {
 	exprEvaluator  hipathsys.Evaluator
 	indexEvaluator hipathsys.Evaluator
+	sourceNode
 }
 
 func NewIndexerExpression(exprEvaluator hipathsys.Evaluator, indexEvaluator hipathsys.Evaluator) *IndexerExpression {
-	return &IndexerExpression{exprEvaluator, indexEvaluator}
+	return &IndexerExpression{exprEvaluator, indexEvaluator, sourceNode{}}
 }
 
 func (e *IndexerExpression) Evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
+	return e.sourceResult(e.evaluate(ctx, node, loop))
+}
+
+func (e *IndexerExpression) evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
 	col, err := e.exprEvaluator.Evaluate(ctx, node, loop)
 	if err != nil {
 		return nil, err
@@ -58,7 +60,7 @@ func (e *IndexerExpression) Evaluate(ctx hipathsys.ContextAccessor, node interfa
 
 	var indexValue int
 	if n, ok := index.(hipathsys.NumberAccessor); !ok {
-		return nil, fmt.Errorf("index is not a number: %T", index)
+		return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "index is not a number: %T", index)
 	} else {
 		indexValue = int(n.Int())
 	}
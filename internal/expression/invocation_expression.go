@@ -33,13 +33,18 @@ import "github.com/healthiop/hipath/hipathsys"
 type InvocationExpression struct {
 	exprEvaluator       hipathsys.Evaluator
 	invocationEvaluator hipathsys.Evaluator
+	sourceNode
 }
 
 func NewInvocationExpression(exprEvaluator hipathsys.Evaluator, invocationEvaluator hipathsys.Evaluator) *InvocationExpression {
-	return &InvocationExpression{exprEvaluator, invocationEvaluator}
+	return &InvocationExpression{exprEvaluator, invocationEvaluator, sourceNode{}}
 }
 
 func (e *InvocationExpression) Evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
+	return e.sourceResult(e.evaluate(ctx, node, loop))
+}
+
+func (e *InvocationExpression) evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
 	exprNode, err := e.exprEvaluator.Evaluate(ctx, node, loop)
 	if err != nil {
 		return nil, err
@@ -38,7 +38,7 @@ import (
 func TestInvocationExpressionEvaluate(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	c := ctx.NewCollection()
-	c.MustAdd(hipathsys.NewInteger(123))
+	c.Add(hipathsys.NewInteger(123))
 
 	f, err := LookupFunctionInvocation("empty", []hipathsys.Evaluator{})
 	if err != nil {
@@ -71,7 +71,7 @@ func TestInvocationExpressionEvaluateExprError(t *testing.T) {
 func TestInvocationExpressionEvaluateFuncErr(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	c := ctx.NewCollection()
-	c.MustAdd(hipathsys.NewInteger(123))
+	c.Add(hipathsys.NewInteger(123))
 
 	evaluator := NewInvocationExpression(newTestExpression(c), newTestErrorExpression())
 
@@ -32,12 +32,17 @@ import "github.com/healthiop/hipath/hipathsys"
 
 type InvocationTerm struct {
 	evaluator hipathsys.Evaluator
+	sourceNode
 }
 
 func NewInvocationTerm(evaluator hipathsys.Evaluator) *InvocationTerm {
-	return &InvocationTerm{evaluator}
+	return &InvocationTerm{evaluator, sourceNode{}}
 }
 
 func (t *InvocationTerm) Evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
+	return t.sourceResult(t.evaluate(ctx, node, loop))
+}
+
+func (t *InvocationTerm) evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
 	return t.evaluator.Evaluate(ctx, node, loop)
 }
@@ -0,0 +1,97 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package expression
+
+import "github.com/healthiop/hipath/hipathsys"
+
+// LambdaExpression binds its parameters to the item that is currently
+// iterated by the invoking function. With two parameters, the first one is
+// bound to $total and the second one to the item, like in
+// aggregate((sum, x) => sum + x, 0).
+type LambdaExpression struct {
+	sourceNode
+	paramNames []string
+	body       hipathsys.Evaluator
+}
+
+type LambdaParamInvocation struct {
+	sourceNode
+	name string
+}
+
+func NewLambdaExpression(paramNames []string, body hipathsys.Evaluator) *LambdaExpression {
+	return &LambdaExpression{paramNames: paramNames, body: body}
+}
+
+func (e *LambdaExpression) ParamNames() []string {
+	return e.paramNames
+}
+
+func (e *LambdaExpression) Evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
+	return e.sourceResult(e.evaluate(ctx, node, loop))
+}
+
+func (e *LambdaExpression) evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
+	if len(e.paramNames) == 0 {
+		return e.body.Evaluate(ctx, node, loop)
+	}
+
+	// when the lambda is iterated by a function, $this refers to the loop
+	// in which the function has been invoked
+	enclosing := loop
+	if loop != nil && loop.Evaluator() == hipathsys.Evaluator(e) {
+		enclosing = hipathsys.LoopOuter(loop)
+	}
+
+	params := make(map[string]interface{}, len(e.paramNames))
+	params[e.paramNames[len(e.paramNames)-1]] = node
+	if len(e.paramNames) > 1 && loop != nil {
+		params[e.paramNames[0]] = loop.Total()
+	}
+	return e.body.Evaluate(ctx, node, hipathsys.NewLambdaLoop(loop, enclosing, params))
+}
+
+func NewLambdaParamInvocation(name string) *LambdaParamInvocation {
+	return &LambdaParamInvocation{name: name}
+}
+
+func (e *LambdaParamInvocation) Name() string {
+	return e.name
+}
+
+func (e *LambdaParamInvocation) Evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
+	return e.sourceResult(e.evaluate(loop))
+}
+
+func (e *LambdaParamInvocation) evaluate(loop hipathsys.Looper) (interface{}, error) {
+	if value, ok := hipathsys.LoopParam(loop, e.name); ok {
+		return value, nil
+	}
+	return nil, hipathsys.NewCodedError(hipathsys.UndefinedVariableErrorCode, "lambda parameter %s is not defined", e.name)
+}
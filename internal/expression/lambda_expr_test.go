@@ -0,0 +1,184 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package expression
+
+import (
+	"errors"
+	"github.com/healthiop/hipath/hipathsys"
+	"github.com/healthiop/hipath/internal/test"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLambdaExpressionWithoutParam(t *testing.T) {
+	loop := hipathsys.NewLoop(nil)
+	this := hipathsys.NewString("test")
+	loop.IncIndex(this)
+
+	e := NewLambdaExpression(nil, NewThisInvocation())
+	assert.Empty(t, e.ParamNames())
+	res, err := e.Evaluate(test.NewTestContext(t), this, loop)
+	assert.NoError(t, err, "no error expected")
+	assert.Same(t, this, res)
+}
+
+func TestLambdaExpressionParam(t *testing.T) {
+	node := hipathsys.NewString("test")
+
+	e := NewLambdaExpression([]string{"x"}, NewLambdaParamInvocation("x"))
+	assert.Equal(t, []string{"x"}, e.ParamNames())
+	res, err := e.Evaluate(test.NewTestContext(t), node, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Same(t, node, res)
+}
+
+func TestLambdaExpressionTotalParam(t *testing.T) {
+	e := NewLambdaExpression([]string{"sum", "x"}, NewArithmeticExpression(
+		NewLambdaParamInvocation("sum"), hipathsys.AdditionOp, NewLambdaParamInvocation("x")))
+	assert.Equal(t, []string{"sum", "x"}, e.ParamNames())
+	loop := hipathsys.NewLoop(e)
+	loop.IncIndex(hipathsys.NewInteger(2))
+	loop.SetTotal(hipathsys.NewInteger(10))
+
+	res, err := e.Evaluate(test.NewTestContext(t), hipathsys.NewInteger(2), loop)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.NewInteger(12), res)
+}
+
+func TestLambdaExpressionLoopThis(t *testing.T) {
+	outerThis := hipathsys.NewString("outer")
+	outer := hipathsys.NewLoop(nil)
+	outer.IncIndex(outerThis)
+
+	e := NewLambdaExpression([]string{"x"}, NewThisInvocation())
+	loop := hipathsys.NewLoopWithOuter(e, outer)
+	this := hipathsys.NewString("test")
+	loop.IncIndex(this)
+
+	res, err := e.Evaluate(test.NewTestContext(t), this, loop)
+	assert.NoError(t, err, "no error expected")
+	assert.Same(t, outerThis, res, "this of enclosing loop expected")
+}
+
+func TestLambdaExpressionArgThis(t *testing.T) {
+	loop := hipathsys.NewLoop(nil)
+	this := hipathsys.NewString("test")
+	loop.IncIndex(this)
+
+	e := NewLambdaExpression([]string{"x"}, NewThisInvocation())
+	res, err := e.Evaluate(test.NewTestContext(t), hipathsys.NewString("node"), loop)
+	assert.NoError(t, err, "no error expected")
+	assert.Same(t, this, res, "this of current loop expected")
+}
+
+func TestLambdaExpressionLoopThisUndefined(t *testing.T) {
+	e := NewLambdaExpression([]string{"x"}, NewThisInvocation())
+	loop := hipathsys.NewLoop(e)
+	this := hipathsys.NewString("test")
+	loop.IncIndex(this)
+
+	res, err := e.Evaluate(test.NewTestContext(t), this, loop)
+	assert.Error(t, err, "error expected")
+	assert.True(t, errors.Is(err, hipathsys.ErrUndefinedVariable))
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestLambdaExpressionLoopIndexTotal(t *testing.T) {
+	e := NewLambdaExpression([]string{"x"}, NewArithmeticExpression(
+		NewIndexInvocation(), hipathsys.AdditionOp, NewTotalInvocation()))
+	loop := hipathsys.NewLoop(e)
+	loop.IncIndex(hipathsys.NewInteger(1))
+	loop.IncIndex(hipathsys.NewInteger(2))
+	loop.SetTotal(hipathsys.NewInteger(10))
+
+	res, err := e.Evaluate(test.NewTestContext(t), nil, loop)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.NewInteger(11), res)
+}
+
+func TestLambdaExpressionError(t *testing.T) {
+	e := NewLambdaExpression([]string{"x"}, newTestErrorExpression())
+	res, err := e.Evaluate(test.NewTestContext(t), nil, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestLambdaParamInvocation(t *testing.T) {
+	value := hipathsys.NewString("test")
+	e := NewLambdaParamInvocation("x")
+	assert.Equal(t, "x", e.Name())
+
+	res, err := e.Evaluate(nil, nil, hipathsys.NewLambdaLoop(nil, nil, map[string]interface{}{"x": value}))
+	assert.NoError(t, err, "no error expected")
+	assert.Same(t, value, res)
+}
+
+func TestLambdaParamInvocationOuter(t *testing.T) {
+	value := hipathsys.NewString("test")
+	loop := hipathsys.NewLoopWithOuter(nil, hipathsys.NewLambdaLoop(nil, nil, map[string]interface{}{"x": value}))
+
+	res, err := NewLambdaParamInvocation("x").Evaluate(nil, nil, loop)
+	assert.NoError(t, err, "no error expected")
+	assert.Same(t, value, res)
+}
+
+func TestLambdaParamInvocationUndefined(t *testing.T) {
+	res, err := NewLambdaParamInvocation("y").Evaluate(nil, nil,
+		hipathsys.NewLambdaLoop(nil, nil, map[string]interface{}{"x": hipathsys.NewString("test")}))
+	assert.Error(t, err, "error expected")
+	assert.True(t, errors.Is(err, hipathsys.ErrUndefinedVariable))
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestLambdaParamInvocationNoLoop(t *testing.T) {
+	res, err := NewLambdaParamInvocation("x").Evaluate(nil, nil, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestLambdaFunctionInvocation(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	c := ctx.NewCollection()
+	c.Add(hipathsys.NewInteger(1))
+	c.Add(hipathsys.NewInteger(5))
+
+	lambda := NewLambdaExpression([]string{"x"}, NewComparisonExpression(
+		NewLambdaParamInvocation("x"), GreaterThanOp, newTestExpression(hipathsys.NewInteger(2))))
+	f, err := LookupFunctionInvocation("where", []hipathsys.Evaluator{lambda})
+	if assert.NoError(t, err, "no error expected") {
+		res, err := f.Evaluate(ctx, c, nil)
+		assert.NoError(t, err, "no error expected")
+		if assert.Implements(t, (*hipathsys.CollectionAccessor)(nil), res) {
+			col := res.(hipathsys.CollectionAccessor)
+			if assert.Equal(t, 1, col.Count()) {
+				assert.Equal(t, hipathsys.NewInteger(5), col.Get(0))
+			}
+		}
+	}
+}
@@ -28,10 +28,7 @@
 
 package expression
 
-import (
-	"fmt"
-	"github.com/healthiop/hipath/hipathsys"
-)
+import "github.com/healthiop/hipath/hipathsys"
 
 type ThisInvocation struct {
 }
@@ -43,8 +40,8 @@ func NewThisInvocation() *ThisInvocation {
 }
 
 func (t *ThisInvocation) Evaluate(_ hipathsys.ContextAccessor, _ interface{}, loop hipathsys.Looper) (interface{}, error) {
-	if loop == nil {
-		return nil, fmt.Errorf("this invocation can only be used inside a loop")
+	if !hipathsys.HasThis(loop) {
+		return nil, hipathsys.NewCodedError(hipathsys.UndefinedVariableErrorCode, "this invocation can only be used inside a loop")
 	}
 	return loop.This(), nil
 }
@@ -59,8 +56,8 @@ func NewIndexInvocation() *IndexInvocation {
 }
 
 func (t *IndexInvocation) Evaluate(_ hipathsys.ContextAccessor, _ interface{}, loop hipathsys.Looper) (interface{}, error) {
-	if loop == nil {
-		return nil, fmt.Errorf("index invocation can only be used inside a loop")
+	if !hipathsys.HasIndex(loop) {
+		return nil, hipathsys.NewCodedError(hipathsys.UndefinedVariableErrorCode, "index invocation can only be used inside a loop")
 	}
 	return hipathsys.NewInteger(int32(loop.Index())), nil
 }
@@ -75,8 +72,8 @@ func NewTotalInvocation() *TotalInvocation {
 }
 
 func (t *TotalInvocation) Evaluate(_ hipathsys.ContextAccessor, _ interface{}, loop hipathsys.Looper) (interface{}, error) {
-	if loop == nil {
-		return nil, fmt.Errorf("index invocation can only be used inside a loop")
+	if !hipathsys.HasIndex(loop) {
+		return nil, hipathsys.NewCodedError(hipathsys.UndefinedVariableErrorCode, "index invocation can only be used inside a loop")
 	}
 	return loop.Total(), nil
 }
@@ -28,10 +28,7 @@
 
 package expression
 
-import (
-	"fmt"
-	"github.com/healthiop/hipath/hipathsys"
-)
+import "github.com/healthiop/hipath/hipathsys"
 
 type absFunction struct {
 	hipathsys.BaseFunction
@@ -269,7 +266,7 @@ func arithmeticNode(node interface{}) (hipathsys.ArithmeticApplier, error) {
 	}
 
 	if a, ok := value.(hipathsys.ArithmeticApplier); !ok {
-		return nil, fmt.Errorf("arithmetic cannot be applied: %T", value)
+		return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "arithmetic cannot be applied: %T", value)
 	} else {
 		return a, nil
 	}
@@ -282,7 +279,7 @@ func numberNode(node interface{}) (hipathsys.NumberAccessor, error) {
 	}
 
 	if a, ok := value.(hipathsys.NumberAccessor); !ok {
-		return nil, fmt.Errorf("not a number: %T", value)
+		return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "not a number: %T", value)
 	} else {
 		return a, nil
 	}
@@ -295,7 +292,7 @@ func integerNode(node interface{}) (hipathsys.IntegerAccessor, error) {
 	}
 
 	if a, ok := value.(hipathsys.IntegerAccessor); !ok {
-		return nil, fmt.Errorf("not an integer: %T", value)
+		return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "not an integer: %T", value)
 	} else {
 		return a, nil
 	}
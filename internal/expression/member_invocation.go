@@ -28,23 +28,29 @@
 
 package expression
 
-import (
-	"fmt"
-	"github.com/healthiop/hipath/hipathsys"
-)
+import "github.com/healthiop/hipath/hipathsys"
 
 type MemberInvocation struct {
 	name string
+	sourceNode
 }
 
 func NewMemberInvocation(name string) *MemberInvocation {
-	return &MemberInvocation{name}
+	return &MemberInvocation{name, sourceNode{}}
 }
 
 func (i *MemberInvocation) Evaluate(ctx hipathsys.ContextAccessor, node interface{}, _ hipathsys.Looper) (interface{}, error) {
+	return i.sourceResult(i.evaluate(ctx, node))
+}
+
+func (i *MemberInvocation) evaluate(ctx hipathsys.ContextAccessor, node interface{}) (interface{}, error) {
 	if node == nil {
-		return nil, fmt.Errorf("cannot extract path from empty: %s", i.name)
+		return nil, hipathsys.NewCodedError(hipathsys.EvaluationErrorCode, "cannot extract path from empty: %s", i.name)
 	}
 
-	return ctx.ModelAdapter().Navigate(node, i.name)
+	res, err := ctx.ModelAdapter().Navigate(node, i.name)
+	if err != nil {
+		return nil, hipathsys.NewAdapterError(err)
+	}
+	return res, nil
 }
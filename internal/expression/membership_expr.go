@@ -28,22 +28,24 @@
 
 package expression
 
-import (
-	"fmt"
-	"github.com/healthiop/hipath/hipathsys"
-)
+import "github.com/healthiop/hipath/hipathsys"
 
 type ContainsExpression struct {
 	evalLeft  hipathsys.Evaluator
 	evalRight hipathsys.Evaluator
 	inverse   bool
+	sourceNode
 }
 
 func NewContainsExpression(evalLeft hipathsys.Evaluator, evalRight hipathsys.Evaluator, inverse bool) *ContainsExpression {
-	return &ContainsExpression{evalLeft, evalRight, inverse}
+	return &ContainsExpression{evalLeft, evalRight, inverse, sourceNode{}}
 }
 
 func (e *ContainsExpression) Evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
+	return e.sourceResult(e.evaluate(ctx, node, loop))
+}
+
+func (e *ContainsExpression) evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
 	left, err := e.evalLeft.Evaluate(ctx, node, loop)
 	if err != nil {
 		return nil, err
@@ -71,7 +73,7 @@ func (e *ContainsExpression) Evaluate(ctx hipathsys.ContextAccessor, node interf
 		return nil, nil
 	}
 	if hipathsys.IsCollection(val) {
-		return nil, fmt.Errorf("collection membership cannot be checked with value: %T", val)
+		return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "collection membership cannot be checked with value: %T", val)
 	}
 
 	return hipathsys.BooleanOf(col.Contains(val)), nil
@@ -28,20 +28,32 @@
 
 package expression
 
-import (
-	"fmt"
-	"github.com/healthiop/hipath/hipathsys"
-)
+import "github.com/healthiop/hipath/hipathsys"
 
+// NegatorExpression negates the result of its operand. When the built-in
+// sort function is invoked with a sort key negator as key (e.g. -name or
+// x => -x.name), the key is sorted descending instead of being negated.
 type NegatorExpression struct {
 	evaluator hipathsys.Evaluator
+	sortKey   bool
+	sourceNode
 }
 
 func NewNegatorExpression(evaluator hipathsys.Evaluator) *NegatorExpression {
-	return &NegatorExpression{evaluator}
+	return &NegatorExpression{evaluator, false, sourceNode{}}
+}
+
+// NewSortKeyNegatorExpression returns a negator whose operand has not been
+// parenthesized and that marks a descending key when used as sort key.
+func NewSortKeyNegatorExpression(evaluator hipathsys.Evaluator) *NegatorExpression {
+	return &NegatorExpression{evaluator, true, sourceNode{}}
 }
 
 func (e *NegatorExpression) Evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
+	return e.sourceResult(e.evaluate(ctx, node, loop))
+}
+
+func (e *NegatorExpression) evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
 	data, err := e.evaluator.Evaluate(ctx, node, loop)
 	if err != nil {
 		return nil, err
@@ -53,7 +65,7 @@ func (e *NegatorExpression) Evaluate(ctx hipathsys.ContextAccessor, node interfa
 
 	negator, ok := data.(hipathsys.Negator)
 	if !ok {
-		return nil, fmt.Errorf("cannot negate value of type: %T", data)
+		return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "cannot negate value of type: %T", data)
 	}
 	return negator.Negate(), nil
 }
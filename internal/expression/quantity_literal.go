@@ -29,7 +29,6 @@
 package expression
 
 import (
-	"fmt"
 	"github.com/healthiop/hipath/hipathsys"
 	"regexp"
 )
@@ -60,7 +59,7 @@ func parseQuantityUnit(unit string) (hipathsys.StringAccessor, error) {
 
 	u := parseStringLiteral(unit, stringDelimiterChar)
 	if !quantityUnitRegexp.MatchString(u) {
-		return nil, fmt.Errorf("invalid quantity unit: %s", u)
+		return nil, hipathsys.NewCodedError(hipathsys.SyntaxErrorCode, "invalid quantity unit: %s", u)
 	}
 	return hipathsys.NewString(u), nil
 }
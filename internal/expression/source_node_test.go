@@ -0,0 +1,85 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package expression
+
+import (
+	"errors"
+	"github.com/healthiop/hipath/hipathsys"
+	"github.com/healthiop/hipath/internal/test"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSourceNode(t *testing.T) {
+	e := NewNegatorExpression(NewNumberLiteralInt(10))
+	assert.Nil(t, e.SourceRange())
+
+	source := hipathsys.NewSourceRange(1, 0, "-10")
+	e.SetSourceRange(source)
+	assert.Same(t, source, e.SourceRange())
+}
+
+func TestSourceNodeError(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	source := hipathsys.NewSourceRange(1, 0, "-error")
+	e := NewNegatorExpression(newTestErrorExpression())
+	e.SetSourceRange(source)
+
+	res, err := e.Evaluate(ctx, nil, nil)
+	var evalErr *hipathsys.EvaluationError
+	if assert.True(t, errors.As(err, &evalErr), "evaluation error expected") {
+		assert.Same(t, source, evalErr.Source())
+	}
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestSourceNodeErrorInnermost(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	inner := NewNegatorExpression(newTestErrorExpression())
+	inner.SetSourceRange(hipathsys.NewSourceRange(1, 1, "-error"))
+	outer := NewNegatorExpression(inner)
+	outer.SetSourceRange(hipathsys.NewSourceRange(1, 0, "--error"))
+
+	_, err := outer.Evaluate(ctx, nil, nil)
+	var evalErr *hipathsys.EvaluationError
+	if assert.True(t, errors.As(err, &evalErr), "evaluation error expected") {
+		assert.Equal(t, "-error", evalErr.Source().Text())
+	}
+}
+
+func TestSourceNodeErrorNoSource(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	e := NewNegatorExpression(newTestErrorExpression())
+
+	_, err := e.Evaluate(ctx, nil, nil)
+	if assert.Error(t, err, "error expected") {
+		var evalErr *hipathsys.EvaluationError
+		assert.False(t, errors.As(err, &evalErr), "no evaluation error expected")
+	}
+}
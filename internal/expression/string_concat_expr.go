@@ -28,21 +28,23 @@
 
 package expression
 
-import (
-	"fmt"
-	"github.com/healthiop/hipath/hipathsys"
-)
+import "github.com/healthiop/hipath/hipathsys"
 
 type StringConcatExpression struct {
 	evalLeft  hipathsys.Evaluator
 	evalRight hipathsys.Evaluator
+	sourceNode
 }
 
 func NewStringConcatExpression(evalLeft hipathsys.Evaluator, evalRight hipathsys.Evaluator) *StringConcatExpression {
-	return &StringConcatExpression{evalLeft, evalRight}
+	return &StringConcatExpression{evalLeft, evalRight, sourceNode{}}
 }
 
 func (e *StringConcatExpression) Evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
+	return e.sourceResult(e.evaluate(ctx, node, loop))
+}
+
+func (e *StringConcatExpression) evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
 	left, err := e.evalLeft.Evaluate(ctx, node, loop)
 	if err != nil {
 		return nil, err
@@ -61,12 +63,12 @@ func (e *StringConcatExpression) Evaluate(ctx hipathsys.ContextAccessor, node in
 	var leftString, rightString hipathsys.Stringifier
 	if left != nil {
 		if leftString, ok = left.(hipathsys.Stringifier); !ok {
-			return nil, fmt.Errorf("left operand is not string: %T", left)
+			return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "left operand is not string: %T", left)
 		}
 	}
 	if right != nil {
 		if rightString, ok = right.(hipathsys.Stringifier); !ok {
-			return nil, fmt.Errorf("right operand is not string: %T", right)
+			return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "right operand is not string: %T", right)
 		}
 	}
 
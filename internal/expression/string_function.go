@@ -29,13 +29,15 @@
 package expression
 
 import (
-	"fmt"
 	"github.com/healthiop/hipath/hipathsys"
 	"regexp"
 	"strings"
 	"unicode/utf8"
 )
 
+// indexOfFunction returns the character (rune) index and not the byte index
+// of the substring, so that the index is consistent with length() and
+// substring() also for strings with multi-byte characters.
 type indexOfFunction struct {
 	hipathsys.BaseFunction
 }
@@ -57,8 +59,43 @@ func (f *indexOfFunction) Execute(_ hipathsys.ContextAccessor, node interface{},
 		return nil, err
 	}
 
-	i := strings.Index(s.String(), ss.String())
-	return hipathsys.NewInteger(int32(i)), nil
+	return hipathsys.NewInteger(int32(runeIndex(s.String(), strings.Index(s.String(), ss.String())))), nil
+}
+
+type lastIndexOfFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newLastIndexOfFunction() *lastIndexOfFunction {
+	return &lastIndexOfFunction{
+		BaseFunction: hipathsys.NewBaseFunction("lastIndexOf", -1, 1, 1),
+	}
+}
+
+func (f *lastIndexOfFunction) Execute(_ hipathsys.ContextAccessor, node interface{}, args []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	s, err := stringNode(node)
+	if s == nil || err != nil {
+		return nil, err
+	}
+
+	ss, err := stringNode(args[0])
+	if ss == nil || err != nil {
+		return nil, err
+	}
+
+	if ss.Length() == 0 {
+		return hipathsys.NewInteger(0), nil
+	}
+	return hipathsys.NewInteger(int32(runeIndex(s.String(), strings.LastIndex(s.String(), ss.String())))), nil
+}
+
+// runeIndex converts the specified byte index of the string into the
+// corresponding character index. A negative index is returned unchanged.
+func runeIndex(s string, i int) int {
+	if i <= 0 {
+		return i
+	}
+	return utf8.RuneCountInString(s[:i])
 }
 
 type substringFunction struct {
@@ -286,6 +323,34 @@ func (f *matchesFunction) Execute(_ hipathsys.ContextAccessor, node interface{},
 	return hipathsys.BooleanOf(b), nil
 }
 
+type matchesFullFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newMatchesFullFunction() *matchesFullFunction {
+	return &matchesFullFunction{
+		BaseFunction: hipathsys.NewBaseFunction("matchesFull", -1, 1, 1),
+	}
+}
+
+func (f *matchesFullFunction) Execute(_ hipathsys.ContextAccessor, node interface{}, args []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	s, err := stringNode(node)
+	if s == nil || err != nil {
+		return nil, err
+	}
+
+	re, err := stringNode(args[0])
+	if re == nil || err != nil {
+		return nil, err
+	}
+
+	b, err := regexp.MatchString(`\A(?:`+re.String()+`)\z`, s.String())
+	if err != nil {
+		return nil, err
+	}
+	return hipathsys.BooleanOf(b), nil
+}
+
 type replaceMatchesFunction struct {
 	hipathsys.BaseFunction
 }
@@ -362,12 +427,110 @@ func (f *toCharsFunction) Execute(ctx hipathsys.ContextAccessor, node interface{
 	col := ctx.NewCollection()
 	sr := []rune(s.String())
 	for _, c := range sr {
-		col.MustAdd(hipathsys.StringOf(string(c)))
+		if err := col.Add(hipathsys.StringOf(string(c))); err != nil {
+			return nil, err
+		}
 	}
 
 	return col, nil
 }
 
+type trimFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newTrimFunction() *trimFunction {
+	return &trimFunction{
+		BaseFunction: hipathsys.NewBaseFunction("trim", -1, 0, 0),
+	}
+}
+
+func (f *trimFunction) Execute(_ hipathsys.ContextAccessor, node interface{}, _ []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	s, err := stringNode(node)
+	if s == nil || err != nil {
+		return nil, err
+	}
+
+	return hipathsys.StringOf(strings.TrimSpace(s.String())), nil
+}
+
+type splitFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newSplitFunction() *splitFunction {
+	return &splitFunction{
+		BaseFunction: hipathsys.NewBaseFunction("split", -1, 1, 1),
+	}
+}
+
+func (f *splitFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}, args []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	s, err := stringNode(node)
+	if s == nil || err != nil {
+		return nil, err
+	}
+
+	sep, err := stringNode(args[0])
+	if sep == nil || err != nil {
+		return nil, err
+	}
+
+	if s.Length() == 0 {
+		return hipathsys.NewEmptyCollection(), nil
+	}
+
+	col := ctx.NewCollection()
+	for _, part := range strings.Split(s.String(), sep.String()) {
+		if err := col.Add(hipathsys.StringOf(part)); err != nil {
+			return nil, err
+		}
+	}
+
+	return col, nil
+}
+
+type joinFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newJoinFunction() *joinFunction {
+	return &joinFunction{
+		BaseFunction: hipathsys.NewBaseFunction("join", -1, 0, 1),
+	}
+}
+
+func (f *joinFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}, args []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	col, err := wrapCollection(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	count := col.Count()
+	if count == 0 {
+		return nil, nil
+	}
+
+	var sep hipathsys.StringAccessor
+	if len(args) > 0 {
+		if sep, err = stringNode(args[0]); err != nil {
+			return nil, err
+		}
+	}
+
+	var b strings.Builder
+	for i := 0; i < count; i++ {
+		s, ok := col.Get(i).(hipathsys.StringAccessor)
+		if !ok {
+			return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "not a string: %T", col.Get(i))
+		}
+		if i > 0 && sep != nil {
+			b.WriteString(sep.String())
+		}
+		b.WriteString(s.String())
+	}
+
+	return hipathsys.StringOf(b.String()), nil
+}
+
 func stringNode(node interface{}) (hipathsys.StringAccessor, error) {
 	value := unwrapCollection(node)
 	if value == nil {
@@ -375,7 +538,7 @@ func stringNode(node interface{}) (hipathsys.StringAccessor, error) {
 	}
 
 	if s, ok := value.(hipathsys.StringAccessor); !ok {
-		return nil, fmt.Errorf("not a string: %T", value)
+		return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "not a string: %T", value)
 	} else {
 		return s, nil
 	}
@@ -29,6 +29,7 @@
 package expression
 
 import (
+	"errors"
 	"github.com/healthiop/hipath/hipathsys"
 	"github.com/healthiop/hipath/internal/test"
 	"github.com/stretchr/testify/assert"
@@ -75,8 +76,8 @@ func TestIndexOfFuncMultiCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test"))
-	col.MustAdd(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
 
 	f := newIndexOfFunction()
 	res, err := f.Execute(ctx, col, []interface{}{hipathsys.NewString("test")}, nil)
@@ -88,8 +89,8 @@ func TestIndexOfFuncSubstringMultiCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test"))
-	col.MustAdd(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
 
 	f := newIndexOfFunction()
 	res, err := f.Execute(ctx, hipathsys.NewString("test"), []interface{}{col}, nil)
@@ -117,6 +118,102 @@ func TestIndexOfFuncNotFound(t *testing.T) {
 	assert.Equal(t, hipathsys.NewInteger(-1), res)
 }
 
+func TestIndexOfFuncSpecialChars(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newIndexOfFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("Ä Çx Üx"),
+		[]interface{}{hipathsys.NewString("x")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.NewInteger(3), res)
+}
+
+func TestIndexOfFuncCharIndex(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newIndexOfFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("aéb"),
+		[]interface{}{hipathsys.NewString("b")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.NewInteger(2), res)
+}
+
+func TestLastIndexOfFuncNil(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newLastIndexOfFunction()
+	res, err := f.Execute(ctx, nil, []interface{}{hipathsys.NewString("test")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty collection expected")
+}
+
+func TestLastIndexOfFuncSubstringNil(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newLastIndexOfFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("test"), []interface{}{nil}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty collection expected")
+}
+
+func TestLastIndexOfFuncOther(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newLastIndexOfFunction()
+	res, err := f.Execute(ctx, "test", []interface{}{hipathsys.NewString("test")}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected expected")
+}
+
+func TestLastIndexOfFuncSubstringOther(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newLastIndexOfFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("test"), []interface{}{"test"}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected expected")
+}
+
+func TestLastIndexOfFunc(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newLastIndexOfFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("This is a test. xy ABC xy"),
+		[]interface{}{hipathsys.NewString("xy")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.NewInteger(23), res)
+}
+
+func TestLastIndexOfFuncSpecialChars(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newLastIndexOfFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("Äx Çx Üy"),
+		[]interface{}{hipathsys.NewString("x")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.NewInteger(4), res)
+}
+
+func TestLastIndexOfFuncEmptyString(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newLastIndexOfFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("test"),
+		[]interface{}{hipathsys.NewString("")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.NewInteger(0), res)
+}
+
+func TestLastIndexOfFuncNotFound(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newLastIndexOfFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("This is a test. xy ABC xy"),
+		[]interface{}{hipathsys.NewString("xyz")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.NewInteger(-1), res)
+}
+
 func TestSubstringFuncNil(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
@@ -150,7 +247,7 @@ func TestSubstringFuncColValidStart(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("abcdefg"))
+	col.Add(hipathsys.NewString("abcdefg"))
 
 	f := newSubstringFunction()
 	res, err := f.Execute(ctx, col, []interface{}{hipathsys.NewInteger(3)}, nil)
@@ -164,7 +261,7 @@ func TestSubstringFuncValidStartCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewInteger(3))
+	col.Add(hipathsys.NewInteger(3))
 
 	f := newSubstringFunction()
 	res, err := f.Execute(ctx, hipathsys.NewString("abcdefg"), []interface{}{col}, nil)
@@ -256,7 +353,7 @@ func TestSubstringFuncValidStartColLen(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewInteger(2))
+	col.Add(hipathsys.NewInteger(2))
 
 	f := newSubstringFunction()
 	res, err := f.Execute(ctx, hipathsys.NewString("abcdefg"), []interface{}{
@@ -338,8 +435,8 @@ func TestStartsWithFuncMultiCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test"))
-	col.MustAdd(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
 
 	f := newStartsWithFunction()
 	res, err := f.Execute(ctx, col, []interface{}{hipathsys.NewString("test")}, nil)
@@ -351,8 +448,8 @@ func TestStartsWithFuncSubstringMultiCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test"))
-	col.MustAdd(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
 
 	f := newStartsWithFunction()
 	res, err := f.Execute(ctx, hipathsys.NewString("test"), []interface{}{col}, nil)
@@ -439,8 +536,8 @@ func TestEndsWithFuncMultiCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test"))
-	col.MustAdd(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
 
 	f := newEndsWithFunction()
 	res, err := f.Execute(ctx, col, []interface{}{hipathsys.NewString("test")}, nil)
@@ -452,8 +549,8 @@ func TestEndsWithFuncSubstringMultiCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test"))
-	col.MustAdd(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
 
 	f := newEndsWithFunction()
 	res, err := f.Execute(ctx, hipathsys.NewString("test"), []interface{}{col}, nil)
@@ -540,8 +637,8 @@ func TestContainsFuncMultiCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test"))
-	col.MustAdd(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
 
 	f := newContainsFunction()
 	res, err := f.Execute(ctx, col, []interface{}{hipathsys.NewString("test")}, nil)
@@ -553,8 +650,8 @@ func TestContainsFuncSubstringMultiCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test"))
-	col.MustAdd(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
 
 	f := newContainsFunction()
 	res, err := f.Execute(ctx, hipathsys.NewString("test"), []interface{}{col}, nil)
@@ -602,8 +699,8 @@ func TestUpperFuncMultiCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test"))
-	col.MustAdd(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
 
 	f := newUpperFunction()
 	res, err := f.Execute(ctx, col, []interface{}{}, nil)
@@ -642,8 +739,8 @@ func TestLowerFuncMultiCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test"))
-	col.MustAdd(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
 
 	f := newLowerFunction()
 	res, err := f.Execute(ctx, col, []interface{}{}, nil)
@@ -733,7 +830,7 @@ func TestReplaceFuncInputCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("xyz"))
+	col.Add(hipathsys.NewString("xyz"))
 
 	f := newReplaceFunction()
 	res, err := f.Execute(ctx, col,
@@ -756,7 +853,7 @@ func TestReplaceFuncPatternCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("x"))
+	col.Add(hipathsys.NewString("x"))
 
 	f := newReplaceFunction()
 	res, err := f.Execute(ctx, hipathsys.NewString("xyz"),
@@ -779,7 +876,7 @@ func TestReplaceFuncSubstitutionCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("y"))
+	col.Add(hipathsys.NewString("y"))
 
 	f := newReplaceFunction()
 	res, err := f.Execute(ctx, hipathsys.NewString("xyz"),
@@ -820,7 +917,7 @@ func TestMatchesFuncInputCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("Atest123abc"))
+	col.Add(hipathsys.NewString("Atest123abc"))
 
 	f := newMatchesFunction()
 	res, err := f.Execute(ctx, col, []interface{}{hipathsys.NewString("[a-z]{3,4}\\d+[a-z]+")}, nil)
@@ -841,7 +938,7 @@ func TestMatchesFuncRegexCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("[a-z]+\\d+[a-z]+"))
+	col.Add(hipathsys.NewString("[a-z]+\\d+[a-z]+"))
 
 	f := newMatchesFunction()
 	res, err := f.Execute(ctx, hipathsys.NewString("Atest123abcZ"), []interface{}{col}, nil)
@@ -881,6 +978,81 @@ func TestMatchesFuncInvalidRegexSyntax(t *testing.T) {
 	assert.Nil(t, res, "empty collection expected")
 }
 
+func TestMatchesFullFuncNil(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newMatchesFullFunction()
+	res, err := f.Execute(ctx, nil, []interface{}{hipathsys.NewString("test")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty collection expected")
+}
+
+func TestMatchesFullFuncRegexNil(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newMatchesFullFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("test"), []interface{}{nil}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty collection expected")
+}
+
+func TestMatchesFullFuncTrue(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newMatchesFullFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("abc123"),
+		[]interface{}{hipathsys.NewString("[a-z]+\\d+")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.True, res)
+}
+
+func TestMatchesFullFuncPartial(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newMatchesFullFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("xabc123"),
+		[]interface{}{hipathsys.NewString("[a-c]+\\d+")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.False, res)
+}
+
+func TestMatchesFullFuncAlternation(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newMatchesFullFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("ab"),
+		[]interface{}{hipathsys.NewString("a|b")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.False, res)
+}
+
+func TestMatchesFullFuncInputInvalid(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newMatchesFullFunction()
+	res, err := f.Execute(ctx, hipathsys.NewInteger(10), []interface{}{hipathsys.NewString("test")}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected expected")
+}
+
+func TestMatchesFullFuncRegexInvalid(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newMatchesFullFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("test"), []interface{}{hipathsys.NewInteger(10)}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected expected")
+}
+
+func TestMatchesFullFuncInvalidRegexSyntax(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newMatchesFullFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("test"), []interface{}{hipathsys.NewString("[a")}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected expected")
+}
+
 func TestReplaceMatchesFuncNil(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
@@ -945,7 +1117,7 @@ func TestReplaceMatchesFuncInputCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("xyz"))
+	col.Add(hipathsys.NewString("xyz"))
 
 	f := newReplaceMatchesFunction()
 	res, err := f.Execute(ctx, col,
@@ -968,7 +1140,7 @@ func TestReplaceMatchesFuncRegexCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("x"))
+	col.Add(hipathsys.NewString("x"))
 
 	f := newReplaceMatchesFunction()
 	res, err := f.Execute(ctx, hipathsys.NewString("xyz"),
@@ -991,7 +1163,7 @@ func TestReplaceMatchesFuncSubstitutionCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("y"))
+	col.Add(hipathsys.NewString("y"))
 
 	f := newReplaceMatchesFunction()
 	res, err := f.Execute(ctx, hipathsys.NewString("xyz"),
@@ -1034,8 +1206,8 @@ func TestLengthFuncMultiCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test"))
-	col.MustAdd(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
 
 	f := newLengthFunction()
 	res, err := f.Execute(ctx, col, []interface{}{}, nil)
@@ -1074,8 +1246,8 @@ func TestToCharsFuncMultiCol(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test"))
-	col.MustAdd(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
 
 	f := newToCharsFunction()
 	res, err := f.Execute(ctx, col, []interface{}{}, nil)
@@ -1102,6 +1274,15 @@ func TestToCharsFunc(t *testing.T) {
 	}
 }
 
+func TestToCharsFuncMaxCollectionSize(t *testing.T) {
+	ctx := test.NewTestContextWithLimits(t, hipathsys.Limits{MaxCollectionSize: 3})
+
+	f := newToCharsFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("abcdef"), []interface{}{}, nil)
+	assert.True(t, errors.Is(err, hipathsys.ErrLimitExceeded), "limit error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
 func TestToCharsFuncEmptyString(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
@@ -1113,3 +1294,187 @@ func TestToCharsFuncEmptyString(t *testing.T) {
 		assert.Equal(t, 0, col.Count())
 	}
 }
+
+func TestTrimFuncNil(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newTrimFunction()
+	res, err := f.Execute(ctx, nil, []interface{}{}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty collection expected")
+}
+
+func TestTrimFuncOther(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newTrimFunction()
+	res, err := f.Execute(ctx, hipathsys.NewInteger(10), []interface{}{}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected expected")
+}
+
+func TestTrimFunc(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newTrimFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString(" \t Ä b \n"), []interface{}{}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.NewString("Ä b"), res)
+}
+
+func TestSplitFuncNil(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newSplitFunction()
+	res, err := f.Execute(ctx, nil, []interface{}{hipathsys.NewString(",")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty collection expected")
+}
+
+func TestSplitFuncSeparatorNil(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newSplitFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("a,b"), []interface{}{nil}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty collection expected")
+}
+
+func TestSplitFuncOther(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newSplitFunction()
+	res, err := f.Execute(ctx, hipathsys.NewInteger(10), []interface{}{hipathsys.NewString(",")}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected expected")
+}
+
+func TestSplitFunc(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newSplitFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("Ä,,Ç"), []interface{}{hipathsys.NewString(",")}, nil)
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.CollectionAccessor)(nil), res) {
+		col := res.(hipathsys.CollectionAccessor)
+		if assert.Equal(t, 3, col.Count()) {
+			assert.Equal(t, hipathsys.NewString("Ä"), col.Get(0))
+			assert.Equal(t, hipathsys.NewString(""), col.Get(1))
+			assert.Equal(t, hipathsys.NewString("Ç"), col.Get(2))
+		}
+	}
+}
+
+func TestSplitFuncMaxCollectionSize(t *testing.T) {
+	ctx := test.NewTestContextWithLimits(t, hipathsys.Limits{MaxCollectionSize: 3})
+
+	f := newSplitFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("a,b,c,d,e"), []interface{}{hipathsys.NewString(",")}, nil)
+	assert.True(t, errors.Is(err, hipathsys.ErrLimitExceeded), "limit error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestSplitFuncEmptySeparator(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newSplitFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("ÄbÇ"), []interface{}{hipathsys.NewString("")}, nil)
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.CollectionAccessor)(nil), res) {
+		col := res.(hipathsys.CollectionAccessor)
+		if assert.Equal(t, 3, col.Count()) {
+			assert.Equal(t, hipathsys.NewString("Ä"), col.Get(0))
+			assert.Equal(t, hipathsys.NewString("b"), col.Get(1))
+			assert.Equal(t, hipathsys.NewString("Ç"), col.Get(2))
+		}
+	}
+}
+
+func TestSplitFuncEmptyString(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newSplitFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString(""), []interface{}{hipathsys.NewString(",")}, nil)
+	assert.NoError(t, err, "no error expected")
+	if assert.Implements(t, (*hipathsys.CollectionAccessor)(nil), res) {
+		col := res.(hipathsys.CollectionAccessor)
+		assert.Equal(t, 0, col.Count())
+	}
+}
+
+func TestJoinFuncNil(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newJoinFunction()
+	res, err := f.Execute(ctx, nil, []interface{}{hipathsys.NewString(",")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty collection expected")
+}
+
+func TestJoinFunc(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	col := ctx.NewCollection()
+	col.Add(hipathsys.NewString("Ä"))
+	col.Add(hipathsys.NewString(""))
+	col.Add(hipathsys.NewString("Ç"))
+
+	f := newJoinFunction()
+	res, err := f.Execute(ctx, col, []interface{}{hipathsys.NewString(", ")}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.NewString("Ä, , Ç"), res)
+}
+
+func TestJoinFuncNoSeparator(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	col := ctx.NewCollection()
+	col.Add(hipathsys.NewString("a"))
+	col.Add(hipathsys.NewString("b"))
+
+	f := newJoinFunction()
+	res, err := f.Execute(ctx, col, []interface{}{}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.NewString("ab"), res)
+}
+
+func TestJoinFuncSeparatorNil(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newJoinFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("a"), []interface{}{nil}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.NewString("a"), res)
+}
+
+func TestJoinFuncSeparatorOther(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newJoinFunction()
+	res, err := f.Execute(ctx, hipathsys.NewString("a"), []interface{}{hipathsys.NewInteger(1)}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected expected")
+}
+
+func TestJoinFuncOther(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	col := ctx.NewCollection()
+	col.Add(hipathsys.NewString("a"))
+	col.Add(hipathsys.NewInteger(1))
+
+	f := newJoinFunction()
+	res, err := f.Execute(ctx, col, []interface{}{}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Equal(t, hipathsys.TypeMismatchErrorCode, hipathsys.ErrorCodeOf(err))
+	assert.Nil(t, res, "no result expected expected")
+}
+
+func TestJoinFuncNodeError(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newJoinFunction()
+	res, err := f.Execute(ctx, test.NewTestModelErrorNode(), []interface{}{}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected expected")
+}
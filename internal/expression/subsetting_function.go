@@ -28,10 +28,7 @@
 
 package expression
 
-import (
-	"fmt"
-	"github.com/healthiop/hipath/hipathsys"
-)
+import "github.com/healthiop/hipath/hipathsys"
 
 type singleFunction struct {
 	hipathsys.BaseFunction
@@ -53,7 +50,7 @@ func (f *singleFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}
 		return nil, nil
 	}
 	if count > 1 {
-		return nil, fmt.Errorf("expected collection with one item: %d", count)
+		return nil, hipathsys.NewCodedError(hipathsys.SingletonErrorCode, "expected collection with one item: %d", count)
 	}
 	return col.Get(0), nil
 }
@@ -145,7 +142,7 @@ func newSkipFunction() *skipFunction {
 func (f *skipFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}, args []interface{}, _ hipathsys.Looper) (interface{}, error) {
 	var num int
 	if n, ok := unwrapCollection(args[0]).(hipathsys.NumberAccessor); !ok {
-		return nil, fmt.Errorf("argument must be an integer: %T", args[0])
+		return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "argument must be an integer: %T", args[0])
 	} else {
 		num = int(n.Int())
 	}
@@ -186,7 +183,7 @@ func newTakeFunction() *takeFunction {
 func (f *takeFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}, args []interface{}, _ hipathsys.Looper) (interface{}, error) {
 	var num int
 	if n, ok := unwrapCollection(args[0]).(hipathsys.NumberAccessor); !ok {
-		return nil, fmt.Errorf("argument must be an integer: %T", args[0])
+		return nil, hipathsys.NewCodedError(hipathsys.TypeMismatchErrorCode, "argument must be an integer: %T", args[0])
 	} else {
 		num = int(n.Int())
 	}
@@ -47,7 +47,7 @@ func TestSinglePathFunc(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	col := ctx.NewCollection()
 
-	col.MustAdd(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
 	f := newSingleFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
 	assert.NoError(t, err, "no error expected")
@@ -67,8 +67,8 @@ func TestSinglePathFuncMulti(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
-	col.MustAdd(hipathsys.NewString("test2"))
+	col.Add(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test2"))
 
 	f := newSingleFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
@@ -88,8 +88,8 @@ func TestFirstPathFunc(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
-	col.MustAdd(hipathsys.NewString("test2"))
+	col.Add(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test2"))
 
 	f := newFirstFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
@@ -118,8 +118,8 @@ func TestLastPathFunc(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
-	col.MustAdd(hipathsys.NewString("test2"))
+	col.Add(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test2"))
 
 	f := newLastFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
@@ -140,7 +140,7 @@ func TestTailPathFuncEmpty(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test1"))
 
 	f := newTailFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
@@ -152,9 +152,9 @@ func TestTailPathFunc(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
-	col.MustAdd(hipathsys.NewString("test2"))
-	col.MustAdd(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test2"))
+	col.Add(hipathsys.NewString("test3"))
 
 	f := newTailFunction()
 	res, err := f.Execute(ctx, col, nil, nil)
@@ -190,8 +190,8 @@ func TestSkipPathFuncEmpty(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
-	col.MustAdd(hipathsys.NewString("test2"))
+	col.Add(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test2"))
 
 	f := newSkipFunction()
 	res, err := f.Execute(ctx, col, []interface{}{hipathsys.NewInteger(2)}, nil)
@@ -203,7 +203,7 @@ func TestSkipPathFuncError(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test1"))
 
 	f := newSkipFunction()
 	res, err := f.Execute(ctx, col, []interface{}{hipathsys.NewString("")}, nil)
@@ -233,11 +233,11 @@ func TestSkipPathFunc(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
-	col.MustAdd(hipathsys.NewString("test2"))
-	col.MustAdd(hipathsys.NewString("test3"))
-	col.MustAdd(hipathsys.NewString("test4"))
-	col.MustAdd(hipathsys.NewString("test5"))
+	col.Add(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test2"))
+	col.Add(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test4"))
+	col.Add(hipathsys.NewString("test5"))
 
 	f := newSkipFunction()
 	res, err := f.Execute(ctx, col, []interface{}{hipathsys.NewInteger(2)}, nil)
@@ -256,9 +256,9 @@ func TestSkipPathFuncNeg(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
-	col.MustAdd(hipathsys.NewString("test2"))
-	col.MustAdd(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test2"))
+	col.Add(hipathsys.NewString("test3"))
 
 	f := newSkipFunction()
 	res, err := f.Execute(ctx, col, []interface{}{hipathsys.NewInteger(-10)}, nil)
@@ -285,8 +285,8 @@ func TestTakePathFuncZero(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
-	col.MustAdd(hipathsys.NewString("test2"))
+	col.Add(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test2"))
 
 	f := newTakeFunction()
 	res, err := f.Execute(ctx, col, []interface{}{hipathsys.NewInteger(0)}, nil)
@@ -298,7 +298,7 @@ func TestTakePathFuncError(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test1"))
 
 	f := newTakeFunction()
 	res, err := f.Execute(ctx, col, []interface{}{hipathsys.NewString("")}, nil)
@@ -328,11 +328,11 @@ func TestTakePathFunc(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
-	col.MustAdd(hipathsys.NewString("test2"))
-	col.MustAdd(hipathsys.NewString("test3"))
-	col.MustAdd(hipathsys.NewString("test4"))
-	col.MustAdd(hipathsys.NewString("test5"))
+	col.Add(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test2"))
+	col.Add(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test4"))
+	col.Add(hipathsys.NewString("test5"))
 
 	f := newTakeFunction()
 	res, err := f.Execute(ctx, col, []interface{}{hipathsys.NewInteger(3)}, nil)
@@ -351,9 +351,9 @@ func TestTakePathFuncMore(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
-	col.MustAdd(hipathsys.NewString("test2"))
-	col.MustAdd(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test2"))
+	col.Add(hipathsys.NewString("test3"))
 
 	f := newTakeFunction()
 	res, err := f.Execute(ctx, col, []interface{}{hipathsys.NewInteger(5)}, nil)
@@ -372,9 +372,9 @@ func TestTakePathFuncNeg(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
-	col.MustAdd(hipathsys.NewString("test2"))
-	col.MustAdd(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test2"))
+	col.Add(hipathsys.NewString("test3"))
 
 	f := newTakeFunction()
 	res, err := f.Execute(ctx, col, []interface{}{hipathsys.NewInteger(-10)}, nil)
@@ -387,7 +387,7 @@ func TestIntersectPathFuncLeftError(t *testing.T) {
 
 	col := test.NewTestModelErrorNode()
 	other := ctx.NewCollection()
-	other.MustAdd(hipathsys.NewString("test1"))
+	other.Add(hipathsys.NewString("test1"))
 
 	f := newIntersectFunction()
 	res, err := f.Execute(ctx, col, []interface{}{other}, nil)
@@ -400,7 +400,7 @@ func TestIntersectPathFuncLeftEmpty(t *testing.T) {
 
 	col := ctx.NewCollection()
 	other := ctx.NewCollection()
-	other.MustAdd(hipathsys.NewString("test1"))
+	other.Add(hipathsys.NewString("test1"))
 
 	f := newIntersectFunction()
 	res, err := f.Execute(ctx, col, []interface{}{other}, nil)
@@ -412,7 +412,7 @@ func TestIntersectPathFuncRightError(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test1"))
 	other := test.NewTestModelErrorNode()
 
 	f := newIntersectFunction()
@@ -425,7 +425,7 @@ func TestIntersectPathFuncRightEmpty(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test1"))
 	other := ctx.NewCollection()
 
 	f := newIntersectFunction()
@@ -449,8 +449,8 @@ func TestIntersectPathFuncLeftBigger(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test2"))
-	col.MustAdd(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test2"))
+	col.Add(hipathsys.NewString("test3"))
 
 	f := newIntersectFunction()
 	res, err := f.Execute(ctx, col, []interface{}{hipathsys.NewString("test3")}, nil)
@@ -467,8 +467,8 @@ func TestIntersectPathFuncRightBigger(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test2"))
-	col.MustAdd(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test2"))
+	col.Add(hipathsys.NewString("test3"))
 
 	f := newIntersectFunction()
 	res, err := f.Execute(ctx, hipathsys.NewString("test3"), []interface{}{col}, nil)
@@ -485,17 +485,17 @@ func TestIntersectPathFuncUnique(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test2"))
-	col.MustAdd(hipathsys.NewString("test3"))
-	col.MustAdd(hipathsys.NewString("test5"))
-	col.MustAdd(hipathsys.NewString("test2"))
-	col.MustAdd(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test2"))
+	col.Add(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test5"))
+	col.Add(hipathsys.NewString("test2"))
+	col.Add(hipathsys.NewString("test1"))
 
 	other := ctx.NewCollection()
-	other.MustAdd(hipathsys.NewString("test3"))
-	other.MustAdd(hipathsys.NewString("test7"))
-	other.MustAdd(hipathsys.NewString("test2"))
-	other.MustAdd(hipathsys.NewString("test1"))
+	other.Add(hipathsys.NewString("test3"))
+	other.Add(hipathsys.NewString("test7"))
+	other.Add(hipathsys.NewString("test2"))
+	other.Add(hipathsys.NewString("test1"))
 
 	f := newIntersectFunction()
 	res, err := f.Execute(ctx, col, []interface{}{other}, nil)
@@ -514,7 +514,7 @@ func TestExcludePathFuncColError(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
 
 	f := newExcludeFunction()
 	res, err := f.Execute(ctx, test.NewErrorCollection(), []interface{}{col}, nil)
@@ -527,7 +527,7 @@ func TestExcludePathFuncLeftError(t *testing.T) {
 
 	col := test.NewTestModelErrorNode()
 	other := ctx.NewCollection()
-	other.MustAdd(hipathsys.NewString("test1"))
+	other.Add(hipathsys.NewString("test1"))
 
 	f := newExcludeFunction()
 	res, err := f.Execute(ctx, col, []interface{}{other}, nil)
@@ -539,7 +539,7 @@ func TestExcludePathFuncRightError(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test1"))
 	other := test.NewTestModelErrorNode()
 
 	f := newExcludeFunction()
@@ -553,7 +553,7 @@ func TestExcludePathFuncLeftEmpty(t *testing.T) {
 
 	col := ctx.NewCollection()
 	other := ctx.NewCollection()
-	other.MustAdd(hipathsys.NewString("test1"))
+	other.Add(hipathsys.NewString("test1"))
 
 	f := newExcludeFunction()
 	res, err := f.Execute(ctx, col, []interface{}{other}, nil)
@@ -565,8 +565,8 @@ func TestExcludePathFuncRightEmpty(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
-	col.MustAdd(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test3"))
 	other := ctx.NewCollection()
 
 	f := newExcludeFunction()
@@ -585,15 +585,15 @@ func TestExcludePathFunc(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test1"))
-	col.MustAdd(hipathsys.NewString("test3"))
-	col.MustAdd(hipathsys.NewString("test7"))
-	col.MustAdd(hipathsys.NewString("test3"))
-	col.MustAdd(hipathsys.NewString("test8"))
-	col.MustAdd(hipathsys.NewString("test9"))
+	col.Add(hipathsys.NewString("test1"))
+	col.Add(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test7"))
+	col.Add(hipathsys.NewString("test3"))
+	col.Add(hipathsys.NewString("test8"))
+	col.Add(hipathsys.NewString("test9"))
 	other := ctx.NewCollection()
-	other.MustAdd(hipathsys.NewString("test7"))
-	other.MustAdd(hipathsys.NewString("test9"))
+	other.Add(hipathsys.NewString("test7"))
+	other.Add(hipathsys.NewString("test9"))
 
 	f := newExcludeFunction()
 	res, err := f.Execute(ctx, col, []interface{}{other}, nil)
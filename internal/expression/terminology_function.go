@@ -0,0 +1,170 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package expression
+
+import "github.com/healthiop/hipath/hipathsys"
+
+// terminologiesMethods are the methods of the %terminologies object. They
+// cannot be invoked on other nodes, since only invocations on %terminologies
+// are dispatched to them when the path is parsed.
+var terminologiesMethods = []hipathsys.FunctionExecutor{
+	newExpandFunction(),
+	newLookupFunction(),
+	newValidateVSFunction(),
+	newValidateCSFunction(),
+	newTerminologiesSubsumesFunction(),
+	newTranslateFunction(),
+}
+
+var TerminologiesMethods = newBuiltInFunctionRegistry(terminologiesMethods)
+
+type expandFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newExpandFunction() *expandFunction {
+	return &expandFunction{
+		BaseFunction: hipathsys.NewBaseFunction("expand", -1, 1, 2),
+	}
+}
+
+func (f *expandFunction) Execute(ctx hipathsys.ContextAccessor, _ interface{}, args []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	provider, args, err := terminologiesInvocation(ctx, args, 1)
+	if provider == nil || err != nil {
+		return nil, err
+	}
+	return provider.Expand(ctx, args[0], args[1])
+}
+
+type lookupFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newLookupFunction() *lookupFunction {
+	return &lookupFunction{
+		BaseFunction: hipathsys.NewBaseFunction("lookup", -1, 1, 2),
+	}
+}
+
+func (f *lookupFunction) Execute(ctx hipathsys.ContextAccessor, _ interface{}, args []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	provider, args, err := terminologiesInvocation(ctx, args, 1)
+	if provider == nil || err != nil {
+		return nil, err
+	}
+	return provider.Lookup(ctx, args[0], args[1])
+}
+
+type validateVSFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newValidateVSFunction() *validateVSFunction {
+	return &validateVSFunction{
+		BaseFunction: hipathsys.NewBaseFunction("validateVS", -1, 2, 3),
+	}
+}
+
+func (f *validateVSFunction) Execute(ctx hipathsys.ContextAccessor, _ interface{}, args []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	provider, args, err := terminologiesInvocation(ctx, args, 2)
+	if provider == nil || err != nil {
+		return nil, err
+	}
+	return provider.ValidateVS(ctx, args[0], args[1], args[2])
+}
+
+type validateCSFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newValidateCSFunction() *validateCSFunction {
+	return &validateCSFunction{
+		BaseFunction: hipathsys.NewBaseFunction("validateCS", -1, 2, 3),
+	}
+}
+
+func (f *validateCSFunction) Execute(ctx hipathsys.ContextAccessor, _ interface{}, args []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	provider, args, err := terminologiesInvocation(ctx, args, 2)
+	if provider == nil || err != nil {
+		return nil, err
+	}
+	return provider.ValidateCS(ctx, args[0], args[1], args[2])
+}
+
+type terminologiesSubsumesFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newTerminologiesSubsumesFunction() *terminologiesSubsumesFunction {
+	return &terminologiesSubsumesFunction{
+		BaseFunction: hipathsys.NewBaseFunction("subsumes", -1, 3, 4),
+	}
+}
+
+func (f *terminologiesSubsumesFunction) Execute(ctx hipathsys.ContextAccessor, _ interface{}, args []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	provider, args, err := terminologiesInvocation(ctx, args, 3)
+	if provider == nil || err != nil {
+		return nil, err
+	}
+	return provider.SubsumesOutcome(ctx, args[0], args[1], args[2], args[3])
+}
+
+type translateFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newTranslateFunction() *translateFunction {
+	return &translateFunction{
+		BaseFunction: hipathsys.NewBaseFunction("translate", -1, 2, 3),
+	}
+}
+
+func (f *translateFunction) Execute(ctx hipathsys.ContextAccessor, _ interface{}, args []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	provider, args, err := terminologiesInvocation(ctx, args, 2)
+	if provider == nil || err != nil {
+		return nil, err
+	}
+	return provider.Translate(ctx, args[0], args[1], args[2])
+}
+
+func terminologiesInvocation(ctx hipathsys.ContextAccessor, args []interface{}, required int) (hipathsys.TerminologyProvider, []interface{}, error) {
+	provider, err := terminologyProvider(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// optional parameters are passed as nil
+	values := make([]interface{}, required+1)
+	for pos, arg := range args {
+		values[pos] = unwrapCollection(arg)
+		if pos < required && values[pos] == nil {
+			return nil, nil, nil
+		}
+	}
+	return provider, values, nil
+}
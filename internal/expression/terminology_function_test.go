@@ -0,0 +1,122 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package expression
+
+import (
+	"fmt"
+	"github.com/healthiop/hipath/hipathsys"
+	"github.com/healthiop/hipath/internal/test"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var testVS = hipathsys.NewString("http://test/vs")
+var testCode = hipathsys.NewString("a")
+var testParams = hipathsys.NewString("x=y")
+
+func TestTerminologyFunctions(t *testing.T) {
+	tests := []struct {
+		executor hipathsys.FunctionExecutor
+		args     []interface{}
+		expected []interface{}
+	}{
+		{newExpandFunction(), []interface{}{testVS}, []interface{}{testVS, nil}},
+		{newExpandFunction(), []interface{}{testVS, testParams}, []interface{}{testVS, testParams}},
+		{newLookupFunction(), []interface{}{testCode}, []interface{}{testCode, nil}},
+		{newValidateVSFunction(), []interface{}{testVS, testCode}, []interface{}{testVS, testCode, nil}},
+		{newValidateCSFunction(), []interface{}{testVS, testCode, testParams}, []interface{}{testVS, testCode, testParams}},
+		{newTerminologiesSubsumesFunction(), []interface{}{testVS, testCode, testCode}, []interface{}{testVS, testCode, testCode, nil}},
+		{newTranslateFunction(), []interface{}{testVS, testCode}, []interface{}{testVS, testCode, nil}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.executor.Name(), func(t *testing.T) {
+			provider := &testTerminologyProvider{}
+			ctx := test.NewTestContextWithTerminologyProvider(t, provider)
+			res, err := tt.executor.Execute(ctx, nil, tt.args, nil)
+			assert.NoError(t, err, "no error expected")
+			assert.Equal(t, hipathsys.NewString(tt.executor.Name()), res)
+			assert.Equal(t, tt.executor.Name(), provider.name)
+			assert.Equal(t, tt.expected, provider.args)
+		})
+	}
+}
+
+func TestTerminologyFunctionCollectionArgs(t *testing.T) {
+	provider := &testTerminologyProvider{}
+	ctx := test.NewTestContextWithTerminologyProvider(t, provider)
+	c := ctx.NewCollection()
+	c.Add(testVS)
+
+	f := newExpandFunction()
+	res, err := f.Execute(ctx, nil, []interface{}{c}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, hipathsys.NewString("expand"), res)
+	assert.Equal(t, []interface{}{testVS, nil}, provider.args)
+}
+
+func TestTerminologyFunctionEmptyArg(t *testing.T) {
+	provider := &testTerminologyProvider{}
+	ctx := test.NewTestContextWithTerminologyProvider(t, provider)
+
+	f := newValidateVSFunction()
+	res, err := f.Execute(ctx, nil, []interface{}{testVS, nil}, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Nil(t, res, "empty result expected")
+	assert.Empty(t, provider.name)
+}
+
+func TestTerminologyFunctionNoProvider(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newExpandFunction()
+	res, err := f.Execute(ctx, nil, []interface{}{testVS}, nil)
+	if assert.Error(t, err, "error expected") {
+		assert.Equal(t, hipathsys.NotSupportedErrorCode, hipathsys.ErrorCodeOf(err))
+	}
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestTerminologyFunctionError(t *testing.T) {
+	ctx := test.NewTestContextWithTerminologyProvider(t, &testTerminologyProvider{err: fmt.Errorf("failed")})
+
+	f := newLookupFunction()
+	res, err := f.Execute(ctx, nil, []interface{}{testCode}, nil)
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestTerminologiesMethods(t *testing.T) {
+	for _, name := range []string{"expand", "lookup", "validateVS", "validateCS", "subsumes", "translate"} {
+		assert.NotNil(t, TerminologiesMethods.Lookup(name), "method expected: %s", name)
+		if name != "subsumes" {
+			assert.Nil(t, BuiltInFunctions.Lookup(name), "no function expected: %s", name)
+		}
+	}
+}
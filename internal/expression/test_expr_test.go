@@ -63,6 +63,20 @@ func (e *testErrorExpression) Evaluate(hipathsys.ContextAccessor, interface{}, h
 	return nil, fmt.Errorf("an error occurred")
 }
 
+type testCancelExpression struct {
+	res    interface{}
+	cancel func()
+}
+
+func newTestCancelExpression(res interface{}, cancel func()) *testCancelExpression {
+	return &testCancelExpression{res, cancel}
+}
+
+func (e *testCancelExpression) Evaluate(hipathsys.ContextAccessor, interface{}, hipathsys.Looper) (interface{}, error) {
+	e.cancel()
+	return e.res, nil
+}
+
 type testingAccessor interface {
 	hipathsys.AnyAccessor
 	testing() *testing.T
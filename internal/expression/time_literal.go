@@ -28,10 +28,7 @@
 
 package expression
 
-import (
-	"fmt"
-	"github.com/healthiop/hipath/hipathsys"
-)
+import "github.com/healthiop/hipath/hipathsys"
 
 type TimeLiteral struct {
 	node hipathsys.TimeAccessor
@@ -39,7 +36,7 @@ type TimeLiteral struct {
 
 func ParseTimeLiteral(value string) (hipathsys.Evaluator, error) {
 	if len(value) < 3 || value[0] != '@' || value[1] != 'T' {
-		return nil, fmt.Errorf("invalid time literal: %s", value)
+		return nil, hipathsys.NewCodedError(hipathsys.SyntaxErrorCode, "invalid time literal: %s", value)
 	}
 	if node, err := hipathsys.ParseTime(value[2:]); err != nil {
 		return nil, err
@@ -57,11 +57,11 @@ func (f *childrenFunction) Execute(ctx hipathsys.ContextAccessor, node interface
 			if c != nil {
 				ccol, err := adapter.Children(c)
 				if err != nil {
-					return nil, err
+					return nil, hipathsys.NewAdapterError(err)
 				}
 				if ccol != nil && !ccol.Empty() {
 					if children == nil {
-						children = hipathsys.NewCollection(adapter)
+						children = ctx.NewCollection()
 					}
 					_, err := children.AddAll(ccol)
 					if err != nil {
@@ -74,7 +74,11 @@ func (f *childrenFunction) Execute(ctx hipathsys.ContextAccessor, node interface
 		return children, nil
 	}
 
-	return ctx.ModelAdapter().Children(node)
+	res, err := ctx.ModelAdapter().Children(node)
+	if err != nil {
+		return nil, hipathsys.NewAdapterError(err)
+	}
+	return res, nil
 }
 
 type descendantsFunction struct {
@@ -29,6 +29,7 @@
 package expression
 
 import (
+	"errors"
 	"github.com/healthiop/hipath/hipathsys"
 	"github.com/healthiop/hipath/internal/test"
 	"github.com/stretchr/testify/assert"
@@ -61,8 +62,8 @@ func TestChildrenFuncColNoChildren(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	n1 := ctx.NewCollection()
-	n1.MustAdd(hipathsys.NewString("test1"))
-	n1.MustAdd(hipathsys.NewString("test2"))
+	n1.Add(hipathsys.NewString("test1"))
+	n1.Add(hipathsys.NewString("test2"))
 
 	f := childrenFunc
 	res, err := f.Execute(ctx, n1, []interface{}{}, nil)
@@ -122,8 +123,8 @@ func TestChildrenFuncColChildren(t *testing.T) {
 	l2["a"] = n4
 
 	n := ctx.NewCollection()
-	n.MustAdd(l1)
-	n.MustAdd(l2)
+	n.Add(l1)
+	n.Add(l2)
 
 	f := childrenFunc
 	res, err := f.Execute(ctx, n, []interface{}{}, nil)
@@ -140,11 +141,31 @@ func TestChildrenFuncColChildren(t *testing.T) {
 	}
 }
 
+func TestChildrenFuncColChildrenMaxSize(t *testing.T) {
+	ctx := test.NewTestContextWithLimits(t, hipathsys.Limits{MaxCollectionSize: 2})
+
+	l1 := map[string]interface{}{}
+	l1["a"] = hipathsys.NewString("test1")
+	l1["b"] = hipathsys.NewString("test2")
+
+	l2 := map[string]interface{}{}
+	l2["a"] = hipathsys.NewString("test3")
+
+	n := ctx.NewCollection()
+	n.Add(l1)
+	n.Add(l2)
+
+	f := childrenFunc
+	res, err := f.Execute(ctx, n, []interface{}{}, nil)
+	assert.True(t, errors.Is(err, hipathsys.ErrLimitExceeded), "limit error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
 func TestChildrenFuncErrorItem(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	n := ctx.NewCollection()
-	n.MustAdd(test.NewTestModelNode(10, false))
+	n.Add(test.NewTestModelNode(10, false))
 
 	f := childrenFunc
 	res, err := f.Execute(ctx, n, []interface{}{}, nil)
@@ -167,7 +188,7 @@ func TestChildrenFuncColChildrenError(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	n := ctx.NewCollection()
-	n.MustAdd(map[string]interface{}{"errorCollection": nil})
+	n.Add(map[string]interface{}{"errorCollection": nil})
 
 	f := childrenFunc
 	res, err := f.Execute(ctx, n, []interface{}{}, nil)
@@ -202,8 +223,8 @@ func TestDescendantsFuncColNoDescendants(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	n1 := ctx.NewCollection()
-	n1.MustAdd(hipathsys.NewString("test1"))
-	n1.MustAdd(hipathsys.NewString("test2"))
+	n1.Add(hipathsys.NewString("test1"))
+	n1.Add(hipathsys.NewString("test2"))
 
 	f := newDescendantsFunction()
 	res, err := f.Execute(ctx, n1, []interface{}{}, nil)
@@ -271,8 +292,8 @@ func TestDescendantsFuncColDescendants(t *testing.T) {
 	l2["a"] = n4
 
 	n := ctx.NewCollection()
-	n.MustAdd(l1)
-	n.MustAdd(l2)
+	n.Add(l1)
+	n.Add(l2)
 
 	f := newDescendantsFunction()
 	res, err := f.Execute(ctx, n, []interface{}{}, nil)
@@ -304,8 +325,8 @@ func TestDescendantsFuncColDescendantsError(t *testing.T) {
 	ctx := test.NewTestContext(t)
 
 	n := ctx.NewCollection()
-	n.MustAdd(hipathsys.NewString("test1"))
-	n.MustAdd(test.NewTestModelNode(0, false))
+	n.Add(hipathsys.NewString("test1"))
+	n.Add(test.NewTestModelNode(0, false))
 
 	f := newDescendantsFunction()
 	res, err := f.Execute(ctx, n, []interface{}{}, nil)
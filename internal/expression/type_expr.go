@@ -28,14 +28,12 @@
 
 package expression
 
-import (
-	"fmt"
-	"github.com/healthiop/hipath/hipathsys"
-)
+import "github.com/healthiop/hipath/hipathsys"
 
 type AsTypeExpression struct {
 	exprEvaluator hipathsys.Evaluator
 	fqName        hipathsys.FQTypeNameAccessor
+	sourceNode
 }
 
 func NewAsTypeExpression(exprEvaluator hipathsys.Evaluator, name string) (*AsTypeExpression, error) {
@@ -44,10 +42,14 @@ func NewAsTypeExpression(exprEvaluator hipathsys.Evaluator, name string) (*AsTyp
 		return nil, err
 	}
 
-	return &AsTypeExpression{exprEvaluator, fqName}, nil
+	return &AsTypeExpression{exprEvaluator, fqName, sourceNode{}}, nil
 }
 
 func (e *AsTypeExpression) Evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
+	return e.sourceResult(e.evaluate(ctx, node, loop))
+}
+
+func (e *AsTypeExpression) evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
 	value, err := e.exprEvaluator.Evaluate(ctx, node, loop)
 	if err != nil {
 		return nil, err
@@ -59,7 +61,7 @@ func (e *AsTypeExpression) Evaluate(ctx hipathsys.ContextAccessor, node interfac
 
 	item := unwrapCollection(value)
 	if _, ok := item.(hipathsys.CollectionAccessor); ok {
-		return nil, fmt.Errorf("as operator cannot be applied on a collection")
+		return nil, hipathsys.NewCodedError(hipathsys.SingletonErrorCode, "as operator cannot be applied on a collection")
 	}
 
 	return hipathsys.CastModelType(ctx.ModelAdapter(), item, e.fqName)
@@ -68,6 +70,7 @@ func (e *AsTypeExpression) Evaluate(ctx hipathsys.ContextAccessor, node interfac
 type IsTypeExpression struct {
 	exprEvaluator hipathsys.Evaluator
 	fqName        hipathsys.FQTypeNameAccessor
+	sourceNode
 }
 
 func NewIsTypeExpression(exprEvaluator hipathsys.Evaluator, name string) (*IsTypeExpression, error) {
@@ -76,10 +79,14 @@ func NewIsTypeExpression(exprEvaluator hipathsys.Evaluator, name string) (*IsTyp
 		return nil, err
 	}
 
-	return &IsTypeExpression{exprEvaluator, fqName}, nil
+	return &IsTypeExpression{exprEvaluator, fqName, sourceNode{}}, nil
 }
 
 func (e *IsTypeExpression) Evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
+	return e.sourceResult(e.evaluate(ctx, node, loop))
+}
+
+func (e *IsTypeExpression) evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
 	value, err := e.exprEvaluator.Evaluate(ctx, node, loop)
 	if err != nil {
 		return nil, err
@@ -91,7 +98,7 @@ func (e *IsTypeExpression) Evaluate(ctx hipathsys.ContextAccessor, node interfac
 
 	item := unwrapCollection(value)
 	if _, ok := item.(hipathsys.CollectionAccessor); ok {
-		return nil, fmt.Errorf("is operator cannot be applied on a collection")
+		return nil, hipathsys.NewCodedError(hipathsys.SingletonErrorCode, "is operator cannot be applied on a collection")
 	}
 
 	return hipathsys.BooleanOf(hipathsys.HasModelType(ctx.ModelAdapter(), item, e.fqName)), nil
@@ -28,10 +28,7 @@
 
 package expression
 
-import (
-	"fmt"
-	"github.com/healthiop/hipath/hipathsys"
-)
+import "github.com/healthiop/hipath/hipathsys"
 
 type asFunction struct {
 	hipathsys.BaseFunction
@@ -56,7 +53,7 @@ func (f *asFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}, ar
 
 	item := unwrapCollection(node)
 	if _, ok := item.(hipathsys.CollectionAccessor); ok {
-		return nil, fmt.Errorf("as function cannot be applied on a collection")
+		return nil, hipathsys.NewCodedError(hipathsys.SingletonErrorCode, "as function cannot be applied on a collection")
 	}
 
 	return hipathsys.CastModelType(ctx.ModelAdapter(), item, fqName)
@@ -85,7 +82,7 @@ func (f *isFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}, ar
 
 	item := unwrapCollection(node)
 	if _, ok := item.(hipathsys.CollectionAccessor); ok {
-		return nil, fmt.Errorf("is function cannot be applied on a collection")
+		return nil, hipathsys.NewCodedError(hipathsys.SingletonErrorCode, "is function cannot be applied on a collection")
 	}
 
 	return hipathsys.BooleanOf(hipathsys.HasModelType(ctx.ModelAdapter(), item, fqName)), nil
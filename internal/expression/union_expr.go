@@ -33,13 +33,18 @@ import "github.com/healthiop/hipath/hipathsys"
 type UnionExpression struct {
 	evalLeft  hipathsys.Evaluator
 	evalRight hipathsys.Evaluator
+	sourceNode
 }
 
 func NewUnionExpression(evalLeft hipathsys.Evaluator, evalRight hipathsys.Evaluator) *UnionExpression {
-	return &UnionExpression{evalLeft, evalRight}
+	return &UnionExpression{evalLeft, evalRight, sourceNode{}}
 }
 
 func (e *UnionExpression) Evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
+	return e.sourceResult(e.evaluate(ctx, node, loop))
+}
+
+func (e *UnionExpression) evaluate(ctx hipathsys.ContextAccessor, node interface{}, loop hipathsys.Looper) (interface{}, error) {
 	left, err := e.evalLeft.Evaluate(ctx, node, loop)
 	if err != nil {
 		return nil, err
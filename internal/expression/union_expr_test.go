@@ -52,13 +52,13 @@ func TestUnionExpressionLiteral(t *testing.T) {
 func TestUnionExpressionCollection(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	c1 := ctx.NewCollection()
-	c1.MustAdd(hipathsys.NewInteger(10))
-	c1.MustAdd(hipathsys.NewInteger(11))
-	c1.MustAdd(hipathsys.NewInteger(14))
+	c1.Add(hipathsys.NewInteger(10))
+	c1.Add(hipathsys.NewInteger(11))
+	c1.Add(hipathsys.NewInteger(14))
 
 	c2 := ctx.NewCollection()
-	c2.MustAdd(hipathsys.NewDecimalInt(11))
-	c2.MustAdd(hipathsys.NewDecimalInt(12))
+	c2.Add(hipathsys.NewDecimalInt(11))
+	c2.Add(hipathsys.NewDecimalInt(12))
 
 	e := NewUnionExpression(newTestExpression(c1), newTestExpression(c2))
 	res, err := e.Evaluate(ctx, nil, nil)
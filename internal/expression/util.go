@@ -118,6 +118,13 @@ func addCollectionItems(collection hipathsys.CollectionModifier, node interface{
 	return nil
 }
 
+func checkEvaluationStep(ctx hipathsys.ContextAccessor) error {
+	if err := hipathsys.CheckCanceled(ctx); err != nil {
+		return err
+	}
+	return hipathsys.ContextLimiter(ctx).Step()
+}
+
 func unwrapCollection(node interface{}) interface{} {
 	if node == nil {
 		return nil
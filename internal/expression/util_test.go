@@ -56,7 +56,7 @@ func TestUnwrapCollectionOne(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	i := hipathsys.NewString("test")
 	c := ctx.NewCollection()
-	c.MustAdd(i)
+	c.Add(i)
 
 	assert.Same(t, i, unwrapCollection(c))
 }
@@ -64,8 +64,8 @@ func TestUnwrapCollectionOne(t *testing.T) {
 func TestUnwrapCollectionMore(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	c := ctx.NewCollection()
-	c.MustAdd(hipathsys.NewString("test1"))
-	c.MustAdd(hipathsys.NewString("test2"))
+	c.Add(hipathsys.NewString("test1"))
+	c.Add(hipathsys.NewString("test2"))
 
 	assert.Same(t, c, unwrapCollection(c))
 }
@@ -113,7 +113,7 @@ func TestEmptyCollectionEmpty(t *testing.T) {
 func TestEmptyCollectionNotEmpty(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	col := ctx.NewCollection()
-	col.MustAdd(hipathsys.NewString("test"))
+	col.Add(hipathsys.NewString("test"))
 	assert.False(t, emptyCollection(col))
 }
 
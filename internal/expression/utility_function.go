@@ -33,6 +33,38 @@ import (
 	"time"
 )
 
+type defineVariableFunction struct {
+	hipathsys.BaseFunction
+}
+
+func newDefineVariableFunction() *defineVariableFunction {
+	return &defineVariableFunction{
+		BaseFunction: hipathsys.NewBaseFunction("defineVariable", -1, 1, 2),
+	}
+}
+
+func (f *defineVariableFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}, args []interface{}, loop hipathsys.Looper) (interface{}, error) {
+	name, err := stringNode(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if name == nil || name.String() == "" {
+		return nil, hipathsys.NewCodedError(hipathsys.InvalidArgumentsErrorCode, "variable name must not be empty")
+	}
+	if _, found := ctx.EnvVar(name.String()); found {
+		return nil, hipathsys.NewCodedError(hipathsys.InvalidArgumentsErrorCode, "environment variable cannot be redefined: %s", name)
+	}
+
+	value := node
+	if len(args) > 1 {
+		value = args[1]
+	}
+	if !hipathsys.DefineLoopVariable(loop, name.String(), value) {
+		return nil, hipathsys.NewCodedError(hipathsys.UndefinedVariableErrorCode, "no scope for variable has been defined: %s", name)
+	}
+	return node, nil
+}
+
 type traceFunction struct {
 	hipathsys.BaseFunction
 }
@@ -70,6 +102,9 @@ func (f *traceFunction) Execute(ctx hipathsys.ContextAccessor, node interface{},
 	} else if loopEvaluator := loop.Evaluator(); loopEvaluator != nil {
 		projected := ctx.NewCollection()
 		for i := 0; i < count; i++ {
+			if err := checkEvaluationStep(ctx); err != nil {
+				return nil, err
+			}
 			this := col.Get(i)
 			loop.IncIndex(this)
 
@@ -36,6 +36,86 @@ import (
 	"time"
 )
 
+func TestDefineVariableFunc(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	node := hipathsys.NewString("node")
+	value := hipathsys.NewInteger(10)
+	loop := hipathsys.NewVariableLoop(nil)
+
+	f := newDefineVariableFunction()
+	res, err := f.Execute(ctx, node, []interface{}{hipathsys.NewString("test"), value}, loop)
+	assert.NoError(t, err, "no error expected")
+	assert.Same(t, node, res)
+
+	v, found := hipathsys.LoopVariable(loop, "test")
+	assert.True(t, found)
+	assert.Same(t, value, v)
+}
+
+func TestDefineVariableFuncInput(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	node := hipathsys.NewString("node")
+	loop := hipathsys.NewVariableLoop(nil)
+
+	f := newDefineVariableFunction()
+	res, err := f.Execute(ctx, node, []interface{}{hipathsys.NewString("test")}, loop)
+	assert.NoError(t, err, "no error expected")
+	assert.Same(t, node, res)
+
+	v, found := hipathsys.LoopVariable(loop, "test")
+	assert.True(t, found)
+	assert.Same(t, node, v)
+}
+
+func TestDefineVariableFuncEmptyValue(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	loop := hipathsys.NewVariableLoop(nil)
+
+	f := newDefineVariableFunction()
+	_, err := f.Execute(ctx, nil, []interface{}{hipathsys.NewString("test"), nil}, loop)
+	assert.NoError(t, err, "no error expected")
+
+	v, found := hipathsys.LoopVariable(loop, "test")
+	assert.True(t, found)
+	assert.Nil(t, v)
+}
+
+func TestDefineVariableFuncNameEmpty(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newDefineVariableFunction()
+	res, err := f.Execute(ctx, nil, []interface{}{hipathsys.NewString("")}, hipathsys.NewVariableLoop(nil))
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestDefineVariableFuncNameInvalid(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newDefineVariableFunction()
+	res, err := f.Execute(ctx, nil, []interface{}{hipathsys.NewInteger(1)}, hipathsys.NewVariableLoop(nil))
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestDefineVariableFuncEnvVar(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newDefineVariableFunction()
+	res, err := f.Execute(ctx, nil, []interface{}{hipathsys.NewString("ucum")}, hipathsys.NewVariableLoop(nil))
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestDefineVariableFuncNoScope(t *testing.T) {
+	ctx := test.NewTestContext(t)
+
+	f := newDefineVariableFunction()
+	res, err := f.Execute(ctx, nil, []interface{}{hipathsys.NewString("test")}, hipathsys.NewLoop(nil))
+	assert.Error(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
 func TestTraceFuncNoTracer(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	node := hipathsys.NewString("value")
@@ -84,8 +164,8 @@ func TestTraceFunc(t *testing.T) {
 	ctx := test.NewTestContextWithNodeAndTracer(t, hipathsys.NewString("test"), tracer)
 
 	node := ctx.NewCollection()
-	node.MustAdd(hipathsys.NewString("value1"))
-	node.MustAdd(hipathsys.NewString("value2"))
+	node.Add(hipathsys.NewString("value1"))
+	node.Add(hipathsys.NewString("value2"))
 
 	f := newTraceFunction()
 	res, err := f.Execute(ctx, node, []interface{}{hipathsys.NewString("test-tracer")},
@@ -115,8 +195,8 @@ func TestTraceFuncDisabled(t *testing.T) {
 	ctx := test.NewTestContextWithNodeAndTracer(t, hipathsys.NewString("test"), tracer)
 
 	node := ctx.NewCollection()
-	node.MustAdd(hipathsys.NewString("value1"))
-	node.MustAdd(hipathsys.NewString("value2"))
+	node.Add(hipathsys.NewString("value1"))
+	node.Add(hipathsys.NewString("value2"))
 
 	f := newTraceFunction()
 	res, err := f.Execute(ctx, node, []interface{}{hipathsys.NewString("other-tracer")},
@@ -136,7 +216,7 @@ func TestTraceFuncProjection(t *testing.T) {
 	nodeN["item"] = "testN"
 
 	id1 := ctx.NewCollection()
-	id1.MustAdd(hipathsys.NewString("1"))
+	id1.Add(hipathsys.NewString("1"))
 	node1 := make(map[string]interface{})
 	node1["id"] = id1
 	node1["item"] = "test1"
@@ -150,10 +230,10 @@ func TestTraceFuncProjection(t *testing.T) {
 	node9["item"] = "test9"
 
 	node := ctx.NewCollection()
-	node.MustAdd(node1)
-	node.MustAdd(node9)
-	node.MustAdd(nodeN)
-	node.MustAdd(node7)
+	node.Add(node1)
+	node.Add(node9)
+	node.Add(nodeN)
+	node.Add(node7)
 
 	f := newTraceFunction()
 	res, err := f.Execute(ctx, node, []interface{}{hipathsys.NewString("test-tracer")},
@@ -181,7 +261,7 @@ func TestTraceFuncProjectionValueError(t *testing.T) {
 	node1["id"] = test.NewTestModelErrorNode()
 
 	node := ctx.NewCollection()
-	node.MustAdd(node1)
+	node.Add(node1)
 
 	f := newTraceFunction()
 	res, err := f.Execute(ctx, node, []interface{}{hipathsys.NewString("test-tracer")},
@@ -196,8 +276,8 @@ func TestTraceFuncProjectionError(t *testing.T) {
 	ctx := test.NewTestContextWithNodeAndTracer(t, hipathsys.NewString("test"), tracer)
 
 	node := ctx.NewCollection()
-	node.MustAdd(hipathsys.NewString("value1"))
-	node.MustAdd(hipathsys.NewString("value2"))
+	node.Add(hipathsys.NewString("value1"))
+	node.Add(hipathsys.NewString("value2"))
 
 	f := newTraceFunction()
 	res, err := f.Execute(ctx, node, []interface{}{hipathsys.NewString("test-tracer")},
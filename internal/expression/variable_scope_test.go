@@ -0,0 +1,65 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package expression
+
+import (
+	"github.com/healthiop/hipath/hipathsys"
+	"github.com/healthiop/hipath/internal/test"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestVariableScope(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	node := hipathsys.NewString("node")
+	value := hipathsys.NewInteger(10)
+
+	define, err := LookupFunctionInvocation("defineVariable", []hipathsys.Evaluator{
+		ParseStringLiteral("'test'"), newTestExpression(value)})
+	if !assert.NoError(t, err, "no error expected") {
+		return
+	}
+
+	e := NewVariableScope(NewInvocationExpression(define, ParseExtConstantTerm("test")))
+	assert.IsType(t, (*InvocationExpression)(nil), e.Evaluator())
+	res, err := e.Evaluate(ctx, node, nil)
+	assert.NoError(t, err, "no error expected")
+	assert.Same(t, value, res)
+}
+
+func TestVariableScopeLoop(t *testing.T) {
+	loop := hipathsys.NewLoop(nil)
+	this := hipathsys.NewString("test")
+	loop.IncIndex(this)
+
+	e := NewVariableScope(NewThisInvocation())
+	res, err := e.Evaluate(test.NewTestContext(t), nil, loop)
+	assert.NoError(t, err, "no error expected")
+	assert.Same(t, this, res)
+}
@@ -29,6 +29,7 @@
 package internal
 
 import (
+	"errors"
 	"github.com/healthiop/hipath/hipathsys"
 	"github.com/healthiop/hipath/internal/expression"
 	"github.com/healthiop/hipath/internal/test"
@@ -742,3 +743,37 @@ func TestParseIsExpressionNot(t *testing.T) {
 		}
 	}
 }
+
+func TestParseSourceRange(t *testing.T) {
+	res, errorItemCollection := testParse("true and\n  ('a' |  'b').single()")
+
+	if assert.NotNil(t, errorItemCollection, "error item collection must have been initialized") {
+		assert.False(t, errorItemCollection.HasErrors(), "no errors expected")
+	}
+	if assert.IsType(t, (*expression.BooleanExpression)(nil), res) {
+		source := res.(expression.SourceRangeAccessor).SourceRange()
+		if assert.NotNil(t, source, "source range expected") {
+			assert.Equal(t, 1, source.Line())
+			assert.Equal(t, 0, source.Column())
+			assert.Equal(t, "true and\n  ('a' |  'b').single()", source.Text())
+		}
+	}
+}
+
+func TestParseSourceRangeError(t *testing.T) {
+	res, errorItemCollection := testParse("true and\n  ('a' |  'b').single()")
+
+	if assert.NotNil(t, errorItemCollection, "error item collection must have been initialized") {
+		assert.False(t, errorItemCollection.HasErrors(), "no errors expected")
+	}
+	if assert.Implements(t, (*hipathsys.Evaluator)(nil), res) {
+		ctx := test.NewTestContext(t)
+		_, err := res.(hipathsys.Evaluator).Evaluate(ctx, nil, nil)
+		var evalErr *hipathsys.EvaluationError
+		if assert.True(t, errors.As(err, &evalErr), "evaluation error expected") {
+			assert.Equal(t, 2, evalErr.Source().Line())
+			assert.Equal(t, 15, evalErr.Source().Column())
+			assert.Equal(t, "single()", evalErr.Source().Text())
+		}
+	}
+}
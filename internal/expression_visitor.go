@@ -29,7 +29,6 @@
 package internal
 
 import (
-	"fmt"
 	"github.com/antlr/antlr4/runtime/Go/antlr"
 	"github.com/healthiop/hipath/hipathsys"
 	"github.com/healthiop/hipath/internal/expression"
@@ -44,16 +43,31 @@ func (v *Visitor) VisitPolarityExpression(ctx *parser.PolarityExpressionContext)
 	return v.visitTree(ctx, 2, visitPolarityExpression)
 }
 
-func visitPolarityExpression(_ antlr.ParserRuleContext, args []interface{}) (hipathsys.Evaluator, error) {
+func visitPolarityExpression(ctx antlr.ParserRuleContext, args []interface{}) (hipathsys.Evaluator, error) {
 	op := args[0].(string)
 	evaluator := args[1].(hipathsys.Evaluator)
 
 	if op == "-" && evaluator != nil {
-		evaluator = expression.NewNegatorExpression(evaluator.(hipathsys.Evaluator))
+		if parenthesizedExpression(ctx.(*parser.PolarityExpressionContext).Expression()) {
+			evaluator = expression.NewNegatorExpression(evaluator)
+		} else {
+			evaluator = expression.NewSortKeyNegatorExpression(evaluator)
+		}
 	}
 	return evaluator, nil
 }
 
+// parenthesizedExpression returns if the specified expression is a
+// parenthesized term. A negated parenthesized expression is negated as usual
+// also when it is used as a key of the sort function.
+func parenthesizedExpression(ctx parser.IExpressionContext) bool {
+	if term, ok := ctx.(*parser.TermExpressionContext); ok {
+		_, ok := term.Term().(*parser.ParenthesizedTermContext)
+		return ok
+	}
+	return false
+}
+
 func (v *Visitor) VisitEqualityExpression(ctx *parser.EqualityExpressionContext) interface{} {
 	return v.visitTree(ctx, 3, visitEqualityExpression)
 }
@@ -75,7 +89,7 @@ func visitEqualityExpression(_ antlr.ParserRuleContext, args []interface{}) (hip
 		not = true
 		equivalent = true
 	default:
-		return nil, fmt.Errorf("invalid equality operator: %s", op)
+		return nil, hipathsys.NewCodedError(hipathsys.SyntaxErrorCode, "invalid equality operator: %s", op)
 	}
 	return expression.NewEqualityExpression(not, equivalent,
 		evalLeft, evalRight), nil
@@ -107,10 +121,15 @@ func (v *Visitor) VisitInvocationExpression(ctx *parser.InvocationExpressionCont
 	return v.visitTree(ctx, 3, visitInvocationExpression)
 }
 
-func visitInvocationExpression(_ antlr.ParserRuleContext, args []interface{}) (hipathsys.Evaluator, error) {
+func visitInvocationExpression(ctx antlr.ParserRuleContext, args []interface{}) (hipathsys.Evaluator, error) {
 	exprEvaluator := args[0].(hipathsys.Evaluator)
 	invocationEvaluator := args[2].(hipathsys.Evaluator)
 
+	// %terminologies is not evaluated, but provides the invoked method
+	if terminologiesInvocation(ctx) {
+		return invocationEvaluator, nil
+	}
+
 	return expression.NewInvocationExpression(exprEvaluator, invocationEvaluator), nil
 }
 
@@ -134,7 +153,7 @@ func visitInequalityExpression(_ antlr.ParserRuleContext, args []interface{}) (h
 	case ">=":
 		cmpOp = expression.GreaterOrEqualThanOp
 	default:
-		return nil, fmt.Errorf("invalid comparison operator: %s", op)
+		return nil, hipathsys.NewCodedError(hipathsys.SyntaxErrorCode, "invalid comparison operator: %s", op)
 	}
 
 	return expression.NewComparisonExpression(evalLeft, cmpOp, evalRight), nil
@@ -155,7 +174,7 @@ func visitMembershipExpression(_ antlr.ParserRuleContext, args []interface{}) (h
 	case "contains":
 		return expression.NewContainsExpression(evalLeft, evalRight, false), nil
 	default:
-		return nil, fmt.Errorf("invalid membership operator: %s", op)
+		return nil, hipathsys.NewCodedError(hipathsys.SyntaxErrorCode, "invalid membership operator: %s", op)
 	}
 }
 
@@ -187,7 +206,7 @@ func visitBooleanExpression(_ antlr.ParserRuleContext, args []interface{}) (hipa
 	case "implies":
 		booleanOp = expression.ImpliesOp
 	default:
-		return nil, fmt.Errorf("invalid boolean operator: %s", op)
+		return nil, hipathsys.NewCodedError(hipathsys.SyntaxErrorCode, "invalid boolean operator: %s", op)
 	}
 
 	return expression.NewBooleanExpression(evalLeft, booleanOp, evalRight), nil
@@ -208,6 +227,34 @@ func visitTypeExpression(_ antlr.ParserRuleContext, args []interface{}) (hipaths
 	case "is":
 		return expression.NewIsTypeExpression(evaluator, name)
 	default:
-		return nil, fmt.Errorf("invalid type expression operator: %s", op)
+		return nil, hipathsys.NewCodedError(hipathsys.SyntaxErrorCode, "invalid type expression operator: %s", op)
+	}
+}
+
+func (v *Visitor) VisitLambdaExpression(ctx *parser.LambdaExpressionContext) interface{} {
+	if _, ok := ctx.GetParent().(*parser.ParamListContext); !ok {
+		return v.AddError(ctx, "lambda expression can only be used as function argument")
+	}
+
+	var paramNames []string
+	for _, identifier := range ctx.LambdaParameters().(*parser.LambdaParametersContext).AllIdentifier() {
+		name := expression.ExtractIdentifier(identifier.GetText())
+		for _, paramName := range paramNames {
+			if paramName == name {
+				return v.AddError(ctx, "lambda parameter has already been defined: "+name)
+			}
+		}
+		paramNames = append(paramNames, name)
+	}
+	if len(paramNames) > 2 {
+		return v.AddError(ctx, "lambda expression accepts at most two parameters")
+	}
+
+	v.lambdaParams = append(v.lambdaParams, paramNames...)
+	body, ok := v.visitVariableScope(ctx.Expression()).(hipathsys.Evaluator)
+	v.lambdaParams = v.lambdaParams[:len(v.lambdaParams)-len(paramNames)]
+	if !ok {
+		return nil
 	}
+	return withSourceRange(ctx, expression.NewLambdaExpression(paramNames, body))
 }
@@ -29,6 +29,7 @@
 package internal
 
 import (
+	"errors"
 	"github.com/healthiop/hipath/hipathsys"
 	"github.com/healthiop/hipath/internal/expression"
 	"github.com/healthiop/hipath/internal/test"
@@ -118,3 +119,154 @@ func TestParseIsInvocation(t *testing.T) {
 		}
 	}
 }
+
+func TestParseOfTypeInvocation(t *testing.T) {
+	res, errorItemCollection := testParse("'my test'.ofType(System.String)")
+
+	if assert.NotNil(t, errorItemCollection, "error item collection must have been initialized") {
+		assert.False(t, errorItemCollection.HasErrors(), "no errors expected")
+	}
+	if assert.IsType(t, (*expression.InvocationExpression)(nil), res) {
+		ctx := test.NewTestContext(t)
+		res, err := res.(hipathsys.Evaluator).Evaluate(ctx, nil, nil)
+		assert.NoError(t, err, "no evaluation error expected")
+		if assert.Implements(t, (*hipathsys.CollectionAccessor)(nil), res) {
+			c := res.(hipathsys.CollectionAccessor)
+			if assert.Equal(t, 1, c.Count()) {
+				assert.Equal(t, hipathsys.NewString("my test"), c.Get(0))
+			}
+		}
+	}
+}
+
+func TestParseOfTypeInvocationDelimited(t *testing.T) {
+	res, errorItemCollection := testParse("'my test'.ofType(`System`.`Integer`)")
+
+	if assert.NotNil(t, errorItemCollection, "error item collection must have been initialized") {
+		assert.False(t, errorItemCollection.HasErrors(), "no errors expected")
+	}
+	if assert.IsType(t, (*expression.InvocationExpression)(nil), res) {
+		ctx := test.NewTestContext(t)
+		res, err := res.(hipathsys.Evaluator).Evaluate(ctx, nil, nil)
+		assert.NoError(t, err, "no evaluation error expected")
+		assert.Nil(t, res, "no result expected")
+	}
+}
+
+func TestParseOfTypeInvocationParamCount(t *testing.T) {
+	_, errorItemCollection := testParse("'my test'.ofType(String, Integer)")
+
+	if assert.NotNil(t, errorItemCollection, "error item collection must have been initialized") {
+		assert.True(t, errorItemCollection.HasErrors(), "errors expected")
+	}
+}
+
+func TestParseLambda(t *testing.T) {
+	res, errorItemCollection := testParse("select(x => x + 1)")
+
+	if assert.NotNil(t, errorItemCollection, "error item collection must have been initialized") {
+		assert.False(t, errorItemCollection.HasErrors(), "no errors expected")
+	}
+	if assert.IsType(t, (*expression.InvocationTerm)(nil), res) {
+		res, err := res.(hipathsys.Evaluator).Evaluate(test.NewTestContext(t), hipathsys.NewInteger(2), nil)
+		assert.NoError(t, err, "no evaluation error expected")
+		if assert.Implements(t, (*hipathsys.CollectionAccessor)(nil), res) {
+			col := res.(hipathsys.CollectionAccessor)
+			if assert.Equal(t, 1, col.Count()) {
+				assert.Equal(t, hipathsys.NewInteger(3), col.Get(0))
+			}
+		}
+	}
+}
+
+func TestParseLambdaWithoutParam(t *testing.T) {
+	_, errorItemCollection := testParse("select(=> $this)")
+
+	if assert.NotNil(t, errorItemCollection, "error item collection must have been initialized") {
+		assert.False(t, errorItemCollection.HasErrors(), "no errors expected")
+	}
+}
+
+func TestParseLambdaWithoutParamParenthesized(t *testing.T) {
+	_, errorItemCollection := testParse("select(() => $this)")
+
+	if assert.NotNil(t, errorItemCollection, "error item collection must have been initialized") {
+		assert.False(t, errorItemCollection.HasErrors(), "no errors expected")
+	}
+}
+
+func TestParseLambdaTwoParams(t *testing.T) {
+	res, errorItemCollection := testParse("aggregate((sum, x) => sum + x, 10)")
+
+	if assert.NotNil(t, errorItemCollection, "error item collection must have been initialized") {
+		assert.False(t, errorItemCollection.HasErrors(), "no errors expected")
+	}
+	if assert.Implements(t, (*hipathsys.Evaluator)(nil), res) {
+		res, err := res.(hipathsys.Evaluator).Evaluate(test.NewTestContext(t), hipathsys.NewInteger(2), nil)
+		assert.NoError(t, err, "no evaluation error expected")
+		assert.Equal(t, hipathsys.NewInteger(12), res)
+	}
+}
+
+func TestParseLambdaDuplicateParam(t *testing.T) {
+	res, errorItemCollection := testParse("aggregate((x, x) => x + 1, 0)")
+
+	if assert.NotNil(t, errorItemCollection, "error item collection must have been initialized") {
+		assert.True(t, errorItemCollection.HasErrors(), "errors expected")
+	}
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestParseLambdaTooManyParams(t *testing.T) {
+	res, errorItemCollection := testParse("aggregate((a, b, c) => a, 0)")
+
+	if assert.NotNil(t, errorItemCollection, "error item collection must have been initialized") {
+		assert.True(t, errorItemCollection.HasErrors(), "errors expected")
+	}
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestParseLambdaNoFunctionArg(t *testing.T) {
+	for _, pathString := range []string{
+		"x => x + 1",
+		"where($this > 1 and x => x > 2)",
+		"where((x => x > 2))",
+		"select(-(x => x))",
+	} {
+		res, errorItemCollection := testParse(pathString)
+
+		if assert.NotNil(t, errorItemCollection, "error item collection must have been initialized") {
+			assert.True(t, errorItemCollection.HasErrors(), "errors expected: %s", pathString)
+		}
+		assert.Nil(t, res, "no result expected: %s", pathString)
+	}
+}
+
+func TestParseLambdaNoEvaluatorParam(t *testing.T) {
+	res, errorItemCollection := testParse("iif(true, x => 1, 2)")
+
+	if assert.NotNil(t, errorItemCollection, "error item collection must have been initialized") {
+		assert.True(t, errorItemCollection.HasErrors(), "errors expected")
+	}
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestParseLambdaParamScope(t *testing.T) {
+	res, errorItemCollection := testParse("(1 | 2).select(x => x + 10) | x")
+
+	if assert.NotNil(t, errorItemCollection, "error item collection must have been initialized") {
+		assert.False(t, errorItemCollection.HasErrors(), "no errors expected")
+	}
+	if assert.IsType(t, (*expression.UnionExpression)(nil), res) {
+		// x outside of the lambda expression is a member invocation
+		res, err := res.(hipathsys.Evaluator).Evaluate(test.NewTestContext(t), nil, nil)
+		assert.Error(t, err, "error expected")
+		assert.False(t, errors.Is(err, hipathsys.ErrUndefinedVariable))
+		assert.Nil(t, res, "no result expected")
+	}
+}
+
+func TestParseLambdaEmptyBody(t *testing.T) {
+	res, _ := testParse("where(x => )")
+	assert.Nil(t, res, "no result expected")
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/healthiop/hipath/hipathsys"
 	"github.com/healthiop/hipath/internal/expression"
 	"github.com/healthiop/hipath/internal/parser"
+	"strings"
 )
 
 func (v *Visitor) VisitFunctionInvocation(ctx *parser.FunctionInvocationContext) interface{} {
@@ -40,10 +41,25 @@ func (v *Visitor) VisitFunctionInvocation(ctx *parser.FunctionInvocationContext)
 }
 
 func (v *Visitor) VisitFunction(ctx *parser.FunctionContext) interface{} {
-	return v.visitTree(ctx, 3, visitFunction)
+	return v.visitTree(ctx, 3, v.visitFunction)
 }
 
-func visitFunction(_ antlr.ParserRuleContext, args []interface{}) (hipathsys.Evaluator, error) {
+func (v *Visitor) lambdaParam(ctx *parser.InvocationTermContext) (string, bool) {
+	member, ok := ctx.Invocation().(*parser.MemberInvocationContext)
+	if !ok {
+		return "", false
+	}
+
+	name := expression.ExtractIdentifier(member.Identifier().GetText())
+	for _, paramName := range v.lambdaParams {
+		if paramName == name {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func (v *Visitor) visitFunction(ctx antlr.ParserRuleContext, args []interface{}) (hipathsys.Evaluator, error) {
 	name := args[0].(string)
 
 	var paramEvaluators []hipathsys.Evaluator
@@ -52,6 +68,8 @@ func visitFunction(_ antlr.ParserRuleContext, args []interface{}) (hipathsys.Eva
 	} else if name == "as" || name == "is" {
 		typeSpec := args[2].(string)
 		paramEvaluators = []hipathsys.Evaluator{expression.NewRawStringLiteral(typeSpec)}
+	} else if typeSpec, ok := typeSpecifierParam(ctx, name); ok {
+		paramEvaluators = []hipathsys.Evaluator{expression.NewRawStringLiteral(typeSpec)}
 	} else {
 		paramList := args[2].([]interface{})
 		// commas need to removed from argument list
@@ -63,7 +81,60 @@ func visitFunction(_ antlr.ParserRuleContext, args []interface{}) (hipathsys.Eva
 		}
 	}
 
-	return expression.LookupFunctionInvocation(expression.ExtractIdentifier(name), paramEvaluators)
+	name = expression.ExtractIdentifier(name)
+	if name == defineVariableFunctionName && len(paramEvaluators) > 0 {
+		if err := v.defineVariable(paramEvaluators[0]); err != nil {
+			return nil, err
+		}
+	}
+
+	registry := v.functions
+	if terminologiesInvocation(ctx.GetParent().GetParent()) {
+		registry = expression.TerminologiesMethods
+	}
+	return expression.LookupFunctionInvocationWithRegistry(registry, name, paramEvaluators)
+}
+
+// terminologiesInvocation returns if the specified node is a function
+// invocation on the %terminologies object, which is dispatched to the methods
+// of this object.
+func terminologiesInvocation(node antlr.Tree) bool {
+	invocation, ok := node.(*parser.InvocationExpressionContext)
+	if !ok {
+		return false
+	}
+	if _, ok := invocation.Invocation().(*parser.FunctionInvocationContext); !ok {
+		return false
+	}
+	term, ok := invocation.Expression().(*parser.TermExpressionContext)
+	if !ok {
+		return false
+	}
+	constant, ok := term.Term().(*parser.ExternalConstantTermContext)
+	if !ok {
+		return false
+	}
+
+	name := constant.ExternalConstant().GetText()[1:]
+	return expression.ExtractIdentifier(name) == hipathsys.TerminologiesEnvVarName
+}
+
+func typeSpecifierParam(ctx antlr.ParserRuleContext, name string) (string, bool) {
+	if expression.ExtractIdentifier(name) != "ofType" {
+		return "", false
+	}
+
+	paramList, ok := ctx.(*parser.FunctionContext).ParamList().(*parser.ParamListContext)
+	if !ok || len(paramList.AllExpression()) != 1 {
+		return "", false
+	}
+
+	// type specifier must not be evaluated as member invocation
+	parts := strings.Split(paramList.Expression(0).GetText(), ".")
+	for pos, part := range parts {
+		parts[pos] = expression.ExtractIdentifier(part)
+	}
+	return strings.Join(parts, "."), true
 }
 
 func (v *Visitor) VisitParamList(ctx *parser.ParamListContext) interface{} {
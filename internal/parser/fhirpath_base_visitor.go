@@ -63,6 +63,10 @@ func (v *BaseFHIRPathVisitor) VisitTypeExpression(ctx *TypeExpressionContext) in
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseFHIRPathVisitor) VisitLambdaExpression(ctx *LambdaExpressionContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseFHIRPathVisitor) VisitInvocationTerm(ctx *InvocationTermContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -170,3 +174,7 @@ func (v *BaseFHIRPathVisitor) VisitQualifiedIdentifier(ctx *QualifiedIdentifierC
 func (v *BaseFHIRPathVisitor) VisitIdentifier(ctx *IdentifierContext) interface{} {
 	return v.VisitChildren(ctx)
 }
+
+func (v *BaseFHIRPathVisitor) VisitLambdaParameters(ctx *LambdaParametersContext) interface{} {
+	return v.VisitChildren(ctx)
+}
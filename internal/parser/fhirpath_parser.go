@@ -15,81 +15,94 @@ var _ = reflect.Copy
 var _ = strconv.Itoa
 
 var parserATN = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 66, 164,
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 67, 186,
 	4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 9, 7,
-	4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 4, 13,
-	9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 3, 2, 3, 2, 3, 2, 3, 2, 5, 2, 35, 10,
-	2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3,
-	2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3,
-	2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3,
-	2, 3, 2, 3, 2, 7, 2, 75, 10, 2, 12, 2, 14, 2, 78, 11, 2, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 3, 3, 5, 3, 87, 10, 3, 3, 4, 3, 4, 3, 4, 3, 4, 3,
-	4, 3, 4, 3, 4, 3, 4, 3, 4, 5, 4, 98, 10, 4, 3, 5, 3, 5, 3, 5, 5, 5, 103,
-	10, 5, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 5, 6, 110, 10, 6, 3, 7, 3, 7, 3, 7,
-	3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 5, 7, 125,
-	10, 7, 3, 7, 3, 7, 5, 7, 129, 10, 7, 3, 8, 3, 8, 3, 8, 7, 8, 134, 10, 8,
-	12, 8, 14, 8, 137, 11, 8, 3, 9, 3, 9, 5, 9, 141, 10, 9, 3, 10, 3, 10, 3,
-	10, 5, 10, 146, 10, 10, 3, 11, 3, 11, 3, 12, 3, 12, 3, 13, 3, 13, 3, 14,
-	3, 14, 3, 14, 7, 14, 157, 10, 14, 12, 14, 14, 14, 160, 11, 14, 3, 15, 3,
-	15, 3, 15, 2, 3, 2, 16, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26,
-	28, 2, 14, 3, 2, 6, 7, 3, 2, 8, 11, 4, 2, 6, 7, 12, 12, 3, 2, 16, 19, 3,
-	2, 20, 23, 3, 2, 24, 25, 3, 2, 27, 28, 3, 2, 13, 14, 3, 2, 34, 35, 3, 2,
-	41, 48, 3, 2, 49, 56, 5, 2, 13, 14, 24, 25, 60, 61, 2, 185, 2, 34, 3, 2,
-	2, 2, 4, 86, 3, 2, 2, 2, 6, 97, 3, 2, 2, 2, 8, 99, 3, 2, 2, 2, 10, 109,
-	3, 2, 2, 2, 12, 128, 3, 2, 2, 2, 14, 130, 3, 2, 2, 2, 16, 138, 3, 2, 2,
-	2, 18, 145, 3, 2, 2, 2, 20, 147, 3, 2, 2, 2, 22, 149, 3, 2, 2, 2, 24, 151,
-	3, 2, 2, 2, 26, 153, 3, 2, 2, 2, 28, 161, 3, 2, 2, 2, 30, 31, 8, 2, 1,
-	2, 31, 35, 5, 4, 3, 2, 32, 33, 9, 2, 2, 2, 33, 35, 5, 2, 2, 13, 34, 30,
-	3, 2, 2, 2, 34, 32, 3, 2, 2, 2, 35, 76, 3, 2, 2, 2, 36, 37, 12, 12, 2,
-	2, 37, 38, 9, 3, 2, 2, 38, 75, 5, 2, 2, 13, 39, 40, 12, 11, 2, 2, 40, 41,
-	9, 4, 2, 2, 41, 75, 5, 2, 2, 12, 42, 43, 12, 9, 2, 2, 43, 44, 7, 15, 2,
-	2, 44, 75, 5, 2, 2, 10, 45, 46, 12, 8, 2, 2, 46, 47, 9, 5, 2, 2, 47, 75,
-	5, 2, 2, 9, 48, 49, 12, 7, 2, 2, 49, 50, 9, 6, 2, 2, 50, 75, 5, 2, 2, 8,
-	51, 52, 12, 6, 2, 2, 52, 53, 9, 7, 2, 2, 53, 75, 5, 2, 2, 7, 54, 55, 12,
-	5, 2, 2, 55, 56, 7, 26, 2, 2, 56, 75, 5, 2, 2, 6, 57, 58, 12, 4, 2, 2,
-	58, 59, 9, 8, 2, 2, 59, 75, 5, 2, 2, 5, 60, 61, 12, 3, 2, 2, 61, 62, 7,
-	29, 2, 2, 62, 75, 5, 2, 2, 4, 63, 64, 12, 15, 2, 2, 64, 65, 7, 3, 2, 2,
-	65, 75, 5, 10, 6, 2, 66, 67, 12, 14, 2, 2, 67, 68, 7, 4, 2, 2, 68, 69,
-	5, 2, 2, 2, 69, 70, 7, 5, 2, 2, 70, 75, 3, 2, 2, 2, 71, 72, 12, 10, 2,
-	2, 72, 73, 9, 9, 2, 2, 73, 75, 5, 24, 13, 2, 74, 36, 3, 2, 2, 2, 74, 39,
-	3, 2, 2, 2, 74, 42, 3, 2, 2, 2, 74, 45, 3, 2, 2, 2, 74, 48, 3, 2, 2, 2,
-	74, 51, 3, 2, 2, 2, 74, 54, 3, 2, 2, 2, 74, 57, 3, 2, 2, 2, 74, 60, 3,
-	2, 2, 2, 74, 63, 3, 2, 2, 2, 74, 66, 3, 2, 2, 2, 74, 71, 3, 2, 2, 2, 75,
-	78, 3, 2, 2, 2, 76, 74, 3, 2, 2, 2, 76, 77, 3, 2, 2, 2, 77, 3, 3, 2, 2,
-	2, 78, 76, 3, 2, 2, 2, 79, 87, 5, 10, 6, 2, 80, 87, 5, 6, 4, 2, 81, 87,
-	5, 8, 5, 2, 82, 83, 7, 30, 2, 2, 83, 84, 5, 2, 2, 2, 84, 85, 7, 31, 2,
-	2, 85, 87, 3, 2, 2, 2, 86, 79, 3, 2, 2, 2, 86, 80, 3, 2, 2, 2, 86, 81,
-	3, 2, 2, 2, 86, 82, 3, 2, 2, 2, 87, 5, 3, 2, 2, 2, 88, 89, 7, 32, 2, 2,
-	89, 98, 7, 33, 2, 2, 90, 98, 9, 10, 2, 2, 91, 98, 7, 62, 2, 2, 92, 98,
-	7, 63, 2, 2, 93, 98, 7, 57, 2, 2, 94, 98, 7, 58, 2, 2, 95, 98, 7, 59, 2,
-	2, 96, 98, 5, 16, 9, 2, 97, 88, 3, 2, 2, 2, 97, 90, 3, 2, 2, 2, 97, 91,
-	3, 2, 2, 2, 97, 92, 3, 2, 2, 2, 97, 93, 3, 2, 2, 2, 97, 94, 3, 2, 2, 2,
-	97, 95, 3, 2, 2, 2, 97, 96, 3, 2, 2, 2, 98, 7, 3, 2, 2, 2, 99, 102, 7,
-	36, 2, 2, 100, 103, 5, 28, 15, 2, 101, 103, 7, 62, 2, 2, 102, 100, 3, 2,
-	2, 2, 102, 101, 3, 2, 2, 2, 103, 9, 3, 2, 2, 2, 104, 110, 5, 28, 15, 2,
-	105, 110, 5, 12, 7, 2, 106, 110, 7, 37, 2, 2, 107, 110, 7, 38, 2, 2, 108,
-	110, 7, 39, 2, 2, 109, 104, 3, 2, 2, 2, 109, 105, 3, 2, 2, 2, 109, 106,
-	3, 2, 2, 2, 109, 107, 3, 2, 2, 2, 109, 108, 3, 2, 2, 2, 110, 11, 3, 2,
-	2, 2, 111, 112, 7, 14, 2, 2, 112, 113, 7, 30, 2, 2, 113, 114, 5, 24, 13,
-	2, 114, 115, 7, 31, 2, 2, 115, 129, 3, 2, 2, 2, 116, 117, 7, 13, 2, 2,
-	117, 118, 7, 30, 2, 2, 118, 119, 5, 24, 13, 2, 119, 120, 7, 31, 2, 2, 120,
-	129, 3, 2, 2, 2, 121, 122, 5, 28, 15, 2, 122, 124, 7, 30, 2, 2, 123, 125,
-	5, 14, 8, 2, 124, 123, 3, 2, 2, 2, 124, 125, 3, 2, 2, 2, 125, 126, 3, 2,
-	2, 2, 126, 127, 7, 31, 2, 2, 127, 129, 3, 2, 2, 2, 128, 111, 3, 2, 2, 2,
-	128, 116, 3, 2, 2, 2, 128, 121, 3, 2, 2, 2, 129, 13, 3, 2, 2, 2, 130, 135,
-	5, 2, 2, 2, 131, 132, 7, 40, 2, 2, 132, 134, 5, 2, 2, 2, 133, 131, 3, 2,
-	2, 2, 134, 137, 3, 2, 2, 2, 135, 133, 3, 2, 2, 2, 135, 136, 3, 2, 2, 2,
-	136, 15, 3, 2, 2, 2, 137, 135, 3, 2, 2, 2, 138, 140, 7, 63, 2, 2, 139,
-	141, 5, 18, 10, 2, 140, 139, 3, 2, 2, 2, 140, 141, 3, 2, 2, 2, 141, 17,
-	3, 2, 2, 2, 142, 146, 5, 20, 11, 2, 143, 146, 5, 22, 12, 2, 144, 146, 7,
-	62, 2, 2, 145, 142, 3, 2, 2, 2, 145, 143, 3, 2, 2, 2, 145, 144, 3, 2, 2,
-	2, 146, 19, 3, 2, 2, 2, 147, 148, 9, 11, 2, 2, 148, 21, 3, 2, 2, 2, 149,
-	150, 9, 12, 2, 2, 150, 23, 3, 2, 2, 2, 151, 152, 5, 26, 14, 2, 152, 25,
-	3, 2, 2, 2, 153, 158, 5, 28, 15, 2, 154, 155, 7, 3, 2, 2, 155, 157, 5,
-	28, 15, 2, 156, 154, 3, 2, 2, 2, 157, 160, 3, 2, 2, 2, 158, 156, 3, 2,
-	2, 2, 158, 159, 3, 2, 2, 2, 159, 27, 3, 2, 2, 2, 160, 158, 3, 2, 2, 2,
-	161, 162, 9, 13, 2, 2, 162, 29, 3, 2, 2, 2, 15, 34, 74, 76, 86, 97, 102,
-	109, 124, 128, 135, 140, 145, 158,
+	4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 4,
+	13, 9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 3, 2, 3, 2, 3, 2,
+	3, 2, 3, 2, 3, 2, 3, 2, 5, 2, 40, 10, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2,
+	3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2,
+	3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2,
+	3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 7, 2, 80, 10, 2,
+	12, 2, 14, 2, 83, 11, 2, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 5,
+	3, 92, 10, 3, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 5,
+	4, 103, 10, 4, 3, 5, 3, 5, 3, 5, 5, 5, 108, 10, 5, 3, 6, 3, 6, 3, 6, 3,
+	6, 3, 6, 5, 6, 115, 10, 6, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 3,
+	7, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 5, 7, 130, 10, 7, 3, 7, 3, 7, 5, 7,
+	134, 10, 7, 3, 8, 3, 8, 3, 8, 7, 8, 139, 10, 8, 12, 8, 14, 8, 142, 11,
+	8, 3, 9, 3, 9, 5, 9, 146, 10, 9, 3, 10, 3, 10, 3, 10, 5, 10, 151, 10,
+	10, 3, 11, 3, 11, 3, 12, 3, 12, 3, 13, 3, 13, 3, 14, 3, 14, 3, 14, 7,
+	14, 162, 10, 14, 12, 14, 14, 14, 165, 11, 14, 3, 15, 3, 15, 3, 15, 3,
+	16, 3, 16, 3, 16, 3, 16, 3, 16, 3, 16, 7, 16, 176, 10, 16, 12, 16, 14,
+	16, 179, 11, 16, 5, 16, 181, 10, 16, 3, 16, 3, 16, 5, 16, 185, 10, 16,
+	2, 3, 2, 17, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 2,
+	14, 3, 2, 6, 7, 3, 2, 8, 11, 4, 2, 6, 7, 12, 12, 3, 2, 16, 19, 3, 2,
+	20, 23, 3, 2, 24, 25, 3, 2, 27, 28, 3, 2, 13, 14, 3, 2, 34, 35, 3, 2,
+	41, 48, 3, 2, 49, 56, 5, 2, 13, 14, 24, 25, 60, 61, 2, 211, 2, 39, 3,
+	2, 2, 2, 4, 91, 3, 2, 2, 2, 6, 102, 3, 2, 2, 2, 8, 104, 3, 2, 2, 2, 10,
+	114, 3, 2, 2, 2, 12, 133, 3, 2, 2, 2, 14, 135, 3, 2, 2, 2, 16, 143, 3,
+	2, 2, 2, 18, 150, 3, 2, 2, 2, 20, 152, 3, 2, 2, 2, 22, 154, 3, 2, 2, 2,
+	24, 156, 3, 2, 2, 2, 26, 158, 3, 2, 2, 2, 28, 166, 3, 2, 2, 2, 30, 184,
+	3, 2, 2, 2, 32, 33, 8, 2, 1, 2, 33, 40, 5, 4, 3, 2, 34, 35, 9, 2, 2, 2,
+	35, 40, 5, 2, 2, 14, 36, 37, 5, 30, 16, 2, 37, 38, 7, 67, 2, 2, 38, 40,
+	5, 2, 2, 3, 39, 32, 3, 2, 2, 2, 39, 34, 3, 2, 2, 2, 39, 36, 3, 2, 2, 2,
+	40, 81, 3, 2, 2, 2, 41, 42, 12, 13, 2, 2, 42, 43, 9, 3, 2, 2, 43, 80,
+	5, 2, 2, 14, 44, 45, 12, 12, 2, 2, 45, 46, 9, 4, 2, 2, 46, 80, 5, 2, 2,
+	13, 47, 48, 12, 10, 2, 2, 48, 49, 7, 15, 2, 2, 49, 80, 5, 2, 2, 11, 50,
+	51, 12, 9, 2, 2, 51, 52, 9, 5, 2, 2, 52, 80, 5, 2, 2, 10, 53, 54, 12,
+	8, 2, 2, 54, 55, 9, 6, 2, 2, 55, 80, 5, 2, 2, 9, 56, 57, 12, 7, 2, 2,
+	57, 58, 9, 7, 2, 2, 58, 80, 5, 2, 2, 8, 59, 60, 12, 6, 2, 2, 60, 61, 7,
+	26, 2, 2, 61, 80, 5, 2, 2, 7, 62, 63, 12, 5, 2, 2, 63, 64, 9, 8, 2, 2,
+	64, 80, 5, 2, 2, 6, 65, 66, 12, 4, 2, 2, 66, 67, 7, 29, 2, 2, 67, 80,
+	5, 2, 2, 5, 68, 69, 12, 16, 2, 2, 69, 70, 7, 3, 2, 2, 70, 80, 5, 10, 6,
+	2, 71, 72, 12, 15, 2, 2, 72, 73, 7, 4, 2, 2, 73, 74, 5, 2, 2, 2, 74,
+	75, 7, 5, 2, 2, 75, 80, 3, 2, 2, 2, 76, 77, 12, 11, 2, 2, 77, 78, 9, 9,
+	2, 2, 78, 80, 5, 24, 13, 2, 79, 41, 3, 2, 2, 2, 79, 44, 3, 2, 2, 2, 79,
+	47, 3, 2, 2, 2, 79, 50, 3, 2, 2, 2, 79, 53, 3, 2, 2, 2, 79, 56, 3, 2,
+	2, 2, 79, 59, 3, 2, 2, 2, 79, 62, 3, 2, 2, 2, 79, 65, 3, 2, 2, 2, 79,
+	68, 3, 2, 2, 2, 79, 71, 3, 2, 2, 2, 79, 76, 3, 2, 2, 2, 80, 83, 3, 2,
+	2, 2, 81, 79, 3, 2, 2, 2, 81, 82, 3, 2, 2, 2, 82, 3, 3, 2, 2, 2, 83,
+	81, 3, 2, 2, 2, 84, 92, 5, 10, 6, 2, 85, 92, 5, 6, 4, 2, 86, 92, 5, 8,
+	5, 2, 87, 88, 7, 30, 2, 2, 88, 89, 5, 2, 2, 2, 89, 90, 7, 31, 2, 2, 90,
+	92, 3, 2, 2, 2, 91, 84, 3, 2, 2, 2, 91, 85, 3, 2, 2, 2, 91, 86, 3, 2,
+	2, 2, 91, 87, 3, 2, 2, 2, 92, 5, 3, 2, 2, 2, 93, 94, 7, 32, 2, 2, 94,
+	103, 7, 33, 2, 2, 95, 103, 9, 10, 2, 2, 96, 103, 7, 62, 2, 2, 97, 103,
+	7, 63, 2, 2, 98, 103, 7, 57, 2, 2, 99, 103, 7, 58, 2, 2, 100, 103, 7,
+	59, 2, 2, 101, 103, 5, 16, 9, 2, 102, 93, 3, 2, 2, 2, 102, 95, 3, 2, 2,
+	2, 102, 96, 3, 2, 2, 2, 102, 97, 3, 2, 2, 2, 102, 98, 3, 2, 2, 2, 102,
+	99, 3, 2, 2, 2, 102, 100, 3, 2, 2, 2, 102, 101, 3, 2, 2, 2, 103, 7, 3,
+	2, 2, 2, 104, 107, 7, 36, 2, 2, 105, 108, 5, 28, 15, 2, 106, 108, 7,
+	62, 2, 2, 107, 105, 3, 2, 2, 2, 107, 106, 3, 2, 2, 2, 108, 9, 3, 2, 2,
+	2, 109, 115, 5, 28, 15, 2, 110, 115, 5, 12, 7, 2, 111, 115, 7, 37, 2,
+	2, 112, 115, 7, 38, 2, 2, 113, 115, 7, 39, 2, 2, 114, 109, 3, 2, 2, 2,
+	114, 110, 3, 2, 2, 2, 114, 111, 3, 2, 2, 2, 114, 112, 3, 2, 2, 2, 114,
+	113, 3, 2, 2, 2, 115, 11, 3, 2, 2, 2, 116, 117, 7, 14, 2, 2, 117, 118,
+	7, 30, 2, 2, 118, 119, 5, 24, 13, 2, 119, 120, 7, 31, 2, 2, 120, 134,
+	3, 2, 2, 2, 121, 122, 7, 13, 2, 2, 122, 123, 7, 30, 2, 2, 123, 124, 5,
+	24, 13, 2, 124, 125, 7, 31, 2, 2, 125, 134, 3, 2, 2, 2, 126, 127, 5,
+	28, 15, 2, 127, 129, 7, 30, 2, 2, 128, 130, 5, 14, 8, 2, 129, 128, 3,
+	2, 2, 2, 129, 130, 3, 2, 2, 2, 130, 131, 3, 2, 2, 2, 131, 132, 7, 31,
+	2, 2, 132, 134, 3, 2, 2, 2, 133, 116, 3, 2, 2, 2, 133, 121, 3, 2, 2, 2,
+	133, 126, 3, 2, 2, 2, 134, 13, 3, 2, 2, 2, 135, 140, 5, 2, 2, 2, 136,
+	137, 7, 40, 2, 2, 137, 139, 5, 2, 2, 2, 138, 136, 3, 2, 2, 2, 139, 142,
+	3, 2, 2, 2, 140, 138, 3, 2, 2, 2, 140, 141, 3, 2, 2, 2, 141, 15, 3, 2,
+	2, 2, 142, 140, 3, 2, 2, 2, 143, 145, 7, 63, 2, 2, 144, 146, 5, 18, 10,
+	2, 145, 144, 3, 2, 2, 2, 145, 146, 3, 2, 2, 2, 146, 17, 3, 2, 2, 2,
+	147, 151, 5, 20, 11, 2, 148, 151, 5, 22, 12, 2, 149, 151, 7, 62, 2, 2,
+	150, 147, 3, 2, 2, 2, 150, 148, 3, 2, 2, 2, 150, 149, 3, 2, 2, 2, 151,
+	19, 3, 2, 2, 2, 152, 153, 9, 11, 2, 2, 153, 21, 3, 2, 2, 2, 154, 155,
+	9, 12, 2, 2, 155, 23, 3, 2, 2, 2, 156, 157, 5, 26, 14, 2, 157, 25, 3,
+	2, 2, 2, 158, 163, 5, 28, 15, 2, 159, 160, 7, 3, 2, 2, 160, 162, 5, 28,
+	15, 2, 161, 159, 3, 2, 2, 2, 162, 165, 3, 2, 2, 2, 163, 161, 3, 2, 2,
+	2, 163, 164, 3, 2, 2, 2, 164, 27, 3, 2, 2, 2, 165, 163, 3, 2, 2, 2,
+	166, 167, 9, 13, 2, 2, 167, 29, 3, 2, 2, 2, 169, 170, 5, 28, 15, 2,
+	170, 185, 3, 2, 2, 2, 171, 180, 7, 30, 2, 2, 172, 177, 5, 28, 15, 2,
+	173, 174, 7, 40, 2, 2, 174, 176, 5, 28, 15, 2, 175, 173, 3, 2, 2, 2,
+	176, 179, 3, 2, 2, 2, 177, 175, 3, 2, 2, 2, 177, 178, 3, 2, 2, 2, 178,
+	181, 3, 2, 2, 2, 179, 177, 3, 2, 2, 2, 180, 172, 3, 2, 2, 2, 180, 181,
+	3, 2, 2, 2, 181, 182, 3, 2, 2, 2, 182, 183, 7, 31, 2, 2, 183, 185, 3,
+	2, 2, 2, 184, 169, 3, 2, 2, 2, 184, 171, 3, 2, 2, 2, 184, 185, 3, 2, 2,
+	2, 185, 31, 3, 2, 2, 2, 18, 39, 79, 81, 91, 102, 107, 114, 129, 133,
+	140, 145, 150, 163, 177, 180, 184,
 }
 var literalNames = []string{
 	"", "'.'", "'['", "']'", "'+'", "'-'", "'*'", "'/'", "'div'", "'mod'",
@@ -98,20 +111,21 @@ var literalNames = []string{
 	"'('", "')'", "'{'", "'}'", "'true'", "'false'", "'%'", "'$this'", "'$index'",
 	"'$total'", "','", "'year'", "'month'", "'week'", "'day'", "'hour'", "'minute'",
 	"'second'", "'millisecond'", "'years'", "'months'", "'weeks'", "'days'",
-	"'hours'", "'minutes'", "'seconds'", "'milliseconds'",
+	"'hours'", "'minutes'", "'seconds'", "'milliseconds'", "", "", "", "", "",
+	"", "", "", "", "", "'=>'",
 }
 var symbolicNames = []string{
 	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
 	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
 	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
 	"", "DATE", "DATETIME", "TIME", "IDENTIFIER", "DELIMITEDIDENTIFIER", "STRING",
-	"NUMBER", "WS", "COMMENT", "LINE_COMMENT",
+	"NUMBER", "WS", "COMMENT", "LINE_COMMENT", "LAMBDA_ARROW",
 }
 
 var ruleNames = []string{
 	"expression", "term", "literal", "externalConstant", "invocation", "function",
 	"paramList", "quantity", "unit", "dateTimePrecision", "pluralDateTimePrecision",
-	"typeSpecifier", "qualifiedIdentifier", "identifier",
+	"typeSpecifier", "qualifiedIdentifier", "identifier", "lambdaParameters",
 }
 
 type FHIRPathParser struct {
@@ -210,6 +224,7 @@ const (
 	FHIRPathParserWS                  = 62
 	FHIRPathParserCOMMENT             = 63
 	FHIRPathParserLINE_COMMENT        = 64
+	FHIRPathParserLAMBDA_ARROW        = 65
 )
 
 // FHIRPathParser rules.
@@ -228,6 +243,7 @@ const (
 	FHIRPathParserRULE_typeSpecifier           = 11
 	FHIRPathParserRULE_qualifiedIdentifier     = 12
 	FHIRPathParserRULE_identifier              = 13
+	FHIRPathParserRULE_lambdaParameters        = 14
 )
 
 // IExpressionContext is an interface to support dynamic dispatch.
@@ -962,6 +978,54 @@ func (s *TypeExpressionContext) Accept(visitor antlr.ParseTreeVisitor) interface
 	}
 }
 
+type LambdaExpressionContext struct {
+	*ExpressionContext
+}
+
+func NewLambdaExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *LambdaExpressionContext {
+	var p = new(LambdaExpressionContext)
+
+	p.ExpressionContext = NewEmptyExpressionContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *LambdaExpressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *LambdaExpressionContext) LambdaParameters() ILambdaParametersContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILambdaParametersContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILambdaParametersContext)
+}
+
+func (s *LambdaExpressionContext) Expression() IExpressionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExpressionContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *LambdaExpressionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case FHIRPathVisitor:
+		return t.VisitLambdaExpression(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
 func (p *FHIRPathParser) Expression() (localctx IExpressionContext) {
 	return p.expression(0)
 }
@@ -995,26 +1059,25 @@ func (p *FHIRPathParser) expression(_p int) (localctx IExpressionContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(32)
+	p.SetState(37)
 	p.GetErrorHandler().Sync(p)
-
-	switch p.GetTokenStream().LA(1) {
-	case FHIRPathParserT__10, FHIRPathParserT__11, FHIRPathParserT__21, FHIRPathParserT__22, FHIRPathParserT__27, FHIRPathParserT__29, FHIRPathParserT__31, FHIRPathParserT__32, FHIRPathParserT__33, FHIRPathParserT__34, FHIRPathParserT__35, FHIRPathParserT__36, FHIRPathParserDATE, FHIRPathParserDATETIME, FHIRPathParserTIME, FHIRPathParserIDENTIFIER, FHIRPathParserDELIMITEDIDENTIFIER, FHIRPathParserSTRING, FHIRPathParserNUMBER:
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 0, p.GetParserRuleContext()) {
+	case 1:
 		localctx = NewTermExpressionContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 
 		{
-			p.SetState(29)
+			p.SetState(31)
 			p.Term()
 		}
 
-	case FHIRPathParserT__3, FHIRPathParserT__4:
+	case 2:
 		localctx = NewPolarityExpressionContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(30)
+			p.SetState(32)
 			_la = p.GetTokenStream().LA(1)
 
 			if !(_la == FHIRPathParserT__3 || _la == FHIRPathParserT__4) {
@@ -1025,15 +1088,30 @@ func (p *FHIRPathParser) expression(_p int) (localctx IExpressionContext) {
 			}
 		}
 		{
-			p.SetState(31)
-			p.expression(11)
+			p.SetState(33)
+			p.expression(12)
+		}
+
+	case 3:
+		localctx = NewLambdaExpressionContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(34)
+			p.LambdaParameters()
+		}
+		{
+			p.SetState(35)
+			p.Match(FHIRPathParserLAMBDA_ARROW)
+		}
+		{
+			p.SetState(36)
+			p.expression(1)
 		}
 
-	default:
-		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(74)
+	p.SetState(79)
 	p.GetErrorHandler().Sync(p)
 	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 2, p.GetParserRuleContext())
 
@@ -1043,19 +1121,19 @@ func (p *FHIRPathParser) expression(_p int) (localctx IExpressionContext) {
 				p.TriggerExitRuleEvent()
 			}
 			_prevctx = localctx
-			p.SetState(72)
+			p.SetState(77)
 			p.GetErrorHandler().Sync(p)
 			switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 1, p.GetParserRuleContext()) {
 			case 1:
 				localctx = NewMultiplicativeExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, FHIRPathParserRULE_expression)
-				p.SetState(34)
+				p.SetState(39)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 10)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 10)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 11)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 11)", ""))
 				}
 				{
-					p.SetState(35)
+					p.SetState(40)
 					_la = p.GetTokenStream().LA(1)
 
 					if !(((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<FHIRPathParserT__5)|(1<<FHIRPathParserT__6)|(1<<FHIRPathParserT__7)|(1<<FHIRPathParserT__8))) != 0) {
@@ -1066,20 +1144,20 @@ func (p *FHIRPathParser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(36)
-					p.expression(11)
+					p.SetState(41)
+					p.expression(12)
 				}
 
 			case 2:
 				localctx = NewAdditiveExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, FHIRPathParserRULE_expression)
-				p.SetState(37)
+				p.SetState(42)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 9)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 9)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 10)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 10)", ""))
 				}
 				{
-					p.SetState(38)
+					p.SetState(43)
 					_la = p.GetTokenStream().LA(1)
 
 					if !(((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<FHIRPathParserT__3)|(1<<FHIRPathParserT__4)|(1<<FHIRPathParserT__9))) != 0) {
@@ -1090,37 +1168,37 @@ func (p *FHIRPathParser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(39)
-					p.expression(10)
+					p.SetState(44)
+					p.expression(11)
 				}
 
 			case 3:
 				localctx = NewUnionExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, FHIRPathParserRULE_expression)
-				p.SetState(40)
+				p.SetState(45)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 7)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 7)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 8)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 8)", ""))
 				}
 				{
-					p.SetState(41)
+					p.SetState(46)
 					p.Match(FHIRPathParserT__12)
 				}
 				{
-					p.SetState(42)
-					p.expression(8)
+					p.SetState(47)
+					p.expression(9)
 				}
 
 			case 4:
 				localctx = NewInequalityExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, FHIRPathParserRULE_expression)
-				p.SetState(43)
+				p.SetState(48)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 6)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 6)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 7)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 7)", ""))
 				}
 				{
-					p.SetState(44)
+					p.SetState(49)
 					_la = p.GetTokenStream().LA(1)
 
 					if !(((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<FHIRPathParserT__13)|(1<<FHIRPathParserT__14)|(1<<FHIRPathParserT__15)|(1<<FHIRPathParserT__16))) != 0) {
@@ -1131,20 +1209,20 @@ func (p *FHIRPathParser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(45)
-					p.expression(7)
+					p.SetState(50)
+					p.expression(8)
 				}
 
 			case 5:
 				localctx = NewEqualityExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, FHIRPathParserRULE_expression)
-				p.SetState(46)
+				p.SetState(51)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 5)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 5)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 6)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 6)", ""))
 				}
 				{
-					p.SetState(47)
+					p.SetState(52)
 					_la = p.GetTokenStream().LA(1)
 
 					if !(((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<FHIRPathParserT__17)|(1<<FHIRPathParserT__18)|(1<<FHIRPathParserT__19)|(1<<FHIRPathParserT__20))) != 0) {
@@ -1155,20 +1233,20 @@ func (p *FHIRPathParser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(48)
-					p.expression(6)
+					p.SetState(53)
+					p.expression(7)
 				}
 
 			case 6:
 				localctx = NewMembershipExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, FHIRPathParserRULE_expression)
-				p.SetState(49)
+				p.SetState(54)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 4)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 4)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 5)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 5)", ""))
 				}
 				{
-					p.SetState(50)
+					p.SetState(55)
 					_la = p.GetTokenStream().LA(1)
 
 					if !(_la == FHIRPathParserT__21 || _la == FHIRPathParserT__22) {
@@ -1179,37 +1257,37 @@ func (p *FHIRPathParser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(51)
-					p.expression(5)
+					p.SetState(56)
+					p.expression(6)
 				}
 
 			case 7:
 				localctx = NewAndExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, FHIRPathParserRULE_expression)
-				p.SetState(52)
+				p.SetState(57)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 3)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 3)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 4)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 4)", ""))
 				}
 				{
-					p.SetState(53)
+					p.SetState(58)
 					p.Match(FHIRPathParserT__23)
 				}
 				{
-					p.SetState(54)
-					p.expression(4)
+					p.SetState(59)
+					p.expression(5)
 				}
 
 			case 8:
 				localctx = NewOrExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, FHIRPathParserRULE_expression)
-				p.SetState(55)
+				p.SetState(60)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 2)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 2)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 3)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 3)", ""))
 				}
 				{
-					p.SetState(56)
+					p.SetState(61)
 					_la = p.GetTokenStream().LA(1)
 
 					if !(_la == FHIRPathParserT__24 || _la == FHIRPathParserT__25) {
@@ -1220,75 +1298,75 @@ func (p *FHIRPathParser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(57)
-					p.expression(3)
+					p.SetState(62)
+					p.expression(4)
 				}
 
 			case 9:
 				localctx = NewImpliesExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, FHIRPathParserRULE_expression)
-				p.SetState(58)
+				p.SetState(63)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 1)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 1)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 2)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 2)", ""))
 				}
 				{
-					p.SetState(59)
+					p.SetState(64)
 					p.Match(FHIRPathParserT__26)
 				}
 				{
-					p.SetState(60)
-					p.expression(2)
+					p.SetState(65)
+					p.expression(3)
 				}
 
 			case 10:
 				localctx = NewInvocationExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, FHIRPathParserRULE_expression)
-				p.SetState(61)
+				p.SetState(66)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 13)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 13)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 14)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 14)", ""))
 				}
 				{
-					p.SetState(62)
+					p.SetState(67)
 					p.Match(FHIRPathParserT__0)
 				}
 				{
-					p.SetState(63)
+					p.SetState(68)
 					p.Invocation()
 				}
 
 			case 11:
 				localctx = NewIndexerExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, FHIRPathParserRULE_expression)
-				p.SetState(64)
+				p.SetState(69)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 12)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 12)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 13)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 13)", ""))
 				}
 				{
-					p.SetState(65)
+					p.SetState(70)
 					p.Match(FHIRPathParserT__1)
 				}
 				{
-					p.SetState(66)
+					p.SetState(71)
 					p.expression(0)
 				}
 				{
-					p.SetState(67)
+					p.SetState(72)
 					p.Match(FHIRPathParserT__2)
 				}
 
 			case 12:
 				localctx = NewTypeExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, FHIRPathParserRULE_expression)
-				p.SetState(69)
+				p.SetState(74)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 8)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 8)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 9)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 9)", ""))
 				}
 				{
-					p.SetState(70)
+					p.SetState(75)
 					_la = p.GetTokenStream().LA(1)
 
 					if !(_la == FHIRPathParserT__10 || _la == FHIRPathParserT__11) {
@@ -1299,14 +1377,14 @@ func (p *FHIRPathParser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(71)
+					p.SetState(76)
 					p.TypeSpecifier()
 				}
 
 			}
 
 		}
-		p.SetState(76)
+		p.SetState(81)
 		p.GetErrorHandler().Sync(p)
 		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 2, p.GetParserRuleContext())
 	}
@@ -1536,7 +1614,7 @@ func (p *FHIRPathParser) Term() (localctx ITermContext) {
 		}
 	}()
 
-	p.SetState(84)
+	p.SetState(89)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
@@ -1544,7 +1622,7 @@ func (p *FHIRPathParser) Term() (localctx ITermContext) {
 		localctx = NewInvocationTermContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(77)
+			p.SetState(82)
 			p.Invocation()
 		}
 
@@ -1552,7 +1630,7 @@ func (p *FHIRPathParser) Term() (localctx ITermContext) {
 		localctx = NewLiteralTermContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(78)
+			p.SetState(83)
 			p.Literal()
 		}
 
@@ -1560,7 +1638,7 @@ func (p *FHIRPathParser) Term() (localctx ITermContext) {
 		localctx = NewExternalConstantTermContext(p, localctx)
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(79)
+			p.SetState(84)
 			p.ExternalConstant()
 		}
 
@@ -1568,15 +1646,15 @@ func (p *FHIRPathParser) Term() (localctx ITermContext) {
 		localctx = NewParenthesizedTermContext(p, localctx)
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(80)
+			p.SetState(85)
 			p.Match(FHIRPathParserT__27)
 		}
 		{
-			p.SetState(81)
+			p.SetState(86)
 			p.expression(0)
 		}
 		{
-			p.SetState(82)
+			p.SetState(87)
 			p.Match(FHIRPathParserT__28)
 		}
 
@@ -1912,18 +1990,18 @@ func (p *FHIRPathParser) Literal() (localctx ILiteralContext) {
 		}
 	}()
 
-	p.SetState(95)
+	p.SetState(100)
 	p.GetErrorHandler().Sync(p)
 	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 4, p.GetParserRuleContext()) {
 	case 1:
 		localctx = NewNullLiteralContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(86)
+			p.SetState(91)
 			p.Match(FHIRPathParserT__29)
 		}
 		{
-			p.SetState(87)
+			p.SetState(92)
 			p.Match(FHIRPathParserT__30)
 		}
 
@@ -1931,7 +2009,7 @@ func (p *FHIRPathParser) Literal() (localctx ILiteralContext) {
 		localctx = NewBooleanLiteralContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(88)
+			p.SetState(93)
 			_la = p.GetTokenStream().LA(1)
 
 			if !(_la == FHIRPathParserT__31 || _la == FHIRPathParserT__32) {
@@ -1946,7 +2024,7 @@ func (p *FHIRPathParser) Literal() (localctx ILiteralContext) {
 		localctx = NewStringLiteralContext(p, localctx)
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(89)
+			p.SetState(94)
 			p.Match(FHIRPathParserSTRING)
 		}
 
@@ -1954,7 +2032,7 @@ func (p *FHIRPathParser) Literal() (localctx ILiteralContext) {
 		localctx = NewNumberLiteralContext(p, localctx)
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(90)
+			p.SetState(95)
 			p.Match(FHIRPathParserNUMBER)
 		}
 
@@ -1962,7 +2040,7 @@ func (p *FHIRPathParser) Literal() (localctx ILiteralContext) {
 		localctx = NewDateLiteralContext(p, localctx)
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(91)
+			p.SetState(96)
 			p.Match(FHIRPathParserDATE)
 		}
 
@@ -1970,7 +2048,7 @@ func (p *FHIRPathParser) Literal() (localctx ILiteralContext) {
 		localctx = NewDateTimeLiteralContext(p, localctx)
 		p.EnterOuterAlt(localctx, 6)
 		{
-			p.SetState(92)
+			p.SetState(97)
 			p.Match(FHIRPathParserDATETIME)
 		}
 
@@ -1978,7 +2056,7 @@ func (p *FHIRPathParser) Literal() (localctx ILiteralContext) {
 		localctx = NewTimeLiteralContext(p, localctx)
 		p.EnterOuterAlt(localctx, 7)
 		{
-			p.SetState(93)
+			p.SetState(98)
 			p.Match(FHIRPathParserTIME)
 		}
 
@@ -1986,7 +2064,7 @@ func (p *FHIRPathParser) Literal() (localctx ILiteralContext) {
 		localctx = NewQuantityLiteralContext(p, localctx)
 		p.EnterOuterAlt(localctx, 8)
 		{
-			p.SetState(94)
+			p.SetState(99)
 			p.Quantity()
 		}
 
@@ -2087,22 +2165,22 @@ func (p *FHIRPathParser) ExternalConstant() (localctx IExternalConstantContext)
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(97)
+		p.SetState(102)
 		p.Match(FHIRPathParserT__33)
 	}
-	p.SetState(100)
+	p.SetState(105)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case FHIRPathParserT__10, FHIRPathParserT__11, FHIRPathParserT__21, FHIRPathParserT__22, FHIRPathParserIDENTIFIER, FHIRPathParserDELIMITEDIDENTIFIER:
 		{
-			p.SetState(98)
+			p.SetState(103)
 			p.Identifier()
 		}
 
 	case FHIRPathParserSTRING:
 		{
-			p.SetState(99)
+			p.SetState(104)
 			p.Match(FHIRPathParserSTRING)
 		}
 
@@ -2343,14 +2421,14 @@ func (p *FHIRPathParser) Invocation() (localctx IInvocationContext) {
 		}
 	}()
 
-	p.SetState(107)
+	p.SetState(112)
 	p.GetErrorHandler().Sync(p)
 	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 6, p.GetParserRuleContext()) {
 	case 1:
 		localctx = NewMemberInvocationContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(102)
+			p.SetState(107)
 			p.Identifier()
 		}
 
@@ -2358,7 +2436,7 @@ func (p *FHIRPathParser) Invocation() (localctx IInvocationContext) {
 		localctx = NewFunctionInvocationContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(103)
+			p.SetState(108)
 			p.Function()
 		}
 
@@ -2366,7 +2444,7 @@ func (p *FHIRPathParser) Invocation() (localctx IInvocationContext) {
 		localctx = NewThisInvocationContext(p, localctx)
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(104)
+			p.SetState(109)
 			p.Match(FHIRPathParserT__34)
 		}
 
@@ -2374,7 +2452,7 @@ func (p *FHIRPathParser) Invocation() (localctx IInvocationContext) {
 		localctx = NewIndexInvocationContext(p, localctx)
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(105)
+			p.SetState(110)
 			p.Match(FHIRPathParserT__35)
 		}
 
@@ -2382,7 +2460,7 @@ func (p *FHIRPathParser) Invocation() (localctx IInvocationContext) {
 		localctx = NewTotalInvocationContext(p, localctx)
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(106)
+			p.SetState(111)
 			p.Match(FHIRPathParserT__36)
 		}
 
@@ -2498,70 +2576,70 @@ func (p *FHIRPathParser) Function() (localctx IFunctionContext) {
 		}
 	}()
 
-	p.SetState(126)
+	p.SetState(131)
 	p.GetErrorHandler().Sync(p)
 	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 8, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(109)
+			p.SetState(114)
 			p.Match(FHIRPathParserT__11)
 		}
 		{
-			p.SetState(110)
+			p.SetState(115)
 			p.Match(FHIRPathParserT__27)
 		}
 		{
-			p.SetState(111)
+			p.SetState(116)
 			p.TypeSpecifier()
 		}
 		{
-			p.SetState(112)
+			p.SetState(117)
 			p.Match(FHIRPathParserT__28)
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(114)
+			p.SetState(119)
 			p.Match(FHIRPathParserT__10)
 		}
 		{
-			p.SetState(115)
+			p.SetState(120)
 			p.Match(FHIRPathParserT__27)
 		}
 		{
-			p.SetState(116)
+			p.SetState(121)
 			p.TypeSpecifier()
 		}
 		{
-			p.SetState(117)
+			p.SetState(122)
 			p.Match(FHIRPathParserT__28)
 		}
 
 	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(119)
+			p.SetState(124)
 			p.Identifier()
 		}
 		{
-			p.SetState(120)
+			p.SetState(125)
 			p.Match(FHIRPathParserT__27)
 		}
-		p.SetState(122)
+		p.SetState(127)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 
-		if (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<FHIRPathParserT__3)|(1<<FHIRPathParserT__4)|(1<<FHIRPathParserT__10)|(1<<FHIRPathParserT__11)|(1<<FHIRPathParserT__21)|(1<<FHIRPathParserT__22)|(1<<FHIRPathParserT__27)|(1<<FHIRPathParserT__29))) != 0) || (((_la-32)&-(0x1f+1)) == 0 && ((1<<uint((_la-32)))&((1<<(FHIRPathParserT__31-32))|(1<<(FHIRPathParserT__32-32))|(1<<(FHIRPathParserT__33-32))|(1<<(FHIRPathParserT__34-32))|(1<<(FHIRPathParserT__35-32))|(1<<(FHIRPathParserT__36-32))|(1<<(FHIRPathParserDATE-32))|(1<<(FHIRPathParserDATETIME-32))|(1<<(FHIRPathParserTIME-32))|(1<<(FHIRPathParserIDENTIFIER-32))|(1<<(FHIRPathParserDELIMITEDIDENTIFIER-32))|(1<<(FHIRPathParserSTRING-32))|(1<<(FHIRPathParserNUMBER-32)))) != 0) {
+		if (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<FHIRPathParserT__3)|(1<<FHIRPathParserT__4)|(1<<FHIRPathParserT__10)|(1<<FHIRPathParserT__11)|(1<<FHIRPathParserT__21)|(1<<FHIRPathParserT__22)|(1<<FHIRPathParserT__27)|(1<<FHIRPathParserT__29))) != 0) || (((_la-32)&-(0x1f+1)) == 0 && ((1<<uint((_la-32)))&((1<<(FHIRPathParserT__31-32))|(1<<(FHIRPathParserT__32-32))|(1<<(FHIRPathParserT__33-32))|(1<<(FHIRPathParserT__34-32))|(1<<(FHIRPathParserT__35-32))|(1<<(FHIRPathParserT__36-32))|(1<<(FHIRPathParserDATE-32))|(1<<(FHIRPathParserDATETIME-32))|(1<<(FHIRPathParserTIME-32))|(1<<(FHIRPathParserIDENTIFIER-32))|(1<<(FHIRPathParserDELIMITEDIDENTIFIER-32))|(1<<(FHIRPathParserSTRING-32))|(1<<(FHIRPathParserNUMBER-32)))) != 0) || _la == FHIRPathParserLAMBDA_ARROW {
 			{
-				p.SetState(121)
+				p.SetState(126)
 				p.ParamList()
 			}
 
 		}
 		{
-			p.SetState(124)
+			p.SetState(129)
 			p.Match(FHIRPathParserT__28)
 		}
 
@@ -2672,24 +2750,24 @@ func (p *FHIRPathParser) ParamList() (localctx IParamListContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(128)
+		p.SetState(133)
 		p.expression(0)
 	}
-	p.SetState(133)
+	p.SetState(138)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == FHIRPathParserT__37 {
 		{
-			p.SetState(129)
+			p.SetState(134)
 			p.Match(FHIRPathParserT__37)
 		}
 		{
-			p.SetState(130)
+			p.SetState(135)
 			p.expression(0)
 		}
 
-		p.SetState(135)
+		p.SetState(140)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -2789,15 +2867,15 @@ func (p *FHIRPathParser) Quantity() (localctx IQuantityContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(136)
+		p.SetState(141)
 		p.Match(FHIRPathParserNUMBER)
 	}
-	p.SetState(138)
+	p.SetState(143)
 	p.GetErrorHandler().Sync(p)
 
 	if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 10, p.GetParserRuleContext()) == 1 {
 		{
-			p.SetState(137)
+			p.SetState(142)
 			p.Unit()
 		}
 
@@ -2906,28 +2984,28 @@ func (p *FHIRPathParser) Unit() (localctx IUnitContext) {
 		}
 	}()
 
-	p.SetState(143)
+	p.SetState(148)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case FHIRPathParserT__38, FHIRPathParserT__39, FHIRPathParserT__40, FHIRPathParserT__41, FHIRPathParserT__42, FHIRPathParserT__43, FHIRPathParserT__44, FHIRPathParserT__45:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(140)
+			p.SetState(145)
 			p.DateTimePrecision()
 		}
 
 	case FHIRPathParserT__46, FHIRPathParserT__47, FHIRPathParserT__48, FHIRPathParserT__49, FHIRPathParserT__50, FHIRPathParserT__51, FHIRPathParserT__52, FHIRPathParserT__53:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(141)
+			p.SetState(146)
 			p.PluralDateTimePrecision()
 		}
 
 	case FHIRPathParserSTRING:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(142)
+			p.SetState(147)
 			p.Match(FHIRPathParserSTRING)
 		}
 
@@ -3016,7 +3094,7 @@ func (p *FHIRPathParser) DateTimePrecision() (localctx IDateTimePrecisionContext
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(145)
+		p.SetState(150)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(((_la-39)&-(0x1f+1)) == 0 && ((1<<uint((_la-39)))&((1<<(FHIRPathParserT__38-39))|(1<<(FHIRPathParserT__39-39))|(1<<(FHIRPathParserT__40-39))|(1<<(FHIRPathParserT__41-39))|(1<<(FHIRPathParserT__42-39))|(1<<(FHIRPathParserT__43-39))|(1<<(FHIRPathParserT__44-39))|(1<<(FHIRPathParserT__45-39)))) != 0) {
@@ -3108,7 +3186,7 @@ func (p *FHIRPathParser) PluralDateTimePrecision() (localctx IPluralDateTimePrec
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(147)
+		p.SetState(152)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(((_la-47)&-(0x1f+1)) == 0 && ((1<<uint((_la-47)))&((1<<(FHIRPathParserT__46-47))|(1<<(FHIRPathParserT__47-47))|(1<<(FHIRPathParserT__48-47))|(1<<(FHIRPathParserT__49-47))|(1<<(FHIRPathParserT__50-47))|(1<<(FHIRPathParserT__51-47))|(1<<(FHIRPathParserT__52-47))|(1<<(FHIRPathParserT__53-47)))) != 0) {
@@ -3210,7 +3288,7 @@ func (p *FHIRPathParser) TypeSpecifier() (localctx ITypeSpecifierContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(149)
+		p.SetState(154)
 		p.QualifiedIdentifier()
 	}
 
@@ -3320,26 +3398,26 @@ func (p *FHIRPathParser) QualifiedIdentifier() (localctx IQualifiedIdentifierCon
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(151)
+		p.SetState(156)
 		p.Identifier()
 	}
-	p.SetState(156)
+	p.SetState(161)
 	p.GetErrorHandler().Sync(p)
 	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 12, p.GetParserRuleContext())
 
 	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
 		if _alt == 1 {
 			{
-				p.SetState(152)
+				p.SetState(157)
 				p.Match(FHIRPathParserT__0)
 			}
 			{
-				p.SetState(153)
+				p.SetState(158)
 				p.Identifier()
 			}
 
 		}
-		p.SetState(158)
+		p.SetState(163)
 		p.GetErrorHandler().Sync(p)
 		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 12, p.GetParserRuleContext())
 	}
@@ -3434,7 +3512,7 @@ func (p *FHIRPathParser) Identifier() (localctx IIdentifierContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(159)
+		p.SetState(164)
 		_la = p.GetTokenStream().LA(1)
 
 		if !((((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<FHIRPathParserT__10)|(1<<FHIRPathParserT__11)|(1<<FHIRPathParserT__21)|(1<<FHIRPathParserT__22))) != 0) || _la == FHIRPathParserIDENTIFIER || _la == FHIRPathParserDELIMITEDIDENTIFIER) {
@@ -3448,6 +3526,162 @@ func (p *FHIRPathParser) Identifier() (localctx IIdentifierContext) {
 	return localctx
 }
 
+// ILambdaParametersContext is an interface to support dynamic dispatch.
+type ILambdaParametersContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsLambdaParametersContext differentiates from other interfaces.
+	IsLambdaParametersContext()
+}
+
+type LambdaParametersContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyLambdaParametersContext() *LambdaParametersContext {
+	var p = new(LambdaParametersContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = FHIRPathParserRULE_lambdaParameters
+	return p
+}
+
+func (*LambdaParametersContext) IsLambdaParametersContext() {}
+
+func NewLambdaParametersContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *LambdaParametersContext {
+	var p = new(LambdaParametersContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = FHIRPathParserRULE_lambdaParameters
+
+	return p
+}
+
+func (s *LambdaParametersContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *LambdaParametersContext) AllIdentifier() []IIdentifierContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IIdentifierContext)(nil)).Elem())
+	var tst = make([]IIdentifierContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IIdentifierContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *LambdaParametersContext) Identifier(i int) IIdentifierContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IIdentifierContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIdentifierContext)
+}
+
+func (s *LambdaParametersContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *LambdaParametersContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *LambdaParametersContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case FHIRPathVisitor:
+		return t.VisitLambdaParameters(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *FHIRPathParser) LambdaParameters() (localctx ILambdaParametersContext) {
+	localctx = NewLambdaParametersContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 28, FHIRPathParserRULE_lambdaParameters)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(182)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case FHIRPathParserT__10, FHIRPathParserT__11, FHIRPathParserT__21, FHIRPathParserT__22, FHIRPathParserIDENTIFIER, FHIRPathParserDELIMITEDIDENTIFIER:
+		{
+			p.SetState(167)
+			p.Identifier()
+		}
+
+	case FHIRPathParserT__27:
+		{
+			p.SetState(169)
+			p.Match(FHIRPathParserT__27)
+		}
+		p.SetState(178)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<FHIRPathParserT__10)|(1<<FHIRPathParserT__11)|(1<<FHIRPathParserT__21)|(1<<FHIRPathParserT__22))) != 0) || _la == FHIRPathParserIDENTIFIER || _la == FHIRPathParserDELIMITEDIDENTIFIER {
+			{
+				p.SetState(170)
+				p.Identifier()
+			}
+			p.SetState(175)
+			p.GetErrorHandler().Sync(p)
+			_la = p.GetTokenStream().LA(1)
+
+			for _la == FHIRPathParserT__37 {
+				{
+					p.SetState(171)
+					p.Match(FHIRPathParserT__37)
+				}
+				{
+					p.SetState(172)
+					p.Identifier()
+				}
+
+				p.SetState(177)
+				p.GetErrorHandler().Sync(p)
+				_la = p.GetTokenStream().LA(1)
+			}
+
+		}
+		{
+			p.SetState(180)
+			p.Match(FHIRPathParserT__28)
+		}
+
+	default:
+	}
+
+	return localctx
+}
+
 func (p *FHIRPathParser) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex int) bool {
 	switch ruleIndex {
 	case 0:
@@ -3465,40 +3699,40 @@ func (p *FHIRPathParser) Sempred(localctx antlr.RuleContext, ruleIndex, predInde
 func (p *FHIRPathParser) Expression_Sempred(localctx antlr.RuleContext, predIndex int) bool {
 	switch predIndex {
 	case 0:
-		return p.Precpred(p.GetParserRuleContext(), 10)
+		return p.Precpred(p.GetParserRuleContext(), 11)
 
 	case 1:
-		return p.Precpred(p.GetParserRuleContext(), 9)
+		return p.Precpred(p.GetParserRuleContext(), 10)
 
 	case 2:
-		return p.Precpred(p.GetParserRuleContext(), 7)
+		return p.Precpred(p.GetParserRuleContext(), 8)
 
 	case 3:
-		return p.Precpred(p.GetParserRuleContext(), 6)
+		return p.Precpred(p.GetParserRuleContext(), 7)
 
 	case 4:
-		return p.Precpred(p.GetParserRuleContext(), 5)
+		return p.Precpred(p.GetParserRuleContext(), 6)
 
 	case 5:
-		return p.Precpred(p.GetParserRuleContext(), 4)
+		return p.Precpred(p.GetParserRuleContext(), 5)
 
 	case 6:
-		return p.Precpred(p.GetParserRuleContext(), 3)
+		return p.Precpred(p.GetParserRuleContext(), 4)
 
 	case 7:
-		return p.Precpred(p.GetParserRuleContext(), 2)
+		return p.Precpred(p.GetParserRuleContext(), 3)
 
 	case 8:
-		return p.Precpred(p.GetParserRuleContext(), 1)
+		return p.Precpred(p.GetParserRuleContext(), 2)
 
 	case 9:
-		return p.Precpred(p.GetParserRuleContext(), 13)
+		return p.Precpred(p.GetParserRuleContext(), 14)
 
 	case 10:
-		return p.Precpred(p.GetParserRuleContext(), 12)
+		return p.Precpred(p.GetParserRuleContext(), 13)
 
 	case 11:
-		return p.Precpred(p.GetParserRuleContext(), 8)
+		return p.Precpred(p.GetParserRuleContext(), 9)
 
 	default:
 		panic("No predicate with index: " + fmt.Sprint(predIndex))
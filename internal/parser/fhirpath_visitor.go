@@ -49,6 +49,9 @@ type FHIRPathVisitor interface {
 	// Visit a parse tree produced by FHIRPathParser#typeExpression.
 	VisitTypeExpression(ctx *TypeExpressionContext) interface{}
 
+	// Visit a parse tree produced by FHIRPathParser#lambdaExpression.
+	VisitLambdaExpression(ctx *LambdaExpressionContext) interface{}
+
 	// Visit a parse tree produced by FHIRPathParser#invocationTerm.
 	VisitInvocationTerm(ctx *InvocationTermContext) interface{}
 
@@ -129,4 +132,7 @@ type FHIRPathVisitor interface {
 
 	// Visit a parse tree produced by FHIRPathParser#identifier.
 	VisitIdentifier(ctx *IdentifierContext) interface{}
+
+	// Visit a parse tree produced by FHIRPathParser#lambdaParameters.
+	VisitLambdaParameters(ctx *LambdaParametersContext) interface{}
 }
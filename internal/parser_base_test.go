@@ -41,7 +41,7 @@ func testParse(pathString string) (res interface{}, errorItemCollection *ErrorIt
 
 	errorItemCollection = NewErrorItemCollection()
 	v := NewVisitor(errorItemCollection)
-	res = p.Expression().Accept(v)
+	res = v.VisitPath(p.Expression())
 
 	return
 }
@@ -48,15 +48,19 @@ func (v *Visitor) VisitExternalConstantTerm(ctx *parser.ExternalConstantTermCont
 }
 
 func (v *Visitor) VisitExternalConstant(ctx *parser.ExternalConstantContext) interface{} {
-	return v.visitTree(ctx, 2, visitExternalConstant)
+	return v.visitTree(ctx, 2, v.visitExternalConstant)
 }
 
-func visitExternalConstant(_ antlr.ParserRuleContext, args []interface{}) (hipathsys.Evaluator, error) {
-	name := args[1].(string)
-	return expression.ParseExtConstantTerm(expression.ExtractIdentifier(name)), nil
+func (v *Visitor) visitExternalConstant(_ antlr.ParserRuleContext, args []interface{}) (hipathsys.Evaluator, error) {
+	name := expression.ExtractIdentifier(args[1].(string))
+	v.referenceVariable(name)
+	return expression.ParseExtConstantTerm(name), nil
 }
 
 func (v *Visitor) VisitInvocationTerm(ctx *parser.InvocationTermContext) interface{} {
+	if name, ok := v.lambdaParam(ctx); ok {
+		return withSourceRange(ctx, expression.NewLambdaParamInvocation(name))
+	}
 	return v.visitTree(ctx, 1, visitInvocationTerm)
 }
 
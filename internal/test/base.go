@@ -80,7 +80,7 @@ type testModel struct {
 	t *testing.T
 }
 
-func newTestModel(t *testing.T) hipathsys.ModelAdapter {
+func NewTestModel(t *testing.T) hipathsys.ModelAdapter {
 	return &testModel{t}
 }
 
@@ -228,22 +228,47 @@ func (a *testModel) Children(node interface{}) (hipathsys.CollectionAccessor, er
 }
 
 type testContext struct {
-	modelAdapter hipathsys.ModelAdapter
-	tracer       hipathsys.Tracer
-	node         interface{}
+	modelAdapter        hipathsys.ModelAdapter
+	tracer              hipathsys.Tracer
+	referenceResolver   hipathsys.ReferenceResolver
+	terminologyProvider hipathsys.TerminologyProvider
+	profileValidator    hipathsys.ProfileValidator
+	lazyErrors          bool
+	limiter             *hipathsys.Limiter
+	node                interface{}
 }
 
 func NewTestContext(t *testing.T) hipathsys.ContextAccessor {
-	return &testContext{modelAdapter: newTestModel(t)}
+	return &testContext{modelAdapter: NewTestModel(t)}
 }
 
 func NewTestContextWithNode(t *testing.T, node interface{}) hipathsys.ContextAccessor {
-	return &testContext{modelAdapter: newTestModel(t), node: node}
+	return &testContext{modelAdapter: NewTestModel(t), node: node}
+}
+
+func NewTestContextWithReferenceResolver(t *testing.T, resolver hipathsys.ReferenceResolver) hipathsys.ContextAccessor {
+	return &testContext{modelAdapter: NewTestModel(t), referenceResolver: resolver}
+}
+
+func NewTestContextWithTerminologyProvider(t *testing.T, provider hipathsys.TerminologyProvider) hipathsys.ContextAccessor {
+	return &testContext{modelAdapter: NewTestModel(t), terminologyProvider: provider}
+}
+
+func NewTestContextWithProfileValidator(t *testing.T, validator hipathsys.ProfileValidator) hipathsys.ContextAccessor {
+	return &testContext{modelAdapter: NewTestModel(t), profileValidator: validator}
+}
+
+func NewTestContextWithLazyErrors(t *testing.T) hipathsys.ContextAccessor {
+	return &testContext{modelAdapter: NewTestModel(t), lazyErrors: true}
+}
+
+func NewTestContextWithLimits(t *testing.T, limits hipathsys.Limits) hipathsys.ContextAccessor {
+	return &testContext{modelAdapter: NewTestModel(t), limiter: hipathsys.NewLimiter(limits)}
 }
 
 func NewTestContextWithNodeAndTracer(t *testing.T, node interface{}, tracer hipathsys.Tracer) hipathsys.ContextAccessor {
 	return &testContext{
-		modelAdapter: newTestModel(t),
+		modelAdapter: NewTestModel(t),
 		tracer:       tracer,
 		node:         node,
 	}
@@ -261,11 +286,11 @@ func (t *testContext) ModelAdapter() hipathsys.ModelAdapter {
 }
 
 func (t *testContext) NewCollection() hipathsys.CollectionModifier {
-	return hipathsys.NewCollection(t.modelAdapter)
+	return hipathsys.NewCollectionWithMaxSize(t.modelAdapter, t.limiter.MaxCollectionSize())
 }
 
 func (t *testContext) NewCollectionWithItem(item interface{}) (hipathsys.CollectionModifier, error) {
-	return hipathsys.NewCollectionWithItem(t.modelAdapter, item)
+	return hipathsys.NewCollectionWithItemAndMaxSize(t.modelAdapter, item, t.limiter.MaxCollectionSize())
 }
 
 func (t *testContext) ContextNode() interface{} {
@@ -276,6 +301,36 @@ func (t *testContext) Tracer() hipathsys.Tracer {
 	return t.tracer
 }
 
+func (t *testContext) ReferenceResolver() hipathsys.ReferenceResolver {
+	return t.referenceResolver
+}
+
+func (t *testContext) TerminologyProvider() hipathsys.TerminologyProvider {
+	return t.terminologyProvider
+}
+
+func (t *testContext) ProfileValidator() hipathsys.ProfileValidator {
+	return t.profileValidator
+}
+
+func (t *testContext) LazyErrors() bool {
+	return t.lazyErrors
+}
+
+func (t *testContext) Limiter() *hipathsys.Limiter {
+	return t.limiter
+}
+
+func (t *testContext) BindLimiter() hipathsys.ContextAccessor {
+	if t.limiter == nil {
+		return t
+	}
+
+	bt := *t
+	bt.limiter = hipathsys.NewLimiter(t.limiter.Limits())
+	return &bt
+}
+
 type errorCollection struct {
 }
 
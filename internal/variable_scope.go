@@ -0,0 +1,170 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package internal
+
+import (
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+	"github.com/healthiop/hipath/hipathsys"
+	"github.com/healthiop/hipath/internal/expression"
+	"github.com/healthiop/hipath/internal/parser"
+	"strings"
+)
+
+const defineVariableFunctionName = "defineVariable"
+
+var systemVariableNames = map[string]bool{
+	"context":       true,
+	"resource":      true,
+	"rootResource":  true,
+	"ucum":          true,
+	"sct":           true,
+	"loinc":         true,
+	"terminologies": true,
+}
+
+var systemVariablePrefixes = []string{"vs-", "ext-"}
+
+// variableScope contains the variables that are defined by defineVariable
+// within a scope and the references to variables that have not (yet) been
+// defined.
+type variableScope struct {
+	parent     *variableScope
+	defined    map[string]bool
+	references map[string]bool
+}
+
+func newVariableScope(parent *variableScope) *variableScope {
+	return &variableScope{
+		parent:     parent,
+		defined:    make(map[string]bool),
+		references: make(map[string]bool),
+	}
+}
+
+func (s *variableScope) isDefined(name string) bool {
+	for c := s; c != nil; c = c.parent {
+		if c.defined[name] {
+			return true
+		}
+	}
+	return false
+}
+
+func isSystemVariable(name string) bool {
+	if systemVariableNames[name] {
+		return true
+	}
+	for _, prefix := range systemVariablePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isVariableScopeChild returns if the child at the specified position opens
+// a new variable scope. Function arguments and the operands of operators
+// are separate scopes, whereas the invocations of a path share their scope.
+func isVariableScopeChild(node antlr.RuleNode, pos int) bool {
+	switch node.(type) {
+	case *parser.ParamListContext:
+		return true
+	case *parser.IndexerExpressionContext:
+		return pos == 2
+	case *parser.MultiplicativeExpressionContext, *parser.AdditiveExpressionContext,
+		*parser.TypeExpressionContext, *parser.UnionExpressionContext,
+		*parser.InequalityExpressionContext, *parser.EqualityExpressionContext,
+		*parser.MembershipExpressionContext, *parser.AndExpressionContext,
+		*parser.OrExpressionContext, *parser.ImpliesExpressionContext:
+		return true
+	}
+	return false
+}
+
+// VisitPath visits the root expression of a path, which is the outermost
+// scope of variables.
+func (v *Visitor) VisitPath(ctx parser.IExpressionContext) interface{} {
+	return v.visitVariableScope(ctx)
+}
+
+func (v *Visitor) visitVariableScope(node antlr.Tree) interface{} {
+	scope := newVariableScope(v.variables)
+	v.variables = scope
+	res := v.evalNode(node)
+	v.variables = scope.parent
+
+	if scope.parent != nil {
+		// variables must not be defined after they have been used in a nested scope
+		for name := range scope.references {
+			scope.parent.references[name] = true
+		}
+	}
+
+	if evaluator, ok := res.(hipathsys.Evaluator); ok && len(scope.defined) > 0 {
+		return expression.NewVariableScope(evaluator)
+	}
+	return res
+}
+
+func (v *Visitor) referenceVariable(name string) {
+	if v.variables != nil && !v.variables.isDefined(name) {
+		v.variables.references[name] = true
+	}
+}
+
+func (v *Visitor) defineVariable(nameEvaluator hipathsys.Evaluator) error {
+	literal, ok := nameEvaluator.(*expression.StringLiteral)
+	if !ok {
+		return hipathsys.NewCodedError(hipathsys.InvalidArgumentsErrorCode,
+			"variable name must be a string literal")
+	}
+	value, err := literal.Evaluate(nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	name := value.(hipathsys.StringAccessor).String()
+	switch {
+	case v.variables == nil:
+		return hipathsys.NewCodedError(hipathsys.NotSupportedErrorCode,
+			"variables cannot be defined outside of a path: %s", name)
+	case isSystemVariable(name):
+		return hipathsys.NewCodedError(hipathsys.InvalidArgumentsErrorCode,
+			"system variable cannot be redefined: %s", name)
+	case v.variables.isDefined(name):
+		return hipathsys.NewCodedError(hipathsys.InvalidArgumentsErrorCode,
+			"variable has already been defined: %s", name)
+	case v.variables.references[name]:
+		return hipathsys.NewCodedError(hipathsys.UndefinedVariableErrorCode,
+			"variable has been used before it has been defined: %s", name)
+	}
+
+	v.variables.defined[name] = true
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package internal
+
+import (
+	"github.com/healthiop/hipath/hipathsys"
+	"github.com/healthiop/hipath/internal/expression"
+	"github.com/healthiop/hipath/internal/test"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func testParseVariableError(t *testing.T, pathString string, column int, code hipathsys.ErrorCode) {
+	res, errorItemCollection := testParse(pathString)
+	assert.Nil(t, res, "no result expected")
+	if assert.NotNil(t, errorItemCollection, "error item collection must have been initialized") &&
+		assert.Len(t, errorItemCollection.Items(), 1) {
+		item := errorItemCollection.Items()[0]
+		assert.Equal(t, column, item.Column())
+		assert.Equal(t, code, item.ErrorCode())
+	}
+}
+
+func TestParseDefineVariable(t *testing.T) {
+	res, errorItemCollection := testParse("defineVariable('a', 5).select(%a + 1)")
+
+	if assert.NotNil(t, errorItemCollection, "error item collection must have been initialized") {
+		assert.False(t, errorItemCollection.HasErrors(), "no errors expected")
+	}
+	if assert.IsType(t, (*expression.VariableScope)(nil), res) {
+		res, err := res.(hipathsys.Evaluator).Evaluate(test.NewTestContext(t), hipathsys.NewString("test"), nil)
+		assert.NoError(t, err, "no evaluation error expected")
+		if assert.Implements(t, (*hipathsys.CollectionAccessor)(nil), res) {
+			col := res.(hipathsys.CollectionAccessor)
+			if assert.Equal(t, 1, col.Count()) {
+				assert.Equal(t, hipathsys.NewInteger(6), col.Get(0))
+			}
+		}
+	}
+}
+
+func TestParseWithoutVariableScope(t *testing.T) {
+	res, errorItemCollection := testParse("select(%ucum)")
+
+	if assert.NotNil(t, errorItemCollection, "error item collection must have been initialized") {
+		assert.False(t, errorItemCollection.HasErrors(), "no errors expected")
+	}
+	assert.IsType(t, (*expression.InvocationTerm)(nil), res)
+}
+
+func TestParseDefineVariableSiblingScopes(t *testing.T) {
+	_, errorItemCollection := testParse(
+		"defineVariable('a', 1).select(%a) | defineVariable('a', 2).select(%a)")
+
+	if assert.NotNil(t, errorItemCollection, "error item collection must have been initialized") {
+		assert.False(t, errorItemCollection.HasErrors(), "no errors expected")
+	}
+}
+
+func TestParseDefineVariableEnvVarSiblingScope(t *testing.T) {
+	_, errorItemCollection := testParse("%a | select(defineVariable('a', 1).select(%a))")
+
+	if assert.NotNil(t, errorItemCollection, "error item collection must have been initialized") {
+		assert.False(t, errorItemCollection.HasErrors(), "no errors expected")
+	}
+}
+
+func TestParseDefineVariableRedefined(t *testing.T) {
+	testParseVariableError(t, "defineVariable('a', 1).defineVariable('a', 2)",
+		23, hipathsys.InvalidArgumentsErrorCode)
+}
+
+func TestParseDefineVariableRedefinedNested(t *testing.T) {
+	testParseVariableError(t, "defineVariable('a', 1).select(defineVariable('a', 2))",
+		30, hipathsys.InvalidArgumentsErrorCode)
+}
+
+func TestParseDefineVariableRedefinedLambda(t *testing.T) {
+	testParseVariableError(t, "defineVariable('a', 1).where(x => x.defineVariable('a', 2).exists())",
+		36, hipathsys.InvalidArgumentsErrorCode)
+}
+
+func TestParseDefineVariableUsedBefore(t *testing.T) {
+	testParseVariableError(t, "%a.defineVariable('a', 2)",
+		3, hipathsys.UndefinedVariableErrorCode)
+}
+
+func TestParseDefineVariableUsedBeforeNested(t *testing.T) {
+	testParseVariableError(t, "select(%a).defineVariable('a', 2)",
+		11, hipathsys.UndefinedVariableErrorCode)
+}
+
+func TestParseDefineVariableUsedInDefinition(t *testing.T) {
+	testParseVariableError(t, "defineVariable('a', %a)",
+		0, hipathsys.UndefinedVariableErrorCode)
+}
+
+func TestParseDefineVariableSystem(t *testing.T) {
+	testParseVariableError(t, "defineVariable('resource')", 0, hipathsys.InvalidArgumentsErrorCode)
+	testParseVariableError(t, "defineVariable('terminologies')", 0, hipathsys.InvalidArgumentsErrorCode)
+	testParseVariableError(t, "defineVariable('vs-test')", 0, hipathsys.InvalidArgumentsErrorCode)
+}
+
+func TestParseDefineVariableNameNotLiteral(t *testing.T) {
+	testParseVariableError(t, "defineVariable('a' + 'b', 1)", 0, hipathsys.InvalidArgumentsErrorCode)
+}
+
+func TestDefineVariableOutsidePath(t *testing.T) {
+	v := NewVisitor(NewErrorItemCollection())
+	err := v.defineVariable(expression.ParseStringLiteral("'a'"))
+	assert.Error(t, err, "error expected")
+}
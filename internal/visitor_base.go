@@ -29,14 +29,19 @@
 package internal
 
 import (
+	"errors"
 	"github.com/antlr/antlr4/runtime/Go/antlr"
 	"github.com/healthiop/hipath/hipathsys"
+	"github.com/healthiop/hipath/internal/expression"
 	"github.com/healthiop/hipath/internal/parser"
 )
 
 type Visitor struct {
 	parser.BaseFHIRPathVisitor
 	errorItemCollection *ErrorItemCollection
+	functions           *hipathsys.FunctionRegistry
+	lambdaParams        []string
+	variables           *variableScope
 }
 
 type visitorFunc func(ctx antlr.ParserRuleContext) (hipathsys.Evaluator, error)
@@ -48,11 +53,28 @@ func NewVisitor(errorItemCollection *ErrorItemCollection) *Visitor {
 	return v
 }
 
+func NewVisitorWithFunctions(errorItemCollection *ErrorItemCollection, functions *hipathsys.FunctionRegistry) *Visitor {
+	v := NewVisitor(errorItemCollection)
+	v.functions = functions
+	return v
+}
+
 func (v *Visitor) AddError(ctx antlr.ParserRuleContext, msg string) hipathsys.Evaluator {
 	v.errorItemCollection.AddError(ctx.GetStart().GetLine(), ctx.GetStart().GetColumn(), msg)
 	return nil
 }
 
+func (v *Visitor) addCodedError(ctx antlr.ParserRuleContext, err error) hipathsys.Evaluator {
+	code := hipathsys.SyntaxErrorCode
+	var coded hipathsys.ErrorCodeAccessor
+	if errors.As(err, &coded) {
+		code = coded.ErrorCode()
+	}
+
+	v.errorItemCollection.AddErrorWithCode(ctx.GetStart().GetLine(), ctx.GetStart().GetColumn(), err.Error(), code)
+	return nil
+}
+
 func (v *Visitor) VisitChildren(node antlr.RuleNode) interface{} {
 	count := node.GetChildCount()
 	if count == 0 {
@@ -61,7 +83,12 @@ func (v *Visitor) VisitChildren(node antlr.RuleNode) interface{} {
 
 	r := make([]interface{}, count)
 	for pos, child := range node.GetChildren() {
-		n := v.evalNode(child)
+		var n interface{}
+		if isVariableScopeChild(node, pos) {
+			n = v.visitVariableScope(child)
+		} else {
+			n = v.evalNode(child)
+		}
 		if n == nil {
 			return nil
 		}
@@ -95,9 +122,9 @@ func (v *Visitor) evalNode(node antlr.Tree) interface{} {
 
 func (v *Visitor) visit(ctx antlr.ParserRuleContext, f visitorFunc) interface{} {
 	if l, err := f(ctx); err != nil {
-		return v.AddError(ctx, err.Error())
+		return v.addCodedError(ctx, err)
 	} else {
-		return l
+		return withSourceRange(ctx, l)
 	}
 }
 
@@ -110,8 +137,27 @@ func (v *Visitor) visitTree(ctx antlr.ParserRuleContext, argCount int, f visitor
 	}
 
 	if l, err := f(ctx, args); err != nil {
-		return v.AddError(ctx, err.Error())
+		return v.addCodedError(ctx, err)
 	} else {
-		return l
+		return withSourceRange(ctx, l)
+	}
+}
+
+func withSourceRange(ctx antlr.ParserRuleContext, evaluator hipathsys.Evaluator) hipathsys.Evaluator {
+	// evaluators that are passed through keep the source range of the innermost expression
+	if n, ok := evaluator.(expression.SourceRangeAccessor); ok && n.SourceRange() == nil {
+		n.SetSourceRange(newSourceRange(ctx))
+	}
+	return evaluator
+}
+
+func newSourceRange(ctx antlr.ParserRuleContext) *hipathsys.SourceRange {
+	start := ctx.GetStart()
+	text := ctx.GetText()
+	if stop := ctx.GetStop(); stop != nil && stop.GetStop() >= start.GetStart() {
+		if input := start.GetInputStream(); input != nil {
+			text = input.GetTextFromInterval(antlr.NewInterval(start.GetStart(), stop.GetStop()))
+		}
 	}
+	return hipathsys.NewSourceRange(start.GetLine(), start.GetColumn(), text)
 }
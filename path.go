@@ -29,18 +29,42 @@
 package gohipath
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/antlr/antlr4/runtime/Go/antlr"
 	"github.com/healthiop/hipath/hipathsys"
 	"github.com/healthiop/hipath/internal"
 	"github.com/healthiop/hipath/internal/expression"
 	"github.com/healthiop/hipath/internal/parser"
+	"runtime"
+	"sync"
 )
 
+// Path is a compiled path expression. A compiled path is immutable and can be
+// executed concurrently by multiple goroutines. Each execution uses its own
+// loop state, collections and limiter. The used context (including its model
+// adapter, tracer and resolvers) must be safe for concurrent use as well.
 type Path struct {
 	evaluator expression.CollectionExpression
 }
 
+// ExecutionResult contains the result or the error of executing a path on a
+// single node.
+type ExecutionResult struct {
+	Result hipathsys.CollectionAccessor
+	Err    *hipathsys.Error
+}
+
+func NewFunctionRegistry() *hipathsys.FunctionRegistry {
+	return hipathsys.NewFunctionRegistry(expression.BuiltInFunctions)
+}
+
 func Compile(pathString string) (*Path, *hipathsys.Error) {
+	return CompileWithFunctions(pathString, nil)
+}
+
+func CompileWithFunctions(pathString string, functions *hipathsys.FunctionRegistry) (*Path, *hipathsys.Error) {
 	errorItemCollection := internal.NewErrorItemCollection()
 	errorListener := internal.NewErrorListener(errorItemCollection)
 
@@ -54,8 +78,8 @@ func Compile(pathString string) (*Path, *hipathsys.Error) {
 	p.RemoveErrorListeners()
 	p.AddErrorListener(errorListener)
 
-	v := internal.NewVisitor(errorItemCollection)
-	res := p.Expression().Accept(v)
+	v := internal.NewVisitorWithFunctions(errorItemCollection, functions)
+	res := v.VisitPath(p.Expression())
 
 	if errorItemCollection.HasErrors() {
 		return nil, hipathsys.NewError(
@@ -66,18 +90,84 @@ func Compile(pathString string) (*Path, *hipathsys.Error) {
 }
 
 func Execute(ctx hipathsys.ContextAccessor, pathString string, node interface{}) (hipathsys.CollectionAccessor, *hipathsys.Error) {
-	path, err := Compile(pathString)
-	if err != nil {
-		return nil, err
-	}
+	return DefaultPathCache.Execute(ctx, pathString, node)
+}
 
-	return path.Execute(ctx, node)
+func ExecuteContext(goCtx context.Context, ctx hipathsys.ContextAccessor, pathString string, node interface{}) (hipathsys.CollectionAccessor, *hipathsys.Error) {
+	return DefaultPathCache.ExecuteContext(goCtx, ctx, pathString, node)
 }
 
 func (p *Path) Execute(ctx hipathsys.ContextAccessor, node interface{}) (hipathsys.CollectionAccessor, *hipathsys.Error) {
+	if binder, ok := ctx.(hipathsys.ContextNodeBinder); ok {
+		ctx = binder.BindContextNode(node)
+	}
+	ctx = hipathsys.BindLimiter(ctx)
+
 	res, err := p.evaluator.Evaluate(ctx, node, nil)
 	if err != nil {
-		return nil, hipathsys.NewError(err.Error(), nil)
+		return nil, newExecutionError(err)
 	}
 	return res.(hipathsys.CollectionAccessor), nil
 }
+
+func (p *Path) ExecuteContext(goCtx context.Context, ctx hipathsys.ContextAccessor, node interface{}) (hipathsys.CollectionAccessor, *hipathsys.Error) {
+	return p.executeCancelable(hipathsys.BindGoContext(ctx, goCtx), node)
+}
+
+// ExecuteAll executes the path on all specified nodes by using the specified
+// number of workers. If the number of workers is not greater than zero, the
+// number of usable CPUs is used. The results are returned in the order of
+// the nodes. The executions that have not been completed when the Go context
+// is canceled result in an error.
+func (p *Path) ExecuteAll(goCtx context.Context, ctx hipathsys.ContextAccessor, nodes []interface{}, workers int) []ExecutionResult {
+	ctx = hipathsys.BindGoContext(ctx, goCtx)
+	results := make([]ExecutionResult, len(nodes))
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(nodes) {
+		workers = len(nodes)
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				res, err := p.executeCancelable(ctx, nodes[index])
+				results[index] = ExecutionResult{res, err}
+			}
+		}()
+	}
+
+	for index := range nodes {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+func (p *Path) executeCancelable(ctx hipathsys.ContextAccessor, node interface{}) (hipathsys.CollectionAccessor, *hipathsys.Error) {
+	if err := hipathsys.CheckCanceled(ctx); err != nil {
+		return nil, newExecutionError(err)
+	}
+
+	return p.Execute(ctx, node)
+}
+
+func newExecutionError(err error) *hipathsys.Error {
+	var evalErr *hipathsys.EvaluationError
+	if !errors.As(err, &evalErr) {
+		return hipathsys.NewErrorWithCause(err.Error(), nil, err)
+	}
+
+	source := evalErr.Source()
+	return hipathsys.NewErrorWithCause(err.Error(), []*hipathsys.ErrorItem{
+		hipathsys.NewErrorItemWithCode(source.Line(), source.Column(),
+			fmt.Sprintf("%s: %v", source.Text(), evalErr.Unwrap()), hipathsys.ErrorCodeOf(err)),
+	}, err)
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package gohipath
+
+import (
+	"container/list"
+	"context"
+	"github.com/healthiop/hipath/hipathsys"
+	"sync"
+)
+
+const DefaultPathCacheCapacity = 1000
+
+var DefaultPathCache = NewPathCache(DefaultPathCacheCapacity)
+
+// PathCache is a concurrency-safe cache of compiled paths that evicts the
+// least recently used path when its capacity has been exceeded.
+type PathCache struct {
+	lock      sync.Mutex
+	capacity  int
+	functions *hipathsys.FunctionRegistry
+	entries   map[string]*list.Element
+	order     *list.List
+	hits      uint64
+	misses    uint64
+}
+
+type PathCacheStats struct {
+	Hits     uint64
+	Misses   uint64
+	Size     int
+	Capacity int
+}
+
+type pathCacheEntry struct {
+	pathString string
+	path       *Path
+}
+
+func NewPathCache(capacity int) *PathCache {
+	return NewPathCacheWithFunctions(capacity, nil)
+}
+
+func NewPathCacheWithFunctions(capacity int, functions *hipathsys.FunctionRegistry) *PathCache {
+	if capacity < 1 {
+		panic("path cache capacity must be positive")
+	}
+
+	return &PathCache{
+		capacity:  capacity,
+		functions: functions,
+		entries:   make(map[string]*list.Element),
+		order:     list.New(),
+	}
+}
+
+func (c *PathCache) Compile(pathString string) (*Path, *hipathsys.Error) {
+	if path := c.get(pathString); path != nil {
+		return path, nil
+	}
+
+	// compilation is done outside the lock, paths that fail are not cached
+	path, err := CompileWithFunctions(pathString, c.functions)
+	if err != nil {
+		return nil, err
+	}
+	return c.put(pathString, path), nil
+}
+
+func (c *PathCache) Execute(ctx hipathsys.ContextAccessor, pathString string, node interface{}) (hipathsys.CollectionAccessor, *hipathsys.Error) {
+	path, err := c.Compile(pathString)
+	if err != nil {
+		return nil, err
+	}
+
+	return path.Execute(ctx, node)
+}
+
+func (c *PathCache) ExecuteContext(goCtx context.Context, ctx hipathsys.ContextAccessor, pathString string, node interface{}) (hipathsys.CollectionAccessor, *hipathsys.Error) {
+	path, err := c.Compile(pathString)
+	if err != nil {
+		return nil, err
+	}
+
+	return path.ExecuteContext(goCtx, ctx, node)
+}
+
+// Prewarm compiles and caches the specified paths. All paths are compiled
+// even if one of them fails. The first compilation error is returned.
+func (c *PathCache) Prewarm(pathStrings ...string) *hipathsys.Error {
+	var firstErr *hipathsys.Error
+	for _, pathString := range pathStrings {
+		if _, err := c.Compile(pathString); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (c *PathCache) Stats() PathCacheStats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return PathCacheStats{
+		Hits:     c.hits,
+		Misses:   c.misses,
+		Size:     c.order.Len(),
+		Capacity: c.capacity,
+	}
+}
+
+func (c *PathCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *PathCache) Clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+	c.hits = 0
+	c.misses = 0
+}
+
+func (c *PathCache) get(pathString string) *Path {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if e, found := c.entries[pathString]; found {
+		c.hits++
+		c.order.MoveToFront(e)
+		return e.Value.(*pathCacheEntry).path
+	}
+	c.misses++
+	return nil
+}
+
+func (c *PathCache) put(pathString string, path *Path) *Path {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if e, found := c.entries[pathString]; found {
+		// path has been compiled concurrently
+		c.order.MoveToFront(e)
+		return e.Value.(*pathCacheEntry).path
+	}
+
+	c.entries[pathString] = c.order.PushFront(&pathCacheEntry{pathString, path})
+	for c.order.Len() > c.capacity {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.entries, e.Value.(*pathCacheEntry).pathString)
+	}
+	return path
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2020-2021, Volker Schmidt (volker@volsch.eu)
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+//    contributors may be used to endorse or promote products derived from
+//    this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package gohipath
+
+import (
+	"context"
+	"fmt"
+	"github.com/healthiop/hipath/hipathsys"
+	"github.com/healthiop/hipath/internal/test"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func TestNewPathCacheInvalidCapacity(t *testing.T) {
+	assert.Panics(t, func() { NewPathCache(0) })
+}
+
+func TestPathCacheCompile(t *testing.T) {
+	c := NewPathCache(10)
+
+	p1, err := c.Compile("1 + 2")
+	assert.Nil(t, err, "no error expected")
+	assert.NotNil(t, p1, "path expected")
+	p2, err := c.Compile("1 + 2")
+	assert.Nil(t, err, "no error expected")
+	assert.Same(t, p1, p2)
+
+	assert.Equal(t, PathCacheStats{Hits: 1, Misses: 1, Size: 1, Capacity: 10}, c.Stats())
+}
+
+func TestPathCacheCompileError(t *testing.T) {
+	c := NewPathCache(10)
+
+	p, err := c.Compile("xxx(")
+	assert.Nil(t, p, "no path expected")
+	assert.NotNil(t, err, "error expected")
+	p, err = c.Compile("xxx(")
+	assert.Nil(t, p, "no path expected")
+	assert.NotNil(t, err, "error expected")
+
+	assert.Equal(t, PathCacheStats{Hits: 0, Misses: 2, Size: 0, Capacity: 10}, c.Stats())
+}
+
+func TestPathCacheEviction(t *testing.T) {
+	c := NewPathCache(2)
+
+	p1, _ := c.Compile("1")
+	c.Compile("2")
+	c.Compile("1")
+	c.Compile("3")
+	assert.Equal(t, 2, c.Len())
+
+	p, _ := c.Compile("1")
+	assert.Same(t, p1, p, "recently used path must not be evicted")
+	c.Compile("2")
+	assert.Equal(t, PathCacheStats{Hits: 2, Misses: 4, Size: 2, Capacity: 2}, c.Stats())
+}
+
+func TestPathCacheFunctions(t *testing.T) {
+	functions := NewFunctionRegistry()
+	err := functions.Register(&testGreetingFunction{
+		BaseFunction: hipathsys.NewBaseFunction("greeting", -1, 1, 1),
+	})
+	assert.NoError(t, err, "no error expected")
+	c := NewPathCacheWithFunctions(10, functions)
+
+	res, executeErr := c.Execute(test.NewTestContext(t), "greeting('Hello')", hipathsys.NewString("World"))
+	assert.Nil(t, executeErr, "no error expected")
+	if assert.NotNil(t, res, "result expected") && assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.NewString("Hello World"), res.Get(0))
+	}
+}
+
+func TestPathCachePrewarm(t *testing.T) {
+	c := NewPathCache(10)
+
+	err := c.Prewarm("1", "xxx(", "2", "yyy(")
+	if assert.NotNil(t, err, "error expected") && assert.Len(t, err.Items(), 1) {
+		assert.Equal(t, 1, err.Items()[0].Line())
+	}
+	assert.Equal(t, 2, c.Len())
+
+	c.Compile("2")
+	assert.Equal(t, PathCacheStats{Hits: 1, Misses: 4, Size: 2, Capacity: 10}, c.Stats())
+}
+
+func TestPathCachePrewarmNoError(t *testing.T) {
+	c := NewPathCache(10)
+
+	assert.Nil(t, c.Prewarm("1", "2"), "no error expected")
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestPathCacheClear(t *testing.T) {
+	c := NewPathCache(10)
+	c.Compile("1")
+	c.Compile("1")
+
+	c.Clear()
+	assert.Equal(t, PathCacheStats{Capacity: 10}, c.Stats())
+}
+
+func TestPathCacheExecute(t *testing.T) {
+	c := NewPathCache(10)
+	ctx := test.NewTestContext(t)
+
+	for i := 0; i < 2; i++ {
+		res, err := c.Execute(ctx, "length()", hipathsys.NewString("test"))
+		assert.Nil(t, err, "no error expected")
+		if assert.NotNil(t, res, "result expected") && assert.Equal(t, 1, res.Count()) {
+			assert.Equal(t, hipathsys.NewInteger(4), res.Get(0))
+		}
+	}
+	assert.Equal(t, PathCacheStats{Hits: 1, Misses: 1, Size: 1, Capacity: 10}, c.Stats())
+}
+
+func TestPathCacheExecuteError(t *testing.T) {
+	c := NewPathCache(10)
+
+	res, err := c.Execute(test.NewTestContext(t), "xxx(", nil)
+	assert.Nil(t, res, "no result expected")
+	assert.NotNil(t, err, "error expected")
+}
+
+func TestPathCacheExecuteContext(t *testing.T) {
+	c := NewPathCache(10)
+
+	res, err := c.ExecuteContext(context.Background(), test.NewTestContext(t), "length()", hipathsys.NewString("test"))
+	assert.Nil(t, err, "no error expected")
+	if assert.NotNil(t, res, "result expected") && assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.NewInteger(4), res.Get(0))
+	}
+}
+
+func TestPathCacheExecuteContextError(t *testing.T) {
+	c := NewPathCache(10)
+
+	res, err := c.ExecuteContext(context.Background(), test.NewTestContext(t), "xxx(", nil)
+	assert.Nil(t, res, "no result expected")
+	assert.NotNil(t, err, "error expected")
+}
+
+func TestPathCacheConcurrent(t *testing.T) {
+	c := NewPathCache(5)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				p, err := c.Compile(fmt.Sprintf("%d + %d", i%3, j%10))
+				assert.Nil(t, err, "no error expected")
+				assert.NotNil(t, p, "path expected")
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	stats := c.Stats()
+	assert.Equal(t, uint64(400), stats.Hits+stats.Misses)
+	assert.Equal(t, 5, stats.Size)
+}
+
+func TestExecuteUsesDefaultPathCache(t *testing.T) {
+	DefaultPathCache.Clear()
+	defer DefaultPathCache.Clear()
+
+	Execute(test.NewTestContext(t), "'default cache'", nil)
+	Execute(test.NewTestContext(t), "'default cache'", nil)
+	assert.Equal(t, uint64(1), DefaultPathCache.Stats().Hits)
+}
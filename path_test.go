@@ -29,10 +29,15 @@
 package gohipath
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/healthiop/hipath/hipathsys"
 	"github.com/healthiop/hipath/internal/test"
 	"github.com/stretchr/testify/assert"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestCompileLiteral(t *testing.T) {
@@ -112,7 +117,799 @@ func TestExecuteErrorExecute(t *testing.T) {
 	ctx := test.NewTestContext(t)
 	res, err := Execute(ctx, "union('value2' | 'value8').single()", nil)
 	if assert.NotNil(t, err, "error expected") {
-		assert.Nil(t, err.Items(), "no items expected")
+		if assert.Len(t, err.Items(), 1) {
+			assert.Equal(t, 1, err.Items()[0].Line())
+			assert.Equal(t, 27, err.Items()[0].Column())
+			assert.Contains(t, err.Items()[0].Msg(), "single(): ")
+		}
+		var evalErr *hipathsys.EvaluationError
+		if assert.True(t, errors.As(err, &evalErr), "evaluation error expected") {
+			assert.Equal(t, "single()", evalErr.Source().Text())
+		}
+	}
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestCompileErrorCodeSyntax(t *testing.T) {
+	_, err := Compile("xxx$#@yyy")
+	if assert.NotNil(t, err, "error expected") {
+		assert.Equal(t, hipathsys.SyntaxErrorCode, err.ErrorCode())
+		assert.True(t, errors.Is(err, hipathsys.ErrSyntax))
+	}
+}
+
+func TestCompileErrorCodeUnknownFunction(t *testing.T) {
+	_, err := Compile("'test'.xxx()")
+	if assert.NotNil(t, err, "error expected") {
+		assert.Equal(t, hipathsys.UnknownFunctionErrorCode, err.ErrorCode())
+		assert.True(t, errors.Is(err, hipathsys.ErrUnknownFunction))
+		assert.False(t, errors.Is(err, hipathsys.ErrSyntax))
+	}
+}
+
+func TestCompileErrorCodeInvalidArguments(t *testing.T) {
+	_, err := Compile("'test'.length(1)")
+	if assert.NotNil(t, err, "error expected") {
+		assert.True(t, errors.Is(err, hipathsys.ErrInvalidArguments))
+	}
+}
+
+func TestExecuteErrorCodeSingleton(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	_, err := Execute(ctx, "('a' | 'b').single()", nil)
+	if assert.NotNil(t, err, "error expected") {
+		assert.Equal(t, hipathsys.SingletonErrorCode, err.ErrorCode())
+		assert.True(t, errors.Is(err, hipathsys.ErrSingleton))
+		if assert.Len(t, err.Items(), 1) {
+			assert.Equal(t, hipathsys.SingletonErrorCode, err.Items()[0].ErrorCode())
+		}
+		assert.Equal(t, "multiple-matches", err.ErrorCode().IssueType())
+	}
+}
+
+func TestExecuteErrorCodeTypeMismatch(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	_, err := Execute(ctx, "(1 | 2).where($this)", nil)
+	if assert.NotNil(t, err, "error expected") {
+		assert.True(t, errors.Is(err, hipathsys.ErrTypeMismatch))
+	}
+}
+
+func TestExecuteErrorCodeUndefinedVariable(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	_, err := Execute(ctx, "%xxx", nil)
+	if assert.NotNil(t, err, "error expected") {
+		assert.True(t, errors.Is(err, hipathsys.ErrUndefinedVariable))
+	}
+}
+
+func TestExecuteErrorCodeAdapter(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	_, err := Execute(ctx, "xxx", map[string]interface{}{})
+	if assert.NotNil(t, err, "error expected") {
+		assert.True(t, errors.Is(err, hipathsys.ErrAdapter))
+	}
+}
+
+func TestExecuteErrorExecuteSubExpression(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	res, err := Execute(ctx, "true and\n  (1 + ('a' |  'b')) = 2", nil)
+	if assert.NotNil(t, err, "error expected") {
+		if assert.Len(t, err.Items(), 1) {
+			assert.Equal(t, 2, err.Items()[0].Line())
+			assert.Equal(t, 3, err.Items()[0].Column())
+		}
+		var evalErr *hipathsys.EvaluationError
+		if assert.True(t, errors.As(err, &evalErr), "evaluation error expected") {
+			assert.Equal(t, "1 + ('a' |  'b')", evalErr.Source().Text())
+		}
+		assert.Contains(t, err.Error(), "at line 2, column 3: 1 + ('a' |  'b')")
+	}
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestExecuteErrorExecuteFunctionArg(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	res, err := Execute(ctx, "'abc'.substring((1 | 2).single())", nil)
+	if assert.NotNil(t, err, "error expected") {
+		var evalErr *hipathsys.EvaluationError
+		if assert.True(t, errors.As(err, &evalErr), "evaluation error expected") {
+			assert.Equal(t, "single()", evalErr.Source().Text())
+			assert.Equal(t, 24, evalErr.Source().Column())
+		}
+	}
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestExecuteShortCircuit(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	res, err := Execute(ctx, "('value2' | 'value8').empty() and ('value2' | 'value8').single() = 'value2'", nil)
+	assert.Nil(t, err, "no error expected")
+	if assert.NotNil(t, res, "result expected") && assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.False, res.Get(0))
+	}
+}
+
+func TestExecuteShortCircuitError(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	res, err := Execute(ctx, "('value2' | 'value8').exists() and ('value2' | 'value8').single() = 'value2'", nil)
+	assert.NotNil(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestExecuteContext(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	goCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	res, err := ExecuteContext(goCtx, ctx, "length()", hipathsys.NewString("This is a test!"))
+	assert.Nil(t, err, "no error expected")
+	if assert.NotNil(t, res, "result expected") && assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.NewInteger(15), res.Get(0))
+	}
+}
+
+func TestExecuteContextErrorCompile(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	res, err := ExecuteContext(context.Background(), ctx, "xxx$#@yyy", nil)
+	assert.NotNil(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestExecuteContextCanceled(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	goCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res, err := ExecuteContext(goCtx, ctx, "'test'", nil)
+	if assert.NotNil(t, err, "error expected") {
+		assert.True(t, errors.Is(err, context.Canceled))
+	}
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestExecuteContextDeadlineExceeded(t *testing.T) {
+	ctx := test.NewTestContext(t)
+	goCtx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	path, compileErr := Compile("(1 | 2 | 3).where($this > 1).count()")
+	if assert.Nil(t, compileErr, "no error expected") {
+		res, err := path.ExecuteContext(goCtx, ctx, nil)
+		if assert.NotNil(t, err, "error expected") {
+			assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		}
+		assert.Nil(t, res, "no result expected")
+	}
+}
+
+func TestExecuteLimits(t *testing.T) {
+	ctx := hipathsys.NewContext(test.NewTestModel(t), hipathsys.WithLimits(hipathsys.Limits{MaxSteps: 3}))
+	path, compileErr := Compile("(1 | 2 | 3 | 4).where($this > 1).count()")
+	if assert.Nil(t, compileErr, "no error expected") {
+		res, err := path.Execute(ctx, nil)
+		assert.Nil(t, res, "no result expected")
+		var limitErr *hipathsys.LimitError
+		if assert.True(t, errors.As(err, &limitErr), "limit error expected") {
+			assert.Equal(t, hipathsys.StepsLimitName, limitErr.Name())
+		}
+	}
+}
+
+func TestExecuteLimitsPerExecution(t *testing.T) {
+	ctx := hipathsys.NewContext(test.NewTestModel(t), hipathsys.WithLimits(hipathsys.Limits{MaxSteps: 5}))
+	path, compileErr := Compile("(1 | 2).where($this > 1).count()")
+	if assert.Nil(t, compileErr, "no error expected") {
+		for i := 0; i < 3; i++ {
+			res, err := path.Execute(ctx, nil)
+			assert.Nil(t, err, "no error expected")
+			if assert.NotNil(t, res, "result expected") && assert.Equal(t, 1, res.Count()) {
+				assert.Equal(t, hipathsys.NewInteger(1), res.Get(0))
+			}
+		}
+	}
+}
+
+func TestExecuteLimitsCollectionSize(t *testing.T) {
+	ctx := hipathsys.NewContext(test.NewTestModel(t), hipathsys.WithLimits(hipathsys.Limits{MaxCollectionSize: 3}))
+	res, err := Execute(ctx, "(1 | 2 | 3 | 4)", nil)
+	assert.Nil(t, res, "no result expected")
+	var limitErr *hipathsys.LimitError
+	if assert.True(t, errors.As(err, &limitErr), "limit error expected") {
+		assert.Equal(t, hipathsys.CollectionSizeLimitName, limitErr.Name())
+	}
+}
+
+func TestExecuteLimitsCollectionSizeFunctions(t *testing.T) {
+	ctx := hipathsys.NewContext(test.NewTestModel(t), hipathsys.WithLimits(hipathsys.Limits{MaxCollectionSize: 3}))
+	for _, path := range []string{"'abcdef'.toChars()", "'a,b,c,d,e'.split(',')"} {
+		res, err := Execute(ctx, path, nil)
+		assert.Nil(t, res, "no result expected: %s", path)
+		if assert.NotNil(t, err, "error expected: %s", path) {
+			assert.True(t, errors.Is(err, hipathsys.ErrLimitExceeded))
+		}
+	}
+}
+
+func TestExecuteLazyErrorsRightOperand(t *testing.T) {
+	ctx := hipathsys.NewContext(test.NewTestModel(t), hipathsys.WithLazyErrors(true))
+	res, err := Execute(ctx, "{} and (1 | 2).single()", nil)
+	assert.Nil(t, err, "no error expected")
+	if assert.NotNil(t, res, "result expected") {
+		assert.True(t, res.Empty(), "empty result expected")
+	}
+
+	res, err = Execute(ctx, "true and (1 | 2).single()", nil)
+	assert.NotNil(t, err, "error expected")
+	assert.Nil(t, res, "no result expected")
+}
+
+func TestExecuteLoopVariablesOutsideLoop(t *testing.T) {
+	for _, expr := range []string{"$index", "$total", "defineVariable('v', 1).iif(true, $total)",
+		"defineVariable('v', 1).iif(true, $index)"} {
+		res, err := Execute(test.NewTestContext(t), expr, hipathsys.NewString("test"))
+		assert.Nil(t, res, "no result expected for %s", expr)
+		if assert.NotNil(t, err, "error expected for %s", expr) {
+			assert.Equal(t, hipathsys.UndefinedVariableErrorCode, hipathsys.ErrorCodeOf(err))
+		}
+	}
+}
+
+func TestExecuteContextNode(t *testing.T) {
+	ctx := hipathsys.NewContext(test.NewTestModel(t))
+	res, err := Execute(ctx, "%context.length() + %resource.length() + %rootResource.length()",
+		hipathsys.NewString("test"))
+	assert.Nil(t, err, "no error expected")
+	if assert.NotNil(t, res, "result expected") && assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.NewInteger(12), res.Get(0))
+	}
+	assert.Nil(t, ctx.ContextNode(), "context must not be modified")
+}
+
+func TestExecuteContextNodeDefined(t *testing.T) {
+	ctx := hipathsys.NewContext(test.NewTestModel(t),
+		hipathsys.WithContextNode(hipathsys.NewString("other")))
+	res, err := Execute(ctx, "%context", hipathsys.NewString("test"))
+	assert.Nil(t, err, "no error expected")
+	if assert.NotNil(t, res, "result expected") && assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.NewString("other"), res.Get(0))
+	}
+}
+
+type testGreetingFunction struct {
+	hipathsys.BaseFunction
+}
+
+func (f *testGreetingFunction) Execute(_ hipathsys.ContextAccessor, node interface{}, args []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	name, ok1 := node.(hipathsys.StringAccessor)
+	greeting, ok2 := args[0].(hipathsys.StringAccessor)
+	if !ok1 || !ok2 {
+		return nil, nil
+	}
+	return hipathsys.NewString(greeting.String() + " " + name.String()), nil
+}
+
+func TestCompileWithFunctions(t *testing.T) {
+	functions := NewFunctionRegistry()
+	err := functions.Register(&testGreetingFunction{
+		BaseFunction: hipathsys.NewBaseFunction("greeting", -1, 1, 1),
+	})
+	assert.NoError(t, err, "no error expected")
+
+	path, compileErr := CompileWithFunctions("greeting('Hello').length() > length()", functions)
+	if assert.Nil(t, compileErr, "no error expected") {
+		res, executeErr := path.Execute(test.NewTestContext(t), hipathsys.NewString("World"))
+		assert.Nil(t, executeErr, "no error expected")
+		if assert.NotNil(t, res, "result expected") && assert.Equal(t, 1, res.Count()) {
+			assert.Equal(t, hipathsys.True, res.Get(0))
+		}
+	}
+}
+
+func TestCompileWithFunctionsOverride(t *testing.T) {
+	functions := NewFunctionRegistry()
+	f := &testGreetingFunction{
+		BaseFunction: hipathsys.NewBaseFunction("trace", -1, 1, 1),
+	}
+	assert.Error(t, functions.Register(f), "error expected")
+	assert.NoError(t, functions.Override(f), "no error expected")
+
+	res, err := executeWithFunctions(t, functions, "trace('Hello')", hipathsys.NewString("World"))
+	assert.Nil(t, err, "no error expected")
+	if assert.NotNil(t, res, "result expected") && assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.NewString("Hello World"), res.Get(0))
+	}
+}
+
+type testArgFunction struct {
+	hipathsys.BaseFunction
+}
+
+func (f *testArgFunction) Execute(_ hipathsys.ContextAccessor, _ interface{}, args []interface{}, _ hipathsys.Looper) (interface{}, error) {
+	return args[0], nil
+}
+
+func TestCompileWithFunctionsOverrideSort(t *testing.T) {
+	functions := NewFunctionRegistry()
+	assert.NoError(t, functions.Override(&testArgFunction{
+		BaseFunction: hipathsys.NewBaseFunction("sort", -1, 1, 1),
+	}), "no error expected")
+
+	res, err := executeWithFunctions(t, functions, "sort(-length())", hipathsys.NewString("test"))
+	assert.Nil(t, err, "no error expected")
+	if assert.NotNil(t, res, "result expected") && assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.NewInteger(-4), res.Get(0))
+	}
+}
+
+func TestCompileWithFunctionsNotDefined(t *testing.T) {
+	path, err := CompileWithFunctions("greeting('Hello')", NewFunctionRegistry())
+	assert.Nil(t, path, "no path expected")
+	assert.NotNil(t, err, "error expected")
+}
+
+func executeWithFunctions(t *testing.T, functions *hipathsys.FunctionRegistry, pathString string, node interface{}) (hipathsys.CollectionAccessor, *hipathsys.Error) {
+	path, err := CompileWithFunctions(pathString, functions)
+	if err != nil {
+		return nil, err
+	}
+	return path.Execute(test.NewTestContext(t), node)
+}
+
+func TestExecuteAll(t *testing.T) {
+	path, compileErr := Compile("length() + (1 | 2 | 3).where($this > 1).select($this * 2).aggregate($total + $this, 0)")
+	if !assert.Nil(t, compileErr, "no error expected") {
+		return
+	}
+
+	nodes := make([]interface{}, 200)
+	for i := range nodes {
+		nodes[i] = hipathsys.NewString(fmt.Sprintf("%0*d", i%20+1, 0))
+	}
+
+	results := path.ExecuteAll(context.Background(), test.NewTestContext(t), nodes, 8)
+	if assert.Len(t, results, len(nodes)) {
+		for i, r := range results {
+			assert.Nil(t, r.Err, "no error expected")
+			if assert.NotNil(t, r.Result, "result expected") && assert.Equal(t, 1, r.Result.Count()) {
+				assert.Equal(t, hipathsys.NewInteger(int32(i%20+11)), r.Result.Get(0))
+			}
+		}
+	}
+}
+
+func TestExecuteAllDefaultWorkers(t *testing.T) {
+	path, compileErr := Compile("length()")
+	if !assert.Nil(t, compileErr, "no error expected") {
+		return
+	}
+
+	results := path.ExecuteAll(context.Background(), test.NewTestContext(t), []interface{}{
+		hipathsys.NewString("a"), hipathsys.NewString("abc")}, 0)
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, hipathsys.NewInteger(1), results[0].Result.Get(0))
+		assert.Equal(t, hipathsys.NewInteger(3), results[1].Result.Get(0))
+	}
+}
+
+func TestExecuteAllEmpty(t *testing.T) {
+	path, compileErr := Compile("length()")
+	if assert.Nil(t, compileErr, "no error expected") {
+		assert.Empty(t, path.ExecuteAll(context.Background(), test.NewTestContext(t), nil, 4))
+	}
+}
+
+func TestExecuteAllItemError(t *testing.T) {
+	path, compileErr := Compile("single()")
+	if !assert.Nil(t, compileErr, "no error expected") {
+		return
+	}
+
+	c := hipathsys.NewCollection(test.NewTestModel(t))
+	c.Add(hipathsys.NewString("a"))
+	c.Add(hipathsys.NewString("b"))
+	results := path.ExecuteAll(context.Background(), test.NewTestContext(t), []interface{}{
+		hipathsys.NewString("a"), c, hipathsys.NewString("c")}, 2)
+	if assert.Len(t, results, 3) {
+		assert.Nil(t, results[0].Err, "no error expected")
+		assert.Equal(t, hipathsys.NewString("a"), results[0].Result.Get(0))
+		assert.Nil(t, results[1].Result, "no result expected")
+		if assert.NotNil(t, results[1].Err, "error expected") {
+			assert.True(t, errors.Is(results[1].Err, hipathsys.ErrSingleton))
+		}
+		assert.Nil(t, results[2].Err, "no error expected")
+		assert.Equal(t, hipathsys.NewString("c"), results[2].Result.Get(0))
+	}
+}
+
+func TestExecuteAllLimits(t *testing.T) {
+	path, compileErr := Compile("(1 | 2 | 3).select($this * 2).count()")
+	if !assert.Nil(t, compileErr, "no error expected") {
+		return
+	}
+
+	ctx := hipathsys.NewContext(test.NewTestModel(t), hipathsys.WithLimits(hipathsys.Limits{MaxSteps: 100}))
+	nodes := make([]interface{}, 100)
+	results := path.ExecuteAll(context.Background(), ctx, nodes, 4)
+	for _, r := range results {
+		assert.Nil(t, r.Err, "no error expected")
+		if assert.NotNil(t, r.Result, "result expected") && assert.Equal(t, 1, r.Result.Count()) {
+			assert.Equal(t, hipathsys.NewInteger(3), r.Result.Get(0))
+		}
+	}
+}
+
+func TestExecuteAllCanceled(t *testing.T) {
+	path, compileErr := Compile("length()")
+	if !assert.Nil(t, compileErr, "no error expected") {
+		return
+	}
+
+	goCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := path.ExecuteAll(goCtx, test.NewTestContext(t), []interface{}{
+		hipathsys.NewString("a"), hipathsys.NewString("b")}, 2)
+	if assert.Len(t, results, 2) {
+		for _, r := range results {
+			assert.Nil(t, r.Result, "no result expected")
+			if assert.NotNil(t, r.Err, "error expected") {
+				assert.True(t, errors.Is(r.Err, context.Canceled))
+			}
+		}
+	}
+}
+
+func TestExecuteSharedPathConcurrent(t *testing.T) {
+	path, compileErr := Compile("(1 | 2 | 3).repeat(iif($this < 10, $this + 3, {})).where($this > %limit).count()")
+	if !assert.Nil(t, compileErr, "no error expected") {
+		return
+	}
+
+	ctx := hipathsys.NewContext(test.NewTestModel(t), hipathsys.WithLimits(hipathsys.Limits{MaxDepth: 20}),
+		hipathsys.WithEnvVar("limit", hipathsys.NewInteger(5)))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				res, err := path.Execute(ctx, nil)
+				assert.Nil(t, err, "no error expected")
+				if assert.NotNil(t, res, "result expected") && assert.Equal(t, 1, res.Count()) {
+					assert.Equal(t, hipathsys.NewInteger(7), res.Get(0))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func executeLambda(t *testing.T, pathString string) []interface{} {
+	res, err := Execute(test.NewTestContext(t), pathString, nil)
+	if !assert.Nil(t, err, "no error expected") || res == nil {
+		return nil
+	}
+
+	items := make([]interface{}, res.Count())
+	for i := range items {
+		items[i] = res.Get(i)
+	}
+	return items
+}
+
+func TestExecuteLambda(t *testing.T) {
+	assert.Equal(t, []interface{}{hipathsys.NewInteger(3), hipathsys.NewInteger(7)},
+		executeLambda(t, "(1 | 2 | 3 | 7).where(x => x > 2)"))
+	assert.Equal(t, []interface{}{hipathsys.NewInteger(10), hipathsys.NewInteger(20)},
+		executeLambda(t, "(1 | 2).select(x => x * 10)"))
+	assert.Equal(t, []interface{}{hipathsys.NewInteger(10), hipathsys.NewInteger(20)},
+		executeLambda(t, "(1 | 2).select(=> $this * 10)"))
+	assert.Equal(t, []interface{}{hipathsys.True},
+		executeLambda(t, "(1 | 2 | 3).all(x => x > 0)"))
+	assert.Equal(t, []interface{}{hipathsys.NewInteger(6)},
+		executeLambda(t, "(1 | 2 | 3).aggregate(x => $total + x, 0)"))
+	assert.Equal(t, []interface{}{hipathsys.NewInteger(3)},
+		executeLambda(t, "(1 | 2).select(x => x + $index + 1).repeat(y => iif(y < 5, y + 1, {})).count()"))
+}
+
+func TestExecuteLambdaTwoParams(t *testing.T) {
+	assert.Equal(t, []interface{}{hipathsys.NewInteger(16)},
+		executeLambda(t, "(1 | 2 | 3).aggregate((sum, x) => sum + x, 10)"))
+	assert.Equal(t, []interface{}{hipathsys.NewInteger(3)},
+		executeLambda(t, "(1 | 2 | 3).aggregate((max, x) => iif(max.empty() or x > max, x, max))"))
+}
+
+func TestExecuteLambdaNoFunctionArg(t *testing.T) {
+	for _, pathString := range []string{
+		"x => 1",
+		"(1 | 2 | 3).where($this > 1 and x => x > 2)",
+		"iif(true, x => 1, 2)",
+	} {
+		res, err := Execute(test.NewTestContext(t), pathString, nil)
+		assert.Nil(t, res, "no result expected: %s", pathString)
+		assert.NotNil(t, err, "error expected: %s", pathString)
+	}
+}
+
+func TestExecuteLambdaNested(t *testing.T) {
+	assert.Equal(t, []interface{}{hipathsys.NewInteger(11), hipathsys.NewInteger(21),
+		hipathsys.NewInteger(12), hipathsys.NewInteger(22)},
+		executeLambda(t, "(1 | 2).select(x => (10 | 20).select(y => x + y))"))
+	assert.Equal(t, []interface{}{hipathsys.NewInteger(3), hipathsys.NewInteger(4), hipathsys.NewInteger(4)},
+		executeLambda(t, "(1 | 2).select(x => (3 | 4).where($this > x + 1))"))
+	assert.Equal(t, []interface{}{hipathsys.NewInteger(2)},
+		executeLambda(t, "(1 | 2).where(x => x.where(x => x > 1).exists())"))
+}
+
+func TestExecuteLambdaOuterThis(t *testing.T) {
+	assert.Equal(t, []interface{}{hipathsys.NewInteger(20)},
+		executeLambda(t, "(1 | 2).select((10 | 20).where(y => y > $this * 10))"))
+}
+
+func TestExecuteLambdaOuterThisUndefined(t *testing.T) {
+	res, err := Execute(test.NewTestContext(t), "(1 | 2).where(x => $this > 1)", nil)
+	assert.Nil(t, res, "no result expected")
+	if assert.NotNil(t, err, "error expected") {
+		assert.True(t, errors.Is(err, hipathsys.ErrUndefinedVariable))
+	}
+}
+
+func TestExecuteLambdaSyntaxError(t *testing.T) {
+	res, err := Execute(test.NewTestContext(t), "(1 | 2).where(x => )", nil)
+	assert.Nil(t, res, "no result expected")
+	if assert.NotNil(t, err, "error expected") && assert.Len(t, err.Items(), 1) {
+		assert.Equal(t, 19, err.Items()[0].Column())
+	}
+}
+
+func TestExecuteLambdaErrorSource(t *testing.T) {
+	res, err := Execute(test.NewTestContext(t), "(1 | 2).select(x => (x | 3).single())", nil)
+	assert.Nil(t, res, "no result expected")
+	if assert.NotNil(t, err, "error expected") && assert.Len(t, err.Items(), 1) {
+		assert.Equal(t, 28, err.Items()[0].Column())
+		assert.Contains(t, err.Items()[0].Msg(), "single()")
+	}
+}
+
+type testCountIfFunction struct {
+	hipathsys.BaseFunction
+}
+
+func (f *testCountIfFunction) Execute(ctx hipathsys.ContextAccessor, node interface{}, _ []interface{}, loop hipathsys.Looper) (interface{}, error) {
+	col, ok := node.(hipathsys.CollectionAccessor)
+	if !ok {
+		return nil, nil
+	}
+
+	count := 0
+	for i := 0; i < col.Count(); i++ {
+		this := col.Get(i)
+		loop.IncIndex(this)
+		res, err := loop.Evaluator().Evaluate(ctx, this, loop)
+		if err != nil {
+			return nil, err
+		}
+		if b, ok := res.(hipathsys.BooleanAccessor); ok && b.Bool() {
+			count++
+		}
 	}
+	return hipathsys.NewInteger(int32(count)), nil
+}
+
+func TestExecuteLambdaCustomFunction(t *testing.T) {
+	functions := NewFunctionRegistry()
+	err := functions.Register(&testCountIfFunction{
+		BaseFunction: hipathsys.NewBaseFunction("countIf", 0, 1, 1),
+	})
+	assert.NoError(t, err, "no error expected")
+
+	res, executeErr := executeWithFunctions(t, functions,
+		"(1 | 2 | 3).select(x => (1 | 2 | 3 | 4).countIf(y => y > x))", nil)
+	assert.Nil(t, executeErr, "no error expected")
+	if assert.NotNil(t, res, "result expected") && assert.Equal(t, 3, res.Count()) {
+		assert.Equal(t, hipathsys.NewInteger(3), res.Get(0))
+		assert.Equal(t, hipathsys.NewInteger(2), res.Get(1))
+		assert.Equal(t, hipathsys.NewInteger(1), res.Get(2))
+	}
+}
+
+func TestExecuteDefineVariable(t *testing.T) {
+	res, err := Execute(test.NewTestContext(t),
+		"defineVariable('a', length()).defineVariable('b', %a * 2).select(%a + %b)", hipathsys.NewString("test"))
+	assert.Nil(t, err, "no error expected")
+	if assert.NotNil(t, res, "result expected") && assert.Equal(t, 1, res.Count()) {
+		assert.Equal(t, hipathsys.NewInteger(12), res.Get(0))
+	}
+}
+
+func TestExecuteDefineVariableLoop(t *testing.T) {
+	res, err := Execute(test.NewTestContext(t),
+		"defineVariable('f', 10).select((1 | 2 | 3).select(defineVariable('v', $this * %f).select(%v + $this)))",
+		hipathsys.NewString("test"))
+	assert.Nil(t, err, "no error expected")
+	if assert.NotNil(t, res, "result expected") && assert.Equal(t, 3, res.Count()) {
+		assert.Equal(t, hipathsys.NewInteger(11), res.Get(0))
+		assert.Equal(t, hipathsys.NewInteger(22), res.Get(1))
+		assert.Equal(t, hipathsys.NewInteger(33), res.Get(2))
+	}
+}
+
+func TestExecuteDefineVariableOutOfScope(t *testing.T) {
+	res, err := Execute(test.NewTestContext(t),
+		"select(defineVariable('v', 1)).select(%v)", hipathsys.NewString("test"))
+	assert.Nil(t, res, "no result expected")
+	if assert.NotNil(t, err, "error expected") {
+		assert.True(t, errors.Is(err, hipathsys.ErrUndefinedVariable))
+	}
+}
+
+func TestExecuteDefineVariableEnvVar(t *testing.T) {
+	ctx := hipathsys.NewContext(test.NewTestModel(t), hipathsys.WithEnvVar("a", hipathsys.NewInteger(1)))
+	res, err := Execute(ctx, "defineVariable('a', 2)", hipathsys.NewString("test"))
+	assert.Nil(t, res, "no result expected")
+	if assert.NotNil(t, err, "error expected") {
+		assert.True(t, errors.Is(err, hipathsys.ErrInvalidArguments))
+	}
+}
+
+func TestExecuteDefineVariableRedefined(t *testing.T) {
+	res, err := Execute(test.NewTestContext(t),
+		"defineVariable('a', 1).select(defineVariable('a', 2))", hipathsys.NewString("test"))
+	assert.Nil(t, res, "no result expected")
+	if assert.NotNil(t, err, "error expected") && assert.Len(t, err.Items(), 1) {
+		assert.Equal(t, 30, err.Items()[0].Column())
+		assert.True(t, errors.Is(err, hipathsys.ErrInvalidArguments))
+	}
+}
+
+func TestExecuteAllDefineVariable(t *testing.T) {
+	path, compileErr := Compile("defineVariable('l', length()).select(%l * %l)")
+	if !assert.Nil(t, compileErr, "no error expected") {
+		return
+	}
+
+	nodes := make([]interface{}, 50)
+	for i := range nodes {
+		nodes[i] = hipathsys.NewString(fmt.Sprintf("%0*d", i%5+1, 0))
+	}
+	for i, r := range path.ExecuteAll(context.Background(), test.NewTestContext(t), nodes, 4) {
+		assert.Nil(t, r.Err, "no error expected")
+		if assert.NotNil(t, r.Result, "result expected") && assert.Equal(t, 1, r.Result.Count()) {
+			assert.Equal(t, hipathsys.NewInteger(int32((i%5+1)*(i%5+1))), r.Result.Get(0))
+		}
+	}
+}
+
+func executeStrings(t *testing.T, pathString string) []string {
+	items := executeLambda(t, pathString)
+	values := make([]string, len(items))
+	for i, item := range items {
+		values[i] = item.(hipathsys.Stringifier).String()
+	}
+	return values
+}
+
+func TestExecuteSort(t *testing.T) {
+	assert.Equal(t, []string{"1", "2", "3"},
+		executeStrings(t, "(3 | 1 | 2).sort()"))
+	assert.Equal(t, []string{"1", "2", "3"},
+		executeStrings(t, "(3 | 1 | 2).sort($this)"))
+	assert.Equal(t, []string{"3", "2", "1"},
+		executeStrings(t, "(3 | 1 | 2).sort(-$this)"))
+	assert.Equal(t, []string{"c", "ab", "bb", "abc"},
+		executeStrings(t, "('abc' | 'bb' | 'c' | 'ab').sort(length(), $this)"))
+	assert.Equal(t, []string{"abc", "bb", "ab", "c"},
+		executeStrings(t, "('abc' | 'bb' | 'c' | 'ab').sort(-length(), -$this)"))
+	assert.Empty(t, executeLambda(t, "{}.sort()"))
+}
+
+func TestExecuteSortNegatedParenthesized(t *testing.T) {
+	assert.Equal(t, []string{"b", "c", "a"},
+		executeStrings(t, "('c' | 'b' | 'a').sort(-(iif($this = 'b', {}, $this.length())))"))
+	assert.Equal(t, []string{"c", "a", "b"},
+		executeStrings(t, "('c' | 'b' | 'a').sort(-iif($this = 'b', {}, $this.length()))"))
+}
+
+func TestExecuteSortLambda(t *testing.T) {
+	assert.Equal(t, []string{"3", "2", "1"},
+		executeStrings(t, "(2 | 1 | 3).sort(x => -x)"))
+	assert.Equal(t, []string{"c", "bb", "a"},
+		executeStrings(t, "('a' | 'c' | 'bb').sort(x => -x)"))
+	assert.Equal(t, []string{"c", "a", "b"},
+		executeStrings(t, "('c' | 'b' | 'a').sort(x => -iif(x = 'b', {}, x))"))
+	assert.Equal(t, []string{"1", "2", "3"},
+		executeStrings(t, "(2 | 1 | 3).sort(x => -(-x))"))
+	assert.Equal(t, []string{"aaa", "c", "bb"},
+		executeStrings(t, "('aaa' | 'bb' | 'c').sort(x => x.length() mod 3)"))
+}
+
+func TestExecuteSortEmptyKeys(t *testing.T) {
+	assert.Equal(t, []string{"b", "a", "c"},
+		executeStrings(t, "('c' | 'b' | 'a').sort(iif($this = 'b', {}, $this))"))
+	assert.Equal(t, []string{"c", "a", "b"},
+		executeStrings(t, "('c' | 'b' | 'a').sort(-iif($this = 'b', {}, $this))"))
+}
+
+func TestExecuteSortTemporalPrecision(t *testing.T) {
+	assert.Equal(t, []string{"2020", "2020-03", "2020-03-01", "2020-03-01T10:00:00+00:00", "2020-04"},
+		executeStrings(t, "(@2020-03-01T10:00:00Z | @2020-04 | @2020-03-01 | @2020 | @2020-03).sort()"))
+	assert.Equal(t, []string{"10:30", "10", "09:45:00"},
+		executeStrings(t, "(@T10 | @T09:45:00 | @T10:30).sort(-$this)"))
+}
+
+func TestExecuteSortQuantity(t *testing.T) {
+	assert.Equal(t, []string{"300 'mm'", "50 'cm'", "1 'm'", "2 'm'"},
+		executeStrings(t, "(2 'm' | 50 'cm' | 300 'mm' | 1 'm').sort()"))
+}
+
+func TestExecuteSortQuantityIncommensurable(t *testing.T) {
+	res, err := Execute(test.NewTestContext(t), "(1 'm' | 50 'cm' | 2 'g').sort()", nil)
+	assert.Nil(t, res, "no result expected")
+	if assert.NotNil(t, err, "error expected") {
+		assert.True(t, errors.Is(err, hipathsys.ErrTypeMismatch))
+	}
+}
+
+func TestExecuteSortNotComparable(t *testing.T) {
+	res, err := Execute(test.NewTestContext(t), "(1 | 'a').sort()", nil)
+	assert.Nil(t, res, "no result expected")
+	if assert.NotNil(t, err, "error expected") {
+		assert.True(t, errors.Is(err, hipathsys.ErrTypeMismatch))
+	}
+}
+
+func TestExecuteSortMultipleKeyItems(t *testing.T) {
+	res, err := Execute(test.NewTestContext(t), "(1 | 2).sort(1 | 2)", nil)
+	assert.Nil(t, res, "no result expected")
+	if assert.NotNil(t, err, "error expected") {
+		assert.True(t, errors.Is(err, hipathsys.ErrSingleton))
+	}
+}
+
+func TestExecuteSumIntegerOverflow(t *testing.T) {
+	res, err := Execute(test.NewTestContext(t), "(2147483647 | 1).sum()", nil)
 	assert.Nil(t, res, "no result expected")
+	if assert.NotNil(t, err, "error expected") {
+		assert.Equal(t, hipathsys.InvalidValueErrorCode, hipathsys.ErrorCodeOf(err))
+	}
+}
+
+func TestExecuteSumMinMaxAvg(t *testing.T) {
+	assert.Equal(t, []string{"6"}, executeStrings(t, "(1 | 2 | 3).sum()"))
+	assert.Equal(t, []string{"0"}, executeStrings(t, "{}.sum()"))
+	assert.Equal(t, []string{"2.5 'm'"}, executeStrings(t, "(2 'm' | 50 'cm').sum()"))
+	assert.Equal(t, []string{"1"}, executeStrings(t, "(3 | 1 | 2).min()"))
+	assert.Equal(t, []string{"3"}, executeStrings(t, "(3 | 1 | 2).max()"))
+	assert.Equal(t, []string{"2"}, executeStrings(t, "(3 | 1 | 2).avg()"))
+	assert.Equal(t, []string{"2.3333333333333333"}, executeStrings(t, "(1 | 2 | 4).avg()"))
+	assert.Equal(t, []string{"2.5"}, executeStrings(t, "(2 | 3).avg()"))
+	assert.Equal(t, []string{"2020-03-02"}, executeStrings(t, "(@2020-03-01 | @2020-03-02).max()"))
+	assert.Equal(t, []string{"09:45"}, executeStrings(t, "(@T10:30 | @T09:45).min()"))
+	assert.Equal(t, []string{"abc"}, executeStrings(t, "('b' | 'abc').min()"))
+	assert.Equal(t, []string{"900 'mg'"}, executeStrings(t, "(1 'g' | 900 'mg').min()"))
+	assert.Empty(t, executeLambda(t, "{}.max()"))
+	assert.Empty(t, executeLambda(t, "{}.avg()"))
+	assert.Empty(t, executeLambda(t, "(@2020-03 | @2020-03-01).max()"))
+}
+
+func TestExecuteSumError(t *testing.T) {
+	res, err := Execute(test.NewTestContext(t), "(1 | 'a').sum()", nil)
+	assert.Nil(t, res, "no result expected")
+	if assert.NotNil(t, err, "error expected") {
+		assert.True(t, errors.Is(err, hipathsys.ErrTypeMismatch))
+	}
+}
+
+func TestExecuteStringFunctions(t *testing.T) {
+	assert.Equal(t, []string{"a b"}, executeStrings(t, "'  a b  '.trim()"))
+	assert.Equal(t, []string{"A", "", "Ç"}, executeStrings(t, "'A,,Ç'.split(',')"))
+	assert.Equal(t, []string{"A;B"}, executeStrings(t, "'A,B'.split(',').join(';')"))
+	assert.Equal(t, []string{"AB"}, executeStrings(t, "('A' | 'B').join()"))
+	assert.Equal(t, []string{"4"}, executeStrings(t, "'Äx Çx'.lastIndexOf('x')"))
+	assert.Equal(t, []string{"1"}, executeStrings(t, "'Äx Çx'.indexOf('x')"))
+	assert.Equal(t, []string{"x"}, executeStrings(t, "'Äx Çx'.substring('Äx Çx'.lastIndexOf('x'))"))
+	assert.Equal(t, []string{"true"}, executeStrings(t, "'abc'.matchesFull('[a-c]+')"))
+	assert.Equal(t, []string{"false"}, executeStrings(t, "'abcd'.matchesFull('[a-c]+')"))
+	assert.Equal(t, []string{"true"}, executeStrings(t, "'abcd'.matches('[a-c]+')"))
 }